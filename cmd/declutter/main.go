@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/internal/usecase/impl"
+	"github.com/House-lovers7/speadwear-go/pkg/config"
+	"github.com/House-lovers7/speadwear-go/pkg/database"
+)
+
+// declutter sends the weekly forgotten items notifications. Each user is
+// notified at most once a week, so it can run more often, e.g. daily from cron.
+func main() {
+	// 設定の読み込み
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Failed to load config:", err)
+	}
+
+	// データベース接続
+	if err := database.Connect(&cfg.Database); err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer database.Close()
+
+	repos := repository.NewContainer(database.DB)
+	declutter := impl.NewDeclutterUsecase(repos.Item, repos.User, repos.WearLog, repos.Notification, cfg)
+
+	notified, err := declutter.SendDigests(context.Background(), time.Now())
+	if err != nil {
+		log.Fatalf("Digest failed after %d users: %v", notified, err)
+	}
+	log.Printf("Sent forgotten items notifications to %d users", notified)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/House-lovers7/speadwear-go/internal/domain"
 	"github.com/House-lovers7/speadwear-go/pkg/config"
 	"github.com/House-lovers7/speadwear-go/pkg/database"
+	"gorm.io/gorm/clause"
 )
 
 func main() {
@@ -61,18 +62,95 @@ func main() {
 
 func runMigrations() error {
 	models := domain.GetAllModels()
-	return database.Migrate(models...)
+	if err := database.Migrate(models...); err != nil {
+		return err
+	}
+	if err := migrateCoordinateItems(); err != nil {
+		return err
+	}
+	return migrateDeletedCommentAuthors()
+}
+
+// migrateCoordinateItems は旧 items.coordinate_id のコーディネート紐付けを
+// coordinate_items テーブルへ移し、カラムを削除する
+func migrateCoordinateItems() error {
+	migrator := database.DB.Migrator()
+	if !migrator.HasColumn("items", "coordinate_id") {
+		return nil
+	}
+
+	var links []struct {
+		ID           uint
+		CoordinateID uint
+	}
+	err := database.DB.Table("items").
+		Select("id, coordinate_id").
+		Where("coordinate_id IS NOT NULL AND deleted_at IS NULL").
+		Order("coordinate_id, id").
+		Scan(&links).Error
+	if err != nil {
+		return err
+	}
+
+	// アイテムIDの順で並び順を振る
+	rows := make([]domain.CoordinateItem, len(links))
+	positions := make(map[uint]int)
+	for i, link := range links {
+		rows[i] = domain.CoordinateItem{
+			CoordinateID: link.CoordinateID,
+			ItemID:       link.ID,
+			Position:     positions[link.CoordinateID],
+		}
+		positions[link.CoordinateID]++
+	}
+	if len(rows) > 0 {
+		err := database.DB.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(rows, 100).Error
+		if err != nil {
+			return err
+		}
+	}
+	log.Printf("Migrated %d item-coordinate links to coordinate_items", len(rows))
+
+	return migrator.DropColumn("items", "coordinate_id")
+}
+
+// migrateDeletedCommentAuthors は削除済みアカウントのコメントに入っていた
+// user_id = 0 を NULL に置き換える
+func migrateDeletedCommentAuthors() error {
+	result := database.DB.Table("comments").Where("user_id = 0").Update("user_id", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("Cleared the author of %d comments left by deleted accounts", result.RowsAffected)
+	}
+	return nil
 }
 
 func dropTables() error {
 	// 逆順でテーブルをドロップ（外部キー制約を考慮）
 	tables := []string{
+		"password_histories",
+		"data_exports",
+		"personal_access_tokens",
+		"oauth_states",
+		"user_identities",
+		"recovery_codes",
+		"login_attempts",
+		"audit_events",
+		"refresh_tokens",
+		"sessions",
 		"notifications",
 		"blocks",
 		"relationships",
 		"like_coordinates",
 		"comments",
+		"wear_log_items",
+		"wear_logs",
+		"coordinate_items",
+		"item_status_changes",
 		"items",
+		"categories",
 		"coordinates",
 		"users",
 	}
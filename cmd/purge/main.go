@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/internal/usecase/impl"
+	"github.com/House-lovers7/speadwear-go/pkg/config"
+	"github.com/House-lovers7/speadwear-go/pkg/database"
+	"github.com/House-lovers7/speadwear-go/pkg/storage"
+)
+
+// purge permanently deletes accounts whose deletion grace period has ended.
+// Run it periodically, e.g. hourly from cron.
+func main() {
+	// 設定の読み込み
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Failed to load config:", err)
+	}
+
+	// データベース接続
+	if err := database.Connect(&cfg.Database); err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer database.Close()
+
+	repos := repository.NewContainer(database.DB)
+	uploads := storage.NewLocalStorage(cfg.Upload.Path, cfg.Upload.MaxFileSize)
+	purge := impl.NewAccountPurgeUsecase(repos.User, uploads, database.DB)
+
+	purged, err := purge.PurgeDeletedAccounts(context.Background(), time.Now())
+	if err != nil {
+		log.Fatalf("Purge failed after %d accounts: %v", purged, err)
+	}
+	log.Printf("Purged %d deleted accounts", purged)
+}
@@ -10,6 +10,11 @@ import (
 	"github.com/House-lovers7/speadwear-go/internal/usecase/impl"
 	"github.com/House-lovers7/speadwear-go/pkg/config"
 	"github.com/House-lovers7/speadwear-go/pkg/database"
+	"github.com/House-lovers7/speadwear-go/pkg/mailer"
+	"github.com/House-lovers7/speadwear-go/pkg/oidc"
+	"github.com/House-lovers7/speadwear-go/pkg/password"
+	"github.com/House-lovers7/speadwear-go/pkg/storage"
+	"github.com/House-lovers7/speadwear-go/pkg/utils"
 	"gorm.io/gorm"
 )
 
@@ -29,15 +34,33 @@ func main() {
 	// リポジトリの初期化
 	repos := repository.NewContainer(database.DB)
 
+	// メーラーの初期化
+	mail, err := mailer.New(&cfg.Mail)
+	if err != nil {
+		log.Fatal("Failed to create mailer:", err)
+	}
+
+	// JWT署名鍵の読み込み
+	keys, err := loadKeySet(&cfg.JWT)
+	if err != nil {
+		log.Fatal("Failed to load JWT keys:", err)
+	}
+
+	// パスワードポリシーの初期化
+	passwords, err := loadPasswordPolicy(&cfg.Password)
+	if err != nil {
+		log.Fatal("Failed to load breached password corpus:", err)
+	}
+
 	// ユースケースの初期化
-	usecases := createUsecaseContainer(repos, cfg, database.DB)
+	usecases := createUsecaseContainer(repos, mail, keys, passwords, cfg, database.DB)
 
 	// ルーターの設定
 	var r *gin.Engine
 	if cfg.App.Env == "development" {
-		r = router.SetupDevRouter(usecases, repos, cfg)
+		r = router.SetupDevRouter(usecases, repos, keys, cfg)
 	} else {
-		r = router.SetupRouter(usecases, repos, cfg)
+		r = router.SetupRouter(usecases, repos, keys, cfg)
 	}
 
 	// サーバーの起動
@@ -47,21 +70,92 @@ func main() {
 	}
 }
 
+// loadKeySet returns the asymmetric keys from JWT_KEYS_DIR, falling back to the HS256 secret
+func loadKeySet(cfg *config.JWTConfig) (*utils.KeySet, error) {
+	if cfg.KeysDir == "" {
+		return utils.NewHMACKeySet(cfg.Secret), nil
+	}
+	return utils.LoadKeySet(cfg.KeysDir, cfg.KeyID)
+}
+
+// loadPasswordPolicy builds the password policy, loading the breached password corpus when configured
+func loadPasswordPolicy(cfg *config.PasswordConfig) (*password.Policy, error) {
+	policy := &password.Policy{MinLength: cfg.MinLength, MinClasses: cfg.MinClasses}
+	if cfg.BreachedCorpusPath == "" {
+		return policy, nil
+	}
+	corpus, err := password.LoadCorpus(cfg.BreachedCorpusPath)
+	if err != nil {
+		return nil, err
+	}
+	policy.Corpus = corpus
+	return policy, nil
+}
+
 // createUsecaseContainer creates a usecase container with actual implementations
-func createUsecaseContainer(repos *repository.Container, cfg *config.Config, db *gorm.DB) *usecase.Container {
+func createUsecaseContainer(repos *repository.Container, mail mailer.Mailer, keys *utils.KeySet, passwords *password.Policy, cfg *config.Config, db *gorm.DB) *usecase.Container {
+	uploads := storage.NewLocalStorage(cfg.Upload.Path, cfg.Upload.MaxFileSize)
+	audit := impl.NewAuditLogger(repos.AuditEvent)
+
+	// The DB store keeps login attempt counts consistent across instances
+	loginAttempts := repos.LoginAttempt
+	if cfg.Auth.LoginAttemptStore == "memory" {
+		loginAttempts = repository.NewMemoryLoginAttemptStore()
+	}
+
+	oidcClients := make(map[string]*oidc.Client)
+	for _, provider := range cfg.OIDC.Providers {
+		oidcClients[provider.Name] = oidc.NewClient(oidc.Config{
+			Issuer:       provider.Issuer,
+			ClientID:     provider.ClientID,
+			ClientSecret: provider.ClientSecret,
+			RedirectURL:  provider.RedirectURL,
+			Scopes:       provider.Scopes,
+		}, nil)
+	}
+
 	return &usecase.Container{
-		User:       impl.NewUserUsecase(repos.User, cfg),
-		Item:       impl.NewItemUsecase(repos.Item, cfg),
+		User: impl.NewUserUsecase(
+			repos.User,
+			repos.Session,
+			repos.RefreshToken,
+			repos.RecoveryCode,
+			repos.UserIdentity,
+			repos.OAuthState,
+			repos.PasswordHistory,
+			repos.AccessToken,
+			loginAttempts,
+			oidcClients,
+			keys,
+			passwords,
+			mail,
+			audit,
+			cfg,
+		),
+		Item:     impl.NewItemUsecase(repos.Item, repos.User, repos.WearLog, repos.Category, uploads, audit, cfg),
+		Category: impl.NewCategoryUsecase(repos.Category, audit),
 		Coordinate: impl.NewCoordinateUsecase(
 			repos.Coordinate,
 			repos.Item,
+			repos.User,
 			repos.LikeCoordinate,
 			repos.Relationship,
 			repos.Block,
 			repos.Notification,
+			repos.WearLog,
+			uploads,
+			audit,
 			cfg,
 			db,
 		),
+		WearLog: impl.NewWearLogUsecase(repos.WearLog, repos.Item, repos.Coordinate, audit, cfg),
+		Declutter: impl.NewDeclutterUsecase(
+			repos.Item,
+			repos.User,
+			repos.WearLog,
+			repos.Notification,
+			cfg,
+		),
 		Social: impl.NewSocialUsecase(
 			repos.Comment,
 			repos.Relationship,
@@ -69,7 +163,22 @@ func createUsecaseContainer(repos *repository.Container, cfg *config.Config, db
 			repos.Notification,
 			repos.Coordinate,
 			repos.User,
+			audit,
+			cfg,
+		),
+		Admin: impl.NewAdminUsecase(
+			repos.User,
+			repos.Item,
+			repos.Coordinate,
+			repos.Session,
+			repos.AuditEvent,
+			uploads,
+			audit,
+			keys,
+			db,
 			cfg,
 		),
+		AccessToken: impl.NewAccessTokenUsecase(repos.AccessToken),
+		Export:      impl.NewExportUsecase(repos, mail, cfg),
 	}
 }
\ No newline at end of file
@@ -94,9 +94,193 @@ const (
 	NotificationActionFollow      = "follow"
 	NotificationActionLike        = "like"
 	NotificationActionComment     = "comment"
+
+	// NotificationActionForgottenItems is the weekly reminder of unused items;
+	// the user is both sender and receiver
+	NotificationActionForgottenItems = "forgotten_items"
+)
+
+// User roles
+const (
+	RoleUser      = "user"
+	RoleSupport   = "support"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// Permissions that can be required per route
+const (
+	PermissionAdminAccess      = "admin:access"
+	PermissionUsersRead        = "users:read"
+	PermissionUsersManage      = "users:manage"
+	PermissionContentModerate  = "content:moderate"
+	PermissionRolesManage      = "roles:manage"
+	PermissionUsersImpersonate = "users:impersonate"
+	PermissionAuditRead        = "audit:read"
+)
+
+// RolePermissions maps roles to their granted permissions.
+// Admins implicitly have every permission.
+var RolePermissions = map[string][]string{
+	RoleSupport: {
+		PermissionAdminAccess,
+		PermissionUsersRead,
+		PermissionUsersImpersonate,
+	},
+	RoleModerator: {
+		PermissionAdminAccess,
+		PermissionUsersRead,
+		PermissionContentModerate,
+	},
+}
+
+// Personal access token scopes. A write scope implies the matching read scope.
+const (
+	ScopeProfileRead        = "profile:read"
+	ScopeItemsRead          = "items:read"
+	ScopeItemsWrite         = "items:write"
+	ScopeCoordinatesRead    = "coordinates:read"
+	ScopeCoordinatesWrite   = "coordinates:write"
+	ScopeWearLogsRead       = "wear_logs:read"
+	ScopeWearLogsWrite      = "wear_logs:write"
+	ScopeSocialRead         = "social:read"
+	ScopeSocialWrite        = "social:write"
+	ScopeNotificationsRead  = "notifications:read"
+	ScopeNotificationsWrite = "notifications:write"
+)
+
+// AccessTokenScopes lists every scope a personal access token may carry
+var AccessTokenScopes = []string{
+	ScopeProfileRead,
+	ScopeItemsRead,
+	ScopeItemsWrite,
+	ScopeCoordinatesRead,
+	ScopeCoordinatesWrite,
+	ScopeWearLogsRead,
+	ScopeWearLogsWrite,
+	ScopeSocialRead,
+	ScopeSocialWrite,
+	ScopeNotificationsRead,
+	ScopeNotificationsWrite,
+}
+
+// AccessTokenPrefix marks personal access tokens so they can be told apart from JWTs
+const AccessTokenPrefix = "spw_pat_"
+
+// DeletedUserName is shown as the author of comments left by a purged account
+const DeletedUserName = "deleted user"
+
+// Data export statuses
+const (
+	ExportStatusPending    = "pending"
+	ExportStatusProcessing = "processing"
+	ExportStatusCompleted  = "completed"
+	ExportStatusFailed     = "failed"
 )
 
-// SuperItem categories
+// Audit actions
+const (
+	AuditActionUserSuspend         = "user.suspend"
+	AuditActionUserUnsuspend       = "user.unsuspend"
+	AuditActionUserDelete          = "user.delete"
+	AuditActionUserRoleChange      = "user.role_change"
+	AuditActionUserContentView     = "user.content_view"
+	AuditActionItemTakedown        = "item.takedown"
+	AuditActionCoordinateTakedown  = "coordinate.takedown"
+	AuditActionUserImpersonate     = "user.impersonate"
+	AuditActionImpersonatedRequest = "user.impersonated_request"
+
+	// Account and security
+	AuditActionUserLogin             = "user.login"
+	AuditActionUserLoginFailed       = "user.login_failed"
+	AuditActionUserLogout            = "user.logout"
+	AuditActionUserProfileUpdate     = "user.profile_update"
+	AuditActionUserPasswordChange    = "user.password_change"
+	AuditActionUserPasswordReset     = "user.password_reset"
+	AuditActionUserEmailChange       = "user.email_change"
+	AuditActionUserEmailChangeCancel = "user.email_change_cancel"
+	AuditActionUserTwoFactorEnable   = "user.2fa_enable"
+	AuditActionUserTwoFactorDisable  = "user.2fa_disable"
+	AuditActionUserDeletionRequest   = "user.deletion_request"
+	AuditActionSessionRevoke         = "session.revoke"
+
+	// Domain
+	AuditActionItemCreate       = "item.create"
+	AuditActionItemUpdate       = "item.update"
+	AuditActionItemDelete       = "item.delete"
+	AuditActionItemStatus       = "item.status_change"
+	AuditActionCategoryCreate   = "category.create"
+	AuditActionCategoryRename   = "category.rename"
+	AuditActionCategoryMerge    = "category.merge"
+	AuditActionCoordinateCreate = "coordinate.create"
+	AuditActionCoordinateUpdate = "coordinate.update"
+	AuditActionCoordinateDelete = "coordinate.delete"
+	AuditActionWearLogCreate    = "wear_log.create"
+	AuditActionWearLogUpdate    = "wear_log.update"
+	AuditActionWearLogDelete    = "wear_log.delete"
+	AuditActionCommentUpdate    = "comment.update"
+	AuditActionCommentDelete    = "comment.delete"
+	AuditActionUserFollow       = "user.follow"
+	AuditActionUserUnfollow     = "user.unfollow"
+	AuditActionUserBlock        = "user.block"
+	AuditActionUserUnblock      = "user.unblock"
+)
+
+// Audit target types
+const (
+	AuditTargetUser       = "user"
+	AuditTargetItem       = "item"
+	AuditTargetCoordinate = "coordinate"
+	AuditTargetComment    = "comment"
+	AuditTargetSession    = "session"
+	AuditTargetWearLog    = "wear_log"
+	AuditTargetCategory   = "category"
+)
+
+// DateLayout formats calendar dates such as WearLog.WornOn
+const DateLayout = "2006-01-02"
+
+// Roles an item can play within a coordinate
+const (
+	CoordinateItemRoleOuter     = "outer"
+	CoordinateItemRoleTop       = "top"
+	CoordinateItemRoleBottom    = "bottom"
+	CoordinateItemRoleDress     = "dress"
+	CoordinateItemRoleShoes     = "shoes"
+	CoordinateItemRoleBag       = "bag"
+	CoordinateItemRoleAccessory = "accessory"
+)
+
+// Item lifecycle statuses
+const (
+	ItemStatusActive   = "active"
+	ItemStatusLaundry  = "in_laundry"
+	ItemStatusLent     = "lent_out"
+	ItemStatusArchived = "archived"
+	ItemStatusDonated  = "donated"
+	ItemStatusSold     = "sold"
+)
+
+// ItemStatuses lists every item lifecycle status
+var ItemStatuses = []string{
+	ItemStatusActive,
+	ItemStatusLaundry,
+	ItemStatusLent,
+	ItemStatusArchived,
+	ItemStatusDonated,
+	ItemStatusSold,
+}
+
+// RetiredItemStatuses are the statuses of items that left the wardrobe; they
+// are kept out of default listings but can still be searched
+var RetiredItemStatuses = []string{
+	ItemStatusArchived,
+	ItemStatusDonated,
+	ItemStatusSold,
+}
+
+// SuperItem categories; every user's category tree starts with these as its
+// top-level categories
 var SuperItemCategories = []string{
 	"アウター",
 	"トップス",
@@ -109,6 +293,9 @@ var SuperItemCategories = []string{
 	"その他",
 }
 
+// MaxCategoryDepth is how many levels a category tree may have
+const MaxCategoryDepth = 5
+
 // SeasonNames maps season constants to their names
 var SeasonNames = map[int]string{
 	SeasonSpring:    "春",
@@ -1,23 +1,46 @@
 package domain
 
 import (
+	"strings"
 	"time"
 )
 
 // User represents a user in the system
 type User struct {
 	BaseModel
-	Name               string         `gorm:"type:varchar(255);not null" json:"name"`
-	Email              string         `gorm:"type:varchar(255);uniqueIndex;not null" json:"email"`
-	Picture            string         `gorm:"type:varchar(255)" json:"picture"`
-	Admin              bool           `gorm:"default:false" json:"admin"`
-	PasswordDigest     string         `gorm:"type:varchar(255)" json:"-"`
-	RememberDigest     string         `gorm:"type:varchar(255)" json:"-"`
-	ActivationDigest   string         `gorm:"type:varchar(255)" json:"-"`
-	Activated          bool           `gorm:"default:false" json:"activated"`
-	ActivatedAt        *time.Time     `json:"activated_at,omitempty"`
-	ResetDigest        string         `gorm:"type:varchar(255)" json:"-"`
-	ResetSentAt        *time.Time     `json:"reset_sent_at,omitempty"`
+	Name                string         `gorm:"type:varchar(255);not null" json:"name"`
+	Email               string         `gorm:"type:varchar(255);uniqueIndex;not null" json:"email"`
+	Picture             string         `gorm:"type:varchar(255)" json:"picture"`
+	Admin               bool           `gorm:"default:false" json:"admin"`
+	Role                string         `gorm:"type:varchar(20);default:'user';not null" json:"role"`
+	PasswordDigest      string         `gorm:"type:varchar(255)" json:"-"`
+	RememberDigest      string         `gorm:"type:varchar(255)" json:"-"`
+	ActivationDigest    string         `gorm:"type:varchar(255)" json:"-"`
+	Activated           bool           `gorm:"default:false" json:"activated"`
+	ActivatedAt         *time.Time     `json:"activated_at,omitempty"`
+	ActivationSentAt    *time.Time     `json:"-"`
+	ResetDigest         string         `gorm:"type:varchar(255)" json:"-"`
+	ResetSentAt         *time.Time     `json:"reset_sent_at,omitempty"`
+	PasswordChangedAt   *time.Time     `json:"-"`
+	UnlockDigest        string         `gorm:"type:varchar(255)" json:"-"`
+	UnlockSentAt        *time.Time     `json:"-"`
+	MagicLinkDigest     string         `gorm:"type:varchar(255)" json:"-"`
+	MagicLinkSentAt     *time.Time     `json:"-"`
+	UnconfirmedEmail    string         `gorm:"type:varchar(255)" json:"-"`
+	PreviousEmail       string         `gorm:"type:varchar(255)" json:"-"`
+	EmailChangeDigest   string         `gorm:"type:varchar(255)" json:"-"`
+	EmailRevertDigest   string         `gorm:"type:varchar(255)" json:"-"`
+	EmailChangeSentAt   *time.Time     `json:"-"`
+	TOTPSecret          string         `gorm:"type:varchar(64)" json:"-"`
+	TOTPEnabledAt       *time.Time     `json:"-"`
+	TOTPLastStep        int64          `gorm:"default:0" json:"-"`
+	SuspendedAt         *time.Time     `json:"suspended_at,omitempty"`
+	SuspendedUntil      *time.Time     `json:"suspended_until,omitempty"`
+	SuspensionReason    string         `gorm:"type:varchar(500)" json:"suspension_reason,omitempty"`
+	SuspendedBy         *uint          `json:"suspended_by,omitempty"`
+	DeletionScheduledAt *time.Time     `gorm:"index" json:"deletion_scheduled_at,omitempty"` // purge time of a requested deletion
+	DeclutterDigestDays int            `gorm:"default:0" json:"declutter_digest_days"` // weekly forgotten items reminder threshold; 0 disables
+	DeclutterNotifiedAt *time.Time     `json:"-"`
 	
 	// Relations
 	Items              []Item         `gorm:"foreignKey:UserID" json:"items,omitempty"`
@@ -31,23 +54,116 @@ type User struct {
 	ReceivedNotifications []Notification `gorm:"foreignKey:ReceiverID" json:"received_notifications,omitempty"`
 }
 
+// IsAdmin reports whether the user has full administrative rights
+func (u *User) IsAdmin() bool {
+	return u.Admin || u.Role == RoleAdmin
+}
+
+// HasPermission reports whether the user's role grants the permission
+func (u *User) HasPermission(permission string) bool {
+	if u.IsAdmin() {
+		return true
+	}
+	for _, p := range RolePermissions[u.Role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSuspended reports whether the account is suspended at the given time
+func (u *User) IsSuspended(now time.Time) bool {
+	if u.SuspendedAt == nil {
+		return false
+	}
+	return u.SuspendedUntil == nil || now.Before(*u.SuspendedUntil)
+}
+
+// DeletionPending reports whether the user has asked for their account to be deleted
+func (u *User) DeletionPending() bool {
+	return u.DeletionScheduledAt != nil
+}
+
+// TwoFactorEnabled reports whether TOTP two-factor authentication is active
+func (u *User) TwoFactorEnabled() bool {
+	return u.TOTPEnabledAt != nil && u.TOTPSecret != ""
+}
+
 // Item represents a clothing item
 type Item struct {
 	BaseModel
-	UserID       uint        `gorm:"not null;index" json:"user_id"`
-	CoordinateID *uint       `json:"coordinate_id,omitempty"`
-	SuperItem    string      `gorm:"type:varchar(100)" json:"super_item"`
-	Season       int         `json:"season"`
-	TPO          int         `json:"tpo"`
-	Color        int         `json:"color"`
-	Content      string      `gorm:"type:text" json:"content"`
-	Memo         string      `gorm:"type:text" json:"memo"`
-	Picture      string      `gorm:"type:varchar(255)" json:"picture"`
-	Rating       float32     `json:"rating"`
-	
+	UserID    uint    `gorm:"not null;index" json:"user_id"`
+	SuperItem string  `gorm:"type:varchar(100)" json:"super_item"`
+	Season    int     `json:"season"`
+	TPO       int     `json:"tpo"`
+	Color     int     `json:"color"`
+	Content   string  `gorm:"type:text" json:"content"`
+	Memo      string  `gorm:"type:text" json:"memo"`
+	Picture   string  `gorm:"type:varchar(255)" json:"picture"`
+	Rating    float32 `json:"rating"`
+	WearStats
+
+	// Category in the user's category tree; SuperItem holds the name of its
+	// top-level category and is kept in step when categories change
+	CategoryID *uint `gorm:"index" json:"category_id,omitempty"`
+
+	// Purchase details; PurchasePrice is in Currency (ISO 4217 code)
+	PurchasePrice *float64   `gorm:"type:decimal(12,2)" json:"purchase_price,omitempty"`
+	Currency      string     `gorm:"type:varchar(3)" json:"currency,omitempty"`
+	PurchasedOn   *time.Time `gorm:"type:date" json:"purchased_on,omitempty"`
+
+	// Lifecycle; only active items can be added to coordinates
+	Status          string     `gorm:"type:varchar(20);not null;default:active;index" json:"status"`
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty"`
+
 	// Relations
-	User        User        `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	Coordinate  *Coordinate `gorm:"foreignKey:CoordinateID" json:"coordinate,omitempty"`
+	User            User               `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	CoordinateItems []CoordinateItem   `gorm:"foreignKey:ItemID" json:"coordinate_items,omitempty"`
+	StatusChanges   []ItemStatusChange `gorm:"foreignKey:ItemID" json:"status_changes,omitempty"`
+}
+
+// CoordinateIDs lists the coordinates the item is part of, as far as they are loaded
+func (i *Item) CoordinateIDs() []uint {
+	ids := make([]uint, len(i.CoordinateItems))
+	for j, link := range i.CoordinateItems {
+		ids[j] = link.CoordinateID
+	}
+	return ids
+}
+
+// IsAvailable reports whether the item is in the wardrobe and ready to wear
+func (i *Item) IsAvailable() bool {
+	return i.Status == ItemStatusActive || i.Status == ""
+}
+
+// IsRetired reports whether the item has left the wardrobe
+func (i *Item) IsRetired() bool {
+	for _, status := range RetiredItemStatuses {
+		if i.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+// ItemStatusChange records an item moving from one lifecycle status to another
+type ItemStatusChange struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	ItemID     uint      `gorm:"not null;index" json:"item_id"`
+	FromStatus string    `gorm:"type:varchar(20);not null" json:"from_status"`
+	ToStatus   string    `gorm:"type:varchar(20);not null" json:"to_status"`
+	ChangedAt  time.Time `gorm:"not null" json:"changed_at"`
+}
+
+// Category is a node of a user's item category tree, e.g. トップス → シャツ →
+// オックスフォード. Position orders a category among its siblings.
+type Category struct {
+	BaseModel
+	UserID   uint   `gorm:"not null;index" json:"user_id"`
+	ParentID *uint  `gorm:"index" json:"parent_id,omitempty"`
+	Name     string `gorm:"type:varchar(100);not null" json:"name"`
+	Position int    `gorm:"not null;default:0" json:"position"`
 }
 
 // Coordinate represents an outfit coordination
@@ -68,24 +184,74 @@ type Coordinate struct {
 	SiShoeSize       int              `json:"si_shoe_size"`
 	Memo             string           `gorm:"type:text" json:"memo"`
 	Rating           float32          `json:"rating"`
+	WearStats
 	
 	// Relations
 	User            User             `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	Items           []Item           `gorm:"foreignKey:CoordinateID" json:"items,omitempty"`
+	Items           []CoordinateItem `gorm:"foreignKey:CoordinateID" json:"items,omitempty"`
 	Comments        []Comment        `gorm:"foreignKey:CoordinateID" json:"comments,omitempty"`
 	LikeCoordinates []LikeCoordinate `gorm:"foreignKey:CoordinateID" json:"like_coordinates,omitempty"`
 }
 
+// CoordinateItem places an item in a coordinate; an item can be part of any number of coordinates
+type CoordinateItem struct {
+	ID           uint      `gorm:"primarykey" json:"id"`
+	CreatedAt    time.Time `json:"created_at"`
+	CoordinateID uint      `gorm:"not null;uniqueIndex:idx_coordinate_item" json:"coordinate_id"`
+	ItemID       uint      `gorm:"not null;uniqueIndex:idx_coordinate_item;index" json:"item_id"`
+	Position     int       `gorm:"not null;default:0" json:"position"`
+	Role         string    `gorm:"type:varchar(20)" json:"role,omitempty"` // one of the CoordinateItemRole constants, or empty
+
+	// Relations
+	Item Item `gorm:"foreignKey:ItemID" json:"item,omitempty"`
+}
+
+// WearStats summarises the wear log of an item or coordinate; derived, not stored
+type WearStats struct {
+	WearCount  int64      `gorm:"-" json:"wear_count"`
+	LastWornAt *time.Time `gorm:"-" json:"last_worn_at,omitempty"`
+}
+
+// WearLog records what a user wore on a day: a coordinate, an ad-hoc set of items, or both
+type WearLog struct {
+	BaseModel
+	UserID       uint      `gorm:"not null;index:idx_wear_log_user_date" json:"user_id"`
+	WornOn       time.Time `gorm:"type:date;not null;index:idx_wear_log_user_date" json:"worn_on"`
+	CoordinateID *uint     `gorm:"index" json:"coordinate_id,omitempty"`
+	Weather      string    `gorm:"type:varchar(255)" json:"weather"`
+	Mood         int       `json:"mood"` // 1-5, 0 when not rated
+
+	// Relations
+	User       User          `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Coordinate *Coordinate   `gorm:"foreignKey:CoordinateID" json:"coordinate,omitempty"`
+	Items      []WearLogItem `gorm:"foreignKey:WearLogID" json:"items,omitempty"`
+}
+
+// WearLogItem is an item worn as part of a wear log
+type WearLogItem struct {
+	ID        uint `gorm:"primarykey" json:"id"`
+	WearLogID uint `gorm:"not null;uniqueIndex:idx_wear_log_item" json:"wear_log_id"`
+	ItemID    uint `gorm:"not null;uniqueIndex:idx_wear_log_item;index" json:"item_id"`
+
+	// Relations
+	Item Item `gorm:"foreignKey:ItemID" json:"item,omitempty"`
+}
+
 // Comment represents a comment on a coordinate
 type Comment struct {
 	BaseModel
-	UserID       uint       `gorm:"not null;index" json:"user_id"`
+	UserID       *uint      `gorm:"index" json:"user_id"` // nil once the author's account is purged
 	CoordinateID uint       `gorm:"not null;index" json:"coordinate_id"`
 	Comment      string     `gorm:"type:text;not null" json:"comment"`
 	User         User       `gorm:"foreignKey:UserID" json:"user,omitempty"`
 	Coordinate   Coordinate `gorm:"foreignKey:CoordinateID" json:"coordinate,omitempty"`
 }
 
+// AuthoredBy reports whether the comment was written by userID
+func (c *Comment) AuthoredBy(userID uint) bool {
+	return c.UserID != nil && *c.UserID == userID
+}
+
 // LikeCoordinate represents a like on a coordinate
 type LikeCoordinate struct {
 	BaseModel
@@ -130,16 +296,187 @@ type Notification struct {
 	LikeCoordinate   *LikeCoordinate `gorm:"foreignKey:LikeCoordinateID" json:"like_coordinate,omitempty"`
 }
 
+// Session represents a login session on a device (a refresh token family)
+type Session struct {
+	BaseModel
+	UserID     uint       `gorm:"not null;index" json:"user_id"`
+	DeviceName string     `gorm:"type:varchar(255)" json:"device_name"`
+	IPAddress  string     `gorm:"type:varchar(45)" json:"ip_address"`
+	UserAgent  string     `gorm:"type:varchar(512)" json:"user_agent"`
+	LastUsedAt time.Time  `json:"last_used_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	User       User       `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// IsActive reports whether the session can still be used
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// RefreshToken represents an opaque refresh token issued for a session
+type RefreshToken struct {
+	BaseModel
+	SessionID   uint       `gorm:"not null;index" json:"session_id"`
+	TokenDigest string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	UsedAt      *time.Time `json:"used_at,omitempty"`
+	Session     Session    `gorm:"foreignKey:SessionID" json:"-"`
+}
+
+// AuditEvent records an administrative, security-relevant or domain action.
+// Events are append-only, so there is no UpdatedAt or soft delete.
+type AuditEvent struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	CreatedAt  time.Time `gorm:"index" json:"created_at"`
+	ActorID    uint      `gorm:"not null;index" json:"actor_id"`
+	Action     string    `gorm:"type:varchar(100);not null;index" json:"action"`
+	TargetType string    `gorm:"type:varchar(50);index:idx_audit_target" json:"target_type"`
+	TargetID   uint      `gorm:"index:idx_audit_target" json:"target_id"`
+	Metadata   string    `gorm:"type:text" json:"metadata,omitempty"`
+	Changes    string    `gorm:"type:text" json:"changes,omitempty"` // changed fields as {"field": {"from": ..., "to": ...}}
+	IPAddress  string    `gorm:"type:varchar(45)" json:"ip_address"`
+	UserAgent  string    `gorm:"type:varchar(512)" json:"user_agent"`
+}
+
+// LoginAttempt tracks failed logins for an identifier (account email or client IP)
+type LoginAttempt struct {
+	BaseModel
+	Identifier    string    `gorm:"type:varchar(255);uniqueIndex;not null" json:"identifier"`
+	Failures      int       `gorm:"not null;default:0" json:"failures"`
+	FirstFailedAt time.Time `json:"first_failed_at"`
+	LastFailedAt  time.Time `json:"last_failed_at"`
+}
+
+// RecoveryCode is a single-use two-factor backup code
+type RecoveryCode struct {
+	BaseModel
+	UserID     uint       `gorm:"not null;index" json:"user_id"`
+	CodeDigest string     `gorm:"type:varchar(64);not null;index" json:"-"`
+	UsedAt     *time.Time `json:"used_at,omitempty"`
+}
+
+// PasswordHistory keeps the digest of a previous password to prevent reuse
+type PasswordHistory struct {
+	BaseModel
+	UserID         uint   `gorm:"not null;index" json:"user_id"`
+	PasswordDigest string `gorm:"type:varchar(255);not null" json:"-"`
+}
+
+// UserIdentity links a user to an external OpenID Connect account
+type UserIdentity struct {
+	BaseModel
+	UserID      uint       `gorm:"not null;index" json:"user_id"`
+	Provider    string     `gorm:"type:varchar(50);not null;uniqueIndex:idx_identity_provider_subject" json:"provider"`
+	Subject     string     `gorm:"type:varchar(255);not null;uniqueIndex:idx_identity_provider_subject" json:"-"`
+	Email       string     `gorm:"type:varchar(255)" json:"email"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+	LinkDigest  string     `gorm:"type:varchar(255)" json:"-"`
+	LinkSentAt  *time.Time `json:"-"`
+}
+
+// OAuthState is a pending OIDC authorization request
+type OAuthState struct {
+	BaseModel
+	StateDigest  string    `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	Provider     string    `gorm:"type:varchar(50);not null" json:"provider"`
+	Nonce        string    `gorm:"type:varchar(255);not null" json:"-"`
+	CodeVerifier string    `gorm:"type:varchar(255);not null" json:"-"`
+	UserID       *uint     `json:"user_id,omitempty"` // set when linking from a signed-in account
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// PersonalAccessToken is a long-lived, scoped API token for scripts and integrations
+type PersonalAccessToken struct {
+	BaseModel
+	UserID      uint       `gorm:"not null;index" json:"user_id"`
+	Name        string     `gorm:"type:varchar(100);not null" json:"name"`
+	TokenDigest string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`
+	TokenPrefix string     `gorm:"type:varchar(20);not null" json:"token_prefix"` // first characters, for recognising a token
+	Scopes      string     `gorm:"type:varchar(500);not null" json:"-"`           // space separated
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`                          // nil never expires
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP  string     `gorm:"type:varchar(45)" json:"last_used_ip,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+}
+
+// ScopeList returns the token's scopes
+func (t *PersonalAccessToken) ScopeList() []string {
+	return strings.Fields(t.Scopes)
+}
+
+// HasScope reports whether the token grants the scope; write scopes imply read
+func (t *PersonalAccessToken) HasScope(scope string) bool {
+	implied := ""
+	if strings.HasSuffix(scope, ":read") {
+		implied = strings.TrimSuffix(scope, ":read") + ":write"
+	}
+	for _, s := range t.ScopeList() {
+		if s == scope || s == implied {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActive reports whether the token can still be used
+func (t *PersonalAccessToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && (t.ExpiresAt == nil || now.Before(*t.ExpiresAt))
+}
+
+// DataExport is an archive of everything a user has stored, built in the background
+type DataExport struct {
+	BaseModel
+	UserID         uint       `gorm:"not null;index" json:"user_id"`
+	Status         string     `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	FilePath       string     `gorm:"type:varchar(255)" json:"-"`
+	FileSize       int64      `json:"file_size"`
+	DownloadDigest string     `gorm:"type:varchar(64);index" json:"-"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"` // download link expiry
+	Error          string     `gorm:"type:varchar(500)" json:"error,omitempty"`
+}
+
+// InProgress reports whether the export job has not finished yet
+func (e *DataExport) InProgress() bool {
+	return e.Status == ExportStatusPending || e.Status == ExportStatusProcessing
+}
+
+// Stalled reports whether an unfinished job has not been touched since
+// staleBefore, e.g. because the server stopped while it ran
+func (e *DataExport) Stalled(staleBefore time.Time) bool {
+	return e.InProgress() && e.UpdatedAt.Before(staleBefore)
+}
+
+// IsDownloadable reports whether the archive can be downloaded at the given time
+func (e *DataExport) IsDownloadable(now time.Time) bool {
+	return e.Status == ExportStatusCompleted && e.ExpiresAt != nil && now.Before(*e.ExpiresAt)
+}
+
 // GetAllModels returns all model structs for migration
 func GetAllModels() []interface{} {
 	return []interface{}{
 		&User{},
+		&Category{},
 		&Item{},
+		&ItemStatusChange{},
 		&Coordinate{},
+		&CoordinateItem{},
+		&WearLog{},
+		&WearLogItem{},
 		&Comment{},
 		&LikeCoordinate{},
 		&Relationship{},
 		&Block{},
 		&Notification{},
+		&Session{},
+		&RefreshToken{},
+		&AuditEvent{},
+		&LoginAttempt{},
+		&RecoveryCode{},
+		&UserIdentity{},
+		&OAuthState{},
+		&PersonalAccessToken{},
+		&DataExport{},
+		&PasswordHistory{},
 	}
 }
\ No newline at end of file
@@ -204,6 +204,7 @@ func TestNotificationActions(t *testing.T) {
 		NotificationActionFollow,
 		NotificationActionLike,
 		NotificationActionComment,
+		NotificationActionForgottenItems,
 	}
 
 	for _, action := range validActions {
@@ -274,4 +275,103 @@ func TestBlockUniqueness(t *testing.T) {
 		// This would be caught by business logic
 		t.Log("Blocker and Blocked are the same - this should be validated in usecase")
 	}
-}
\ No newline at end of file
+}
+func TestUserHasPermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		user       User
+		permission string
+		want       bool
+	}{
+		{"admin flag grants everything", User{Admin: true}, PermissionRolesManage, true},
+		{"admin role grants everything", User{Role: RoleAdmin}, PermissionUsersManage, true},
+		{"moderator can moderate", User{Role: RoleModerator}, PermissionContentModerate, true},
+		{"moderator cannot manage roles", User{Role: RoleModerator}, PermissionRolesManage, false},
+		{"support can read users", User{Role: RoleSupport}, PermissionUsersRead, true},
+		{"support cannot moderate", User{Role: RoleSupport}, PermissionContentModerate, false},
+		{"support can impersonate", User{Role: RoleSupport}, PermissionUsersImpersonate, true},
+		{"moderator cannot impersonate", User{Role: RoleModerator}, PermissionUsersImpersonate, false},
+		{"regular user has no permissions", User{Role: RoleUser}, PermissionAdminAccess, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.user.HasPermission(tt.permission); got != tt.want {
+				t.Errorf("HasPermission(%s) = %v, want %v", tt.permission, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserIsSuspended(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name string
+		user User
+		want bool
+	}{
+		{"not suspended", User{}, false},
+		{"suspended indefinitely", User{SuspendedAt: &past}, true},
+		{"suspended until future", User{SuspendedAt: &past, SuspendedUntil: &future}, true},
+		{"suspension expired", User{SuspendedAt: &past, SuspendedUntil: &past}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.user.IsSuspended(now); got != tt.want {
+				t.Errorf("IsSuspended() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPersonalAccessTokenHasScope(t *testing.T) {
+	token := PersonalAccessToken{Scopes: "items:write notifications:read"}
+
+	tests := []struct {
+		scope string
+		want  bool
+	}{
+		{ScopeItemsWrite, true},
+		{ScopeItemsRead, true},
+		{ScopeNotificationsRead, true},
+		{ScopeNotificationsWrite, false},
+		{ScopeCoordinatesRead, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			if got := token.HasScope(tt.scope); got != tt.want {
+				t.Errorf("HasScope(%s) = %v, want %v", tt.scope, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPersonalAccessTokenIsActive(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		token PersonalAccessToken
+		want  bool
+	}{
+		{"no expiry", PersonalAccessToken{}, true},
+		{"expires in future", PersonalAccessToken{ExpiresAt: &future}, true},
+		{"expired", PersonalAccessToken{ExpiresAt: &past}, false},
+		{"revoked", PersonalAccessToken{RevokedAt: &past}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.token.IsActive(now); got != tt.want {
+				t.Errorf("IsActive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
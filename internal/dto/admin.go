@@ -0,0 +1,103 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SuspendUserRequest represents account suspension request
+type SuspendUserRequest struct {
+	Reason string     `json:"reason" binding:"max=500"`
+	Until  *time.Time `json:"until"`
+}
+
+// SetUserRoleRequest represents role assignment request
+type SetUserRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=user support moderator admin"`
+}
+
+// AdminUserSearchRequest represents admin user search parameters
+type AdminUserSearchRequest struct {
+	Query   string `form:"q"`
+	Page    int    `form:"page,default=1" binding:"min=1"`
+	PerPage int    `form:"per_page,default=20" binding:"min=1,max=100"`
+}
+
+// AdminUserResponse represents user data including moderation state
+type AdminUserResponse struct {
+	ID               uint       `json:"id"`
+	Name             string     `json:"name"`
+	Email            string     `json:"email"`
+	Picture          string     `json:"picture,omitempty"`
+	Admin            bool       `json:"admin"`
+	Role             string     `json:"role"`
+	Activated        bool       `json:"activated"`
+	SuspendedAt      *time.Time `json:"suspended_at,omitempty"`
+	SuspendedUntil   *time.Time `json:"suspended_until,omitempty"`
+	SuspensionReason string     `json:"suspension_reason,omitempty"`
+	SuspendedBy      *uint      `json:"suspended_by,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// AdminUserListResponse represents paginated admin user list response
+type AdminUserListResponse struct {
+	Users      []AdminUserResponse `json:"users"`
+	TotalCount int64               `json:"total_count"`
+	Page       int                 `json:"page"`
+	PerPage    int                 `json:"per_page"`
+}
+
+// AdminUserContentResponse represents everything a user has posted
+type AdminUserContentResponse struct {
+	User        AdminUserResponse    `json:"user"`
+	Items       []ItemResponse       `json:"items"`
+	Coordinates []CoordinateResponse `json:"coordinates"`
+}
+
+// ImpersonateUserRequest represents a support impersonation request
+type ImpersonateUserRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// ImpersonationResponse represents a read-only access token for acting as a user
+type ImpersonationResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	UserID    uint      `json:"user_id"`
+	ReadOnly  bool      `json:"read_only"`
+}
+
+// AuditEventSearchRequest represents audit trail query parameters. Times are RFC 3339.
+type AuditEventSearchRequest struct {
+	ActorID    *uint      `form:"actor_id"`
+	Action     string     `form:"action"`
+	TargetType string     `form:"target_type"`
+	TargetID   *uint      `form:"target_id"`
+	IPAddress  string     `form:"ip"`
+	From       *time.Time `form:"from"`
+	To         *time.Time `form:"to"`
+	Page       int        `form:"page,default=1" binding:"min=1"`
+	PerPage    int        `form:"per_page,default=50" binding:"min=1,max=200"`
+}
+
+// AuditEventResponse represents an audit trail entry
+type AuditEventResponse struct {
+	ID         uint            `json:"id"`
+	ActorID    uint            `json:"actor_id"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"target_type"`
+	TargetID   uint            `json:"target_id"`
+	Metadata   json.RawMessage `json:"metadata,omitempty"`
+	Changes    json.RawMessage `json:"changes,omitempty"`
+	IPAddress  string          `json:"ip_address"`
+	UserAgent  string          `json:"user_agent"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// AuditEventListResponse represents a page of audit trail entries
+type AuditEventListResponse struct {
+	Events     []AuditEventResponse `json:"events"`
+	TotalCount int64                `json:"total_count"`
+	Page       int                  `json:"page"`
+	PerPage    int                  `json:"per_page"`
+}
@@ -4,8 +4,21 @@ import "time"
 
 // LoginRequest represents login request body
 type LoginRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=6"`
+	Email      string `json:"email" binding:"required,email"`
+	Password   string `json:"password" binding:"required,min=6"`
+	DeviceName string `json:"device_name" binding:"max=255"`
+}
+
+// RefreshTokenRequest represents token refresh request body
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// ClientInfo describes the client a session is created from
+type ClientInfo struct {
+	IPAddress  string
+	UserAgent  string
+	DeviceName string
 }
 
 // SignupRequest represents signup request body
@@ -23,15 +36,126 @@ type AuthResponse struct {
 	User         UserResponse `json:"user"`
 }
 
+// TwoFactorChallengeResponse is returned by login when a TOTP code is still required
+type TwoFactorChallengeResponse struct {
+	TwoFactorRequired bool      `json:"two_factor_required"`
+	ChallengeToken    string    `json:"challenge_token"`
+	ExpiresAt         time.Time `json:"expires_at"`
+}
+
+// LoginTwoFactorRequest exchanges a login challenge and a TOTP or recovery code for tokens
+type LoginTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+	DeviceName     string `json:"device_name" binding:"max=255"`
+}
+
+// OIDCAuthorizationResponse holds the provider URL to redirect the user to
+type OIDCAuthorizationResponse struct {
+	AuthorizationURL string    `json:"authorization_url"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
+
+// OIDCCallbackRequest carries the code and state the provider redirected back with
+type OIDCCallbackRequest struct {
+	Code       string `json:"code" binding:"required"`
+	State      string `json:"state" binding:"required"`
+	DeviceName string `json:"device_name" binding:"max=255"`
+}
+
+// IdentityResponse represents a linked OIDC identity in responses
+type IdentityResponse struct {
+	ID          uint       `json:"id"`
+	Provider    string     `json:"provider"`
+	Email       string     `json:"email"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// TwoFactorEnrollResponse holds the secret of a pending 2FA enrollment
+type TwoFactorEnrollResponse struct {
+	Secret        string   `json:"secret"`
+	OTPAuthURI    string   `json:"otpauth_uri"`
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorCodeRequest carries a TOTP code
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// DisableTwoFactorRequest requires the password and a TOTP or recovery code
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
 // UserResponse represents user data in responses
 type UserResponse struct {
-	ID        uint      `json:"id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	Picture   string    `json:"picture,omitempty"`
-	Admin     bool      `json:"admin"`
-	Activated bool      `json:"activated"`
-	CreatedAt time.Time `json:"created_at"`
+	ID               uint      `json:"id"`
+	Name             string    `json:"name"`
+	Email            string    `json:"email"`
+	Picture          string    `json:"picture,omitempty"`
+	Admin            bool      `json:"admin"`
+	Activated        bool      `json:"activated"`
+	CreatedAt        time.Time `json:"created_at"`
+	UnconfirmedEmail string    `json:"unconfirmed_email,omitempty"` // pending new address, only shown to its owner
+}
+
+// ChangePasswordRequest represents password change request body
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=6"`
+	// RevokeOtherSessions logs out all other sessions (defaults to true)
+	RevokeOtherSessions *bool `json:"revoke_other_sessions"`
+}
+
+// SessionResponse represents a login session in responses
+type SessionResponse struct {
+	ID         uint      `json:"id"`
+	DeviceName string    `json:"device_name"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	Current    bool      `json:"current"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// CreateAccessTokenRequest represents a personal access token creation request
+type CreateAccessTokenRequest struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1"`
+	ExpiresAt *time.Time `json:"expires_at"` // omit for a token that never expires
+}
+
+// AccessTokenResponse represents a personal access token in responses
+type AccessTokenResponse struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"token_prefix"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	LastUsedIP  string     `json:"last_used_ip"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// CreateAccessTokenResponse includes the plaintext token, which is shown only once
+type CreateAccessTokenResponse struct {
+	AccessTokenResponse
+	Token string `json:"token"`
+}
+
+// MagicLinkRequest represents a passwordless login link request
+type MagicLinkRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// MagicLinkLoginRequest exchanges a login link token for tokens
+type MagicLinkLoginRequest struct {
+	Token      string `json:"token" binding:"required"`
+	DeviceName string `json:"device_name"`
 }
 
 // PasswordResetRequest represents password reset request
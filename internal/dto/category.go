@@ -0,0 +1,39 @@
+package dto
+
+// CreateCategoryRequest represents category creation request. Without
+// parent_id the category is top-level.
+type CreateCategoryRequest struct {
+	Name     string `json:"name" binding:"required,max=100"`
+	ParentID *uint  `json:"parent_id"`
+}
+
+// RenameCategoryRequest represents category rename request
+type RenameCategoryRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// ReorderCategoriesRequest lists every child of parent_id (top-level
+// categories without it) in their new order
+type ReorderCategoriesRequest struct {
+	ParentID    *uint  `json:"parent_id"`
+	CategoryIDs []uint `json:"category_ids" binding:"required,min=1"`
+}
+
+// MergeCategoryRequest represents category merge request
+type MergeCategoryRequest struct {
+	IntoID uint `json:"into_id" binding:"required"`
+}
+
+// CategoryResponse represents a category with its sub-categories in responses
+type CategoryResponse struct {
+	ID       uint               `json:"id"`
+	ParentID *uint              `json:"parent_id"`
+	Name     string             `json:"name"`
+	Position int                `json:"position"`
+	Children []CategoryResponse `json:"children"`
+}
+
+// CategoryTreeResponse represents a user's category tree
+type CategoryTreeResponse struct {
+	Categories []CategoryResponse `json:"categories"`
+}
@@ -4,20 +4,27 @@ import "time"
 
 // CreateCoordinateRequest represents coordinate creation request
 type CreateCoordinateRequest struct {
-	Season         int     `json:"season" binding:"required,min=1,max=5"`
-	TPO            int     `json:"tpo" binding:"required,min=1,max=5"`
-	SiTopLength    int     `json:"si_top_length" binding:"min=0,max=3"`
-	SiTopSleeve    int     `json:"si_top_sleeve" binding:"min=0,max=5"`
-	SiBottomLength int     `json:"si_bottom_length" binding:"min=0,max=6"`
-	SiBottomType   int     `json:"si_bottom_type" binding:"min=0,max=2"`
-	SiDressLength  int     `json:"si_dress_length" binding:"min=0,max=6"`
-	SiDressSleeve  int     `json:"si_dress_sleeve" binding:"min=0,max=5"`
-	SiOuterLength  int     `json:"si_outer_length" binding:"min=0,max=3"`
-	SiOuterSleeve  int     `json:"si_outer_sleeve" binding:"min=0,max=3"`
-	SiShoeSize     int     `json:"si_shoe_size"`
-	Memo           string  `json:"memo"`
-	Rating         float32 `json:"rating" binding:"min=0,max=5"`
-	ItemIDs        []uint  `json:"item_ids" binding:"required,min=1"`
+	Season         int                     `json:"season" binding:"required,min=1,max=5"`
+	TPO            int                     `json:"tpo" binding:"required,min=1,max=5"`
+	SiTopLength    int                     `json:"si_top_length" binding:"min=0,max=3"`
+	SiTopSleeve    int                     `json:"si_top_sleeve" binding:"min=0,max=5"`
+	SiBottomLength int                     `json:"si_bottom_length" binding:"min=0,max=6"`
+	SiBottomType   int                     `json:"si_bottom_type" binding:"min=0,max=2"`
+	SiDressLength  int                     `json:"si_dress_length" binding:"min=0,max=6"`
+	SiDressSleeve  int                     `json:"si_dress_sleeve" binding:"min=0,max=5"`
+	SiOuterLength  int                     `json:"si_outer_length" binding:"min=0,max=3"`
+	SiOuterSleeve  int                     `json:"si_outer_sleeve" binding:"min=0,max=3"`
+	SiShoeSize     int                     `json:"si_shoe_size"`
+	Memo           string                  `json:"memo"`
+	Rating         float32                 `json:"rating" binding:"min=0,max=5"`
+	ItemIDs        []uint                  `json:"item_ids" binding:"required_without=Items"`
+	Items          []CoordinateItemRequest `json:"items" binding:"omitempty,dive"` // takes precedence over item_ids
+}
+
+// CoordinateItemRequest places an item in a coordinate, in list order
+type CoordinateItemRequest struct {
+	ItemID uint   `json:"item_id" binding:"required"`
+	Role   string `json:"role" binding:"omitempty,oneof=outer top bottom dress shoes bag accessory"`
 }
 
 // UpdateCoordinateRequest represents coordinate update request
@@ -36,6 +43,7 @@ type UpdateCoordinateRequest struct {
 	Memo           *string  `json:"memo"`
 	Rating         *float32 `json:"rating" binding:"omitempty,min=0,max=5"`
 	ItemIDs        []uint   `json:"item_ids"`
+	Items          []CoordinateItemRequest `json:"items" binding:"omitempty,dive"` // takes precedence over item_ids
 }
 
 // CoordinateResponse represents coordinate data in responses
@@ -56,13 +64,22 @@ type CoordinateResponse struct {
 	SiShoeSize     int            `json:"si_shoe_size"`
 	Memo           string         `json:"memo"`
 	Rating         float32        `json:"rating"`
-	Items          []ItemResponse `json:"items"`
-	LikeCount      int64          `json:"like_count"`
-	CommentCount   int64          `json:"comment_count"`
-	IsLiked        bool           `json:"is_liked"`
-	User           UserResponse   `json:"user"`
-	CreatedAt      time.Time      `json:"created_at"`
-	UpdatedAt      time.Time      `json:"updated_at"`
+	Items          []CoordinateItemResponse `json:"items"`
+	WearCount      int64                    `json:"wear_count"`
+	LastWornAt     *time.Time               `json:"last_worn_at"`
+	LikeCount      int64                    `json:"like_count"`
+	CommentCount   int64                    `json:"comment_count"`
+	IsLiked        bool                     `json:"is_liked"`
+	User           UserResponse             `json:"user"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+}
+
+// CoordinateItemResponse represents an item as placed in a coordinate
+type CoordinateItemResponse struct {
+	ItemResponse
+	Position int    `json:"position"`
+	Role     string `json:"role,omitempty"`
 }
 
 // CoordinateListResponse represents paginated coordinate list response
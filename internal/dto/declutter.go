@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// ForgottenItemsRequest represents forgotten items query parameters
+type ForgottenItemsRequest struct {
+	Days int `form:"days,default=365" binding:"min=1,max=3650"`
+}
+
+// ForgottenItemResponse represents an unused item and its declutter score
+type ForgottenItemResponse struct {
+	Item           ItemResponse `json:"item"`
+	LastUsedAt     time.Time    `json:"last_used_at"`
+	UnusedDays     int          `json:"unused_days"`
+	DeclutterScore int          `json:"declutter_score"`
+}
+
+// ForgottenItemGroupResponse represents the forgotten items of one category
+type ForgottenItemGroupResponse struct {
+	SuperItem string                  `json:"super_item"`
+	Items     []ForgottenItemResponse `json:"items"`
+}
+
+// ForgottenItemsResponse represents forgotten items grouped by category
+type ForgottenItemsResponse struct {
+	Days       int                          `json:"days"`
+	TotalCount int                          `json:"total_count"`
+	Groups     []ForgottenItemGroupResponse `json:"groups"`
+}
+
+// DeclutterDigestRequest represents the weekly forgotten items notification
+// setting; 0 days turns it off
+type DeclutterDigestRequest struct {
+	Days *int `json:"days" binding:"required,min=0,max=3650"`
+}
+
+// DeclutterDigestResponse represents the weekly forgotten items notification setting
+type DeclutterDigestResponse struct {
+	Days int `json:"days"`
+}
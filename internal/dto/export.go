@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+// DataExportResponse represents the state of an account data export
+type DataExportResponse struct {
+	ID          uint       `json:"id"`
+	Status      string     `json:"status"`
+	FileSize    int64      `json:"file_size"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	ExpiresAt   *time.Time `json:"expires_at"` // download link expiry
+	Error       string     `json:"error,omitempty"`
+}
@@ -2,18 +2,26 @@ package dto
 
 import "time"
 
-// CreateItemRequest represents item creation request
+// CreateItemRequest represents item creation request. The item goes in
+// category_id, or else in the top-level category named super_item.
 type CreateItemRequest struct {
-	SuperItem    string  `json:"super_item" binding:"required"`
+	SuperItem    string  `json:"super_item" binding:"required_without=CategoryID"`
 	Season       int     `json:"season" binding:"required,min=1,max=5"`
 	TPO          int     `json:"tpo" binding:"required,min=1,max=5"`
 	Color        int     `json:"color" binding:"required,min=1,max=15"`
 	Content      string  `json:"content"`
 	Memo         string  `json:"memo"`
 	Rating       float32 `json:"rating" binding:"min=0,max=5"`
+
+	PurchasePrice *float64 `json:"purchase_price" binding:"omitempty,min=0"`
+	Currency      string   `json:"currency" binding:"omitempty,len=3,alpha"`
+	PurchasedOn   string   `json:"purchased_on" binding:"omitempty,datetime=2006-01-02"`
+
+	CategoryID *uint `json:"category_id"`
 }
 
-// UpdateItemRequest represents item update request
+// UpdateItemRequest represents item update request; category_id takes
+// precedence over super_item
 type UpdateItemRequest struct {
 	SuperItem    *string  `json:"super_item"`
 	Season       *int     `json:"season" binding:"omitempty,min=1,max=5"`
@@ -22,23 +30,50 @@ type UpdateItemRequest struct {
 	Content      *string  `json:"content"`
 	Memo         *string  `json:"memo"`
 	Rating       *float32 `json:"rating" binding:"omitempty,min=0,max=5"`
+
+	PurchasePrice *float64 `json:"purchase_price" binding:"omitempty,min=0"`
+	Currency      *string  `json:"currency" binding:"omitempty,len=3,alpha"`
+	PurchasedOn   *string  `json:"purchased_on" binding:"omitempty,datetime=2006-01-02"`
+
+	CategoryID *uint `json:"category_id"`
 }
 
 // ItemResponse represents item data in responses
 type ItemResponse struct {
-	ID           uint      `json:"id"`
-	UserID       uint      `json:"user_id"`
-	CoordinateID *uint     `json:"coordinate_id,omitempty"`
-	SuperItem    string    `json:"super_item"`
-	Season       int       `json:"season"`
-	TPO          int       `json:"tpo"`
-	Color        int       `json:"color"`
-	Content      string    `json:"content"`
-	Memo         string    `json:"memo"`
-	Picture      string    `json:"picture"`
-	Rating       float32   `json:"rating"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID              uint                       `json:"id"`
+	UserID          uint                       `json:"user_id"`
+	CoordinateIDs   []uint                     `json:"coordinate_ids"`
+	CategoryID      *uint                      `json:"category_id"`
+	SuperItem       string                     `json:"super_item"`
+	Season          int                        `json:"season"`
+	TPO             int                        `json:"tpo"`
+	Color           int                        `json:"color"`
+	Content         string                     `json:"content"`
+	Memo            string                     `json:"memo"`
+	Picture         string                     `json:"picture"`
+	Rating          float32                    `json:"rating"`
+	PurchasePrice   *float64                   `json:"purchase_price"`
+	Currency        string                     `json:"currency,omitempty"`
+	PurchasedOn     *string                    `json:"purchased_on"`
+	Status          string                     `json:"status"`
+	StatusChangedAt *time.Time                 `json:"status_changed_at"`
+	StatusHistory   []ItemStatusChangeResponse `json:"status_history,omitempty"` // item detail only
+	WearCount       int64                      `json:"wear_count"`
+	LastWornAt      *time.Time                 `json:"last_worn_at"`
+	CreatedAt       time.Time                  `json:"created_at"`
+	UpdatedAt       time.Time                  `json:"updated_at"`
+}
+
+// ItemStatusChangeResponse represents a lifecycle status transition
+type ItemStatusChangeResponse struct {
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+// ChangeItemStatusRequest represents item lifecycle status change request
+type ChangeItemStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=active in_laundry lent_out archived donated sold"`
 }
 
 // ItemListResponse represents paginated item list response
@@ -57,6 +92,50 @@ type ItemFilterRequest struct {
 	SuperItem *string  `form:"super_item"`
 	MinRating *float32 `form:"min_rating" binding:"omitempty,min=0,max=5"`
 	MaxRating *float32 `form:"max_rating" binding:"omitempty,min=0,max=5"`
+	Status    []string `form:"status" binding:"omitempty,dive,oneof=active in_laundry lent_out archived donated sold"`
 	Page      int      `form:"page,default=1" binding:"min=1"`
 	PerPage   int      `form:"per_page,default=20" binding:"min=1,max=100"`
-}
\ No newline at end of file
+
+	// CategoryID matches the category and all its sub-categories
+	CategoryID *uint `form:"category_id"`
+}
+
+// WardrobeAnalyticsRequest selects the currency analytics are computed in
+type WardrobeAnalyticsRequest struct {
+	Currency string `form:"currency" binding:"omitempty,len=3,alpha"`
+}
+
+// WardrobeAnalyticsResponse represents wardrobe value and cost per wear
+type WardrobeAnalyticsResponse struct {
+	Currency         string                     `json:"currency"`
+	Currencies       []string                   `json:"currencies"`
+	ItemCount        int                        `json:"item_count"`
+	PricedItemCount  int                        `json:"priced_item_count"`
+	TotalValue       float64                    `json:"total_value"`
+	TotalWears       int64                      `json:"total_wears"`
+	CostPerWear      float64                    `json:"cost_per_wear"`
+	MonthlySpending  []MonthlySpendingResponse  `json:"monthly_spending"`
+	CategorySpending []CategorySpendingResponse `json:"category_spending"`
+	BestValue        []ItemValueResponse        `json:"best_value"`
+	WorstValue       []ItemValueResponse        `json:"worst_value"`
+}
+
+// MonthlySpendingResponse represents the amount spent in a purchase month
+type MonthlySpendingResponse struct {
+	Month     string  `json:"month"`
+	Amount    float64 `json:"amount"`
+	ItemCount int     `json:"item_count"`
+}
+
+// CategorySpendingResponse represents the amount spent on a category
+type CategorySpendingResponse struct {
+	Category  string  `json:"category"`
+	Amount    float64 `json:"amount"`
+	ItemCount int     `json:"item_count"`
+}
+
+// ItemValueResponse represents an item with its cost per wear
+type ItemValueResponse struct {
+	Item        ItemResponse `json:"item"`
+	CostPerWear float64      `json:"cost_per_wear"`
+}
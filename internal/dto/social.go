@@ -15,7 +15,7 @@ type UpdateCommentRequest struct {
 // CommentResponse represents comment data in responses
 type CommentResponse struct {
 	ID           uint         `json:"id"`
-	UserID       uint         `json:"user_id"`
+	UserID       *uint        `json:"user_id"` // null when the author's account was purged
 	CoordinateID uint         `json:"coordinate_id"`
 	Comment      string       `json:"comment"`
 	User         UserResponse `json:"user"`
@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+// CreateWearLogRequest represents wear log creation request. Without item_ids
+// the coordinate's current items are recorded.
+type CreateWearLogRequest struct {
+	WornOn       string `json:"worn_on" binding:"required,datetime=2006-01-02"`
+	CoordinateID *uint  `json:"coordinate_id" binding:"required_without=ItemIDs"`
+	ItemIDs      []uint `json:"item_ids"`
+	Weather      string `json:"weather" binding:"max=255"`
+	Mood         int    `json:"mood" binding:"omitempty,min=1,max=5"`
+}
+
+// UpdateWearLogRequest represents wear log update request. A coordinate_id of 0
+// detaches the coordinate and keeps the recorded items.
+type UpdateWearLogRequest struct {
+	WornOn       *string `json:"worn_on" binding:"omitempty,datetime=2006-01-02"`
+	CoordinateID *uint   `json:"coordinate_id"`
+	ItemIDs      []uint  `json:"item_ids"`
+	Weather      *string `json:"weather" binding:"omitempty,max=255"`
+	Mood         *int    `json:"mood" binding:"omitempty,min=0,max=5"`
+}
+
+// WearLogResponse represents wear log data in responses
+type WearLogResponse struct {
+	ID           uint           `json:"id"`
+	UserID       uint           `json:"user_id"`
+	WornOn       string         `json:"worn_on"`
+	CoordinateID *uint          `json:"coordinate_id,omitempty"`
+	Items        []ItemResponse `json:"items"`
+	Weather      string         `json:"weather"`
+	Mood         int            `json:"mood"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
+
+// WearLogListResponse represents paginated wear log list response
+type WearLogListResponse struct {
+	WearLogs   []WearLogResponse `json:"wear_logs"`
+	TotalCount int64             `json:"total_count"`
+	Page       int               `json:"page"`
+	PerPage    int               `json:"per_page"`
+}
+
+// WearLogFilterRequest represents wear log list parameters; both days are inclusive
+type WearLogFilterRequest struct {
+	From    *time.Time `form:"from" time_format:"2006-01-02"`
+	To      *time.Time `form:"to" time_format:"2006-01-02"`
+	Page    int        `form:"page,default=1" binding:"min=1"`
+	PerPage int        `form:"per_page,default=31" binding:"min=1,max=100"`
+}
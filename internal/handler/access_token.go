@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/dto"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+)
+
+type AccessTokenHandler struct {
+	accessTokenUsecase usecase.AccessTokenUsecase
+}
+
+// NewAccessTokenHandler creates a new personal access token handler
+func NewAccessTokenHandler(accessTokenUsecase usecase.AccessTokenUsecase) *AccessTokenHandler {
+	return &AccessTokenHandler{
+		accessTokenUsecase: accessTokenUsecase,
+	}
+}
+
+// CreateAccessToken POST /api/v1/users/me/tokens
+func (h *AccessTokenHandler) CreateAccessToken(c *gin.Context) {
+	var req dto.CreateAccessTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plaintext, token, err := h.accessTokenUsecase.CreateAccessToken(c.Request.Context(), c.GetUint("userID"), req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		switch err.Error() {
+		case "name is required", "invalid scope", "expiry must be in the future":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create token"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.CreateAccessTokenResponse{
+		AccessTokenResponse: accessTokenToResponse(token),
+		Token:               plaintext,
+	})
+}
+
+// ListAccessTokens GET /api/v1/users/me/tokens
+func (h *AccessTokenHandler) ListAccessTokens(c *gin.Context) {
+	tokens, err := h.accessTokenUsecase.ListAccessTokens(c.Request.Context(), c.GetUint("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokenResponses := make([]dto.AccessTokenResponse, len(tokens))
+	for i, token := range tokens {
+		tokenResponses[i] = accessTokenToResponse(token)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tokens": tokenResponses})
+}
+
+// RevokeAccessToken DELETE /api/v1/users/me/tokens/:id
+func (h *AccessTokenHandler) RevokeAccessToken(c *gin.Context) {
+	tokenID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	err = h.accessTokenUsecase.RevokeAccessToken(c.Request.Context(), c.GetUint("userID"), uint(tokenID))
+	if err != nil {
+		if err.Error() == "token not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Token revoked successfully"})
+}
+
+// accessTokenToResponse converts a personal access token to response DTO
+func accessTokenToResponse(token *domain.PersonalAccessToken) dto.AccessTokenResponse {
+	return dto.AccessTokenResponse{
+		ID:          token.ID,
+		Name:        token.Name,
+		TokenPrefix: token.TokenPrefix,
+		Scopes:      token.ScopeList(),
+		ExpiresAt:   token.ExpiresAt,
+		LastUsedAt:  token.LastUsedAt,
+		LastUsedIP:  token.LastUsedIP,
+		CreatedAt:   token.CreatedAt,
+	}
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+)
+
+// Mock usecase
+type mockAccessTokenUsecase struct {
+	mock.Mock
+}
+
+func (m *mockAccessTokenUsecase) CreateAccessToken(ctx context.Context, userID uint, name string, scopes []string, expiresAt *time.Time) (string, *domain.PersonalAccessToken, error) {
+	args := m.Called(ctx, userID, name, scopes, expiresAt)
+	if args.Get(1) == nil {
+		return "", nil, args.Error(2)
+	}
+	return args.String(0), args.Get(1).(*domain.PersonalAccessToken), args.Error(2)
+}
+
+func (m *mockAccessTokenUsecase) ListAccessTokens(ctx context.Context, userID uint) ([]*domain.PersonalAccessToken, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PersonalAccessToken), args.Error(1)
+}
+
+func (m *mockAccessTokenUsecase) RevokeAccessToken(ctx context.Context, userID, tokenID uint) error {
+	args := m.Called(ctx, userID, tokenID)
+	return args.Error(0)
+}
+
+func TestAccessTokenHandler_CreateAccessToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		requestBody  map[string]interface{}
+		mockSetup    func(*mockAccessTokenUsecase)
+		expectedCode int
+		expectToken  bool
+	}{
+		{
+			name: "successful creation",
+			requestBody: map[string]interface{}{
+				"name":   "spreadsheet sync",
+				"scopes": []string{"items:read", "items:write"},
+			},
+			mockSetup: func(m *mockAccessTokenUsecase) {
+				m.On("CreateAccessToken", mock.Anything, uint(1), "spreadsheet sync", []string{"items:read", "items:write"}, (*time.Time)(nil)).
+					Return("spw_pat_secret", &domain.PersonalAccessToken{
+						BaseModel:   domain.BaseModel{ID: 5},
+						Name:        "spreadsheet sync",
+						TokenPrefix: "spw_pat_secr",
+						Scopes:      "items:read items:write",
+					}, nil)
+			},
+			expectedCode: http.StatusCreated,
+			expectToken:  true,
+		},
+		{
+			name: "unknown scope",
+			requestBody: map[string]interface{}{
+				"name":   "dashboard",
+				"scopes": []string{"admin:everything"},
+			},
+			mockSetup: func(m *mockAccessTokenUsecase) {
+				m.On("CreateAccessToken", mock.Anything, uint(1), "dashboard", []string{"admin:everything"}, (*time.Time)(nil)).
+					Return("", nil, errors.New("invalid scope"))
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "missing scopes",
+			requestBody:  map[string]interface{}{"name": "dashboard"},
+			mockSetup:    func(m *mockAccessTokenUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockAccessTokenUsecase)
+			tt.mockSetup(mockUsecase)
+
+			handler := NewAccessTokenHandler(mockUsecase)
+
+			jsonBody, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/users/me/tokens", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Set("userID", uint(1))
+
+			handler.CreateAccessToken(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectToken {
+				var responseBody map[string]interface{}
+				json.Unmarshal(w.Body.Bytes(), &responseBody)
+				assert.Equal(t, "spw_pat_secret", responseBody["token"])
+				assert.Equal(t, []interface{}{"items:read", "items:write"}, responseBody["scopes"])
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAccessTokenHandler_RevokeAccessToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		tokenID      string
+		mockSetup    func(*mockAccessTokenUsecase)
+		expectedCode int
+	}{
+		{
+			name:    "successful revoke",
+			tokenID: "5",
+			mockSetup: func(m *mockAccessTokenUsecase) {
+				m.On("RevokeAccessToken", mock.Anything, uint(1), uint(5)).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:    "token of another user",
+			tokenID: "6",
+			mockSetup: func(m *mockAccessTokenUsecase) {
+				m.On("RevokeAccessToken", mock.Anything, uint(1), uint(6)).Return(errors.New("token not found"))
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "invalid token ID",
+			tokenID:      "abc",
+			mockSetup:    func(m *mockAccessTokenUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockAccessTokenUsecase)
+			tt.mockSetup(mockUsecase)
+
+			handler := NewAccessTokenHandler(mockUsecase)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodDelete, "/api/v1/users/me/tokens/"+tt.tokenID, nil)
+			c.Params = gin.Params{{Key: "id", Value: tt.tokenID}}
+			c.Set("userID", uint(1))
+
+			handler.RevokeAccessToken(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,387 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/dto"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+)
+
+type AdminHandler struct {
+	adminUsecase usecase.AdminUsecase
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(adminUsecase usecase.AdminUsecase) *AdminHandler {
+	return &AdminHandler{
+		adminUsecase: adminUsecase,
+	}
+}
+
+// ListAllUsers GET /api/v1/admin/users
+func (h *AdminHandler) ListAllUsers(c *gin.Context) {
+	var req dto.AdminUserSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	limit := req.PerPage
+	offset := (req.Page - 1) * req.PerPage
+
+	users, total, err := h.adminUsecase.SearchUsers(c.Request.Context(), req.Query, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	userResponses := make([]dto.AdminUserResponse, len(users))
+	for i, user := range users {
+		userResponses[i] = adminUserToResponse(user)
+	}
+
+	c.JSON(http.StatusOK, dto.AdminUserListResponse{
+		Users:      userResponses,
+		TotalCount: total,
+		Page:       req.Page,
+		PerPage:    req.PerPage,
+	})
+}
+
+// GetUserContent GET /api/v1/admin/users/:id/content
+func (h *AdminHandler) GetUserContent(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var pagination dto.PaginationRequest
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	limit := pagination.PerPage
+	offset := (pagination.Page - 1) * pagination.PerPage
+
+	content, err := h.adminUsecase.GetUserContent(c.Request.Context(), c.GetUint("userID"), uint(userID), limit, offset)
+	if err != nil {
+		if err.Error() == "user not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	itemResponses := make([]dto.ItemResponse, len(content.Items))
+	for i, item := range content.Items {
+		itemResponses[i] = itemToResponse(item)
+	}
+
+	coordinateResponses := make([]dto.CoordinateResponse, len(content.Coordinates))
+	for i, coordinate := range content.Coordinates {
+		items := coordinateItemsToResponse(coordinate.Items)
+		coordinateResponses[i] = dto.CoordinateResponse{
+			ID:             coordinate.ID,
+			UserID:         coordinate.UserID,
+			Season:         coordinate.Season,
+			TPO:            coordinate.TPO,
+			Picture:        coordinate.Picture,
+			SiTopLength:    coordinate.SiTopLength,
+			SiTopSleeve:    coordinate.SiTopSleeve,
+			SiBottomLength: coordinate.SiBottomLength,
+			SiBottomType:   coordinate.SiBottomType,
+			SiDressLength:  coordinate.SiDressLength,
+			SiDressSleeve:  coordinate.SiDressSleeve,
+			SiOuterLength:  coordinate.SiOuterLength,
+			SiOuterSleeve:  coordinate.SiOuterSleeve,
+			SiShoeSize:     coordinate.SiShoeSize,
+			Memo:           coordinate.Memo,
+			Rating:         coordinate.Rating,
+			Items:          items,
+			CreatedAt:      coordinate.CreatedAt,
+			UpdatedAt:      coordinate.UpdatedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.AdminUserContentResponse{
+		User:        adminUserToResponse(content.User),
+		Items:       itemResponses,
+		Coordinates: coordinateResponses,
+	})
+}
+
+// SuspendUser POST /api/v1/admin/users/:id/suspend
+func (h *AdminHandler) SuspendUser(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req dto.SuspendUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.adminUsecase.SuspendUser(c.Request.Context(), c.GetUint("userID"), uint(userID), req.Until, req.Reason)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User suspended successfully"})
+}
+
+// UnsuspendUser DELETE /api/v1/admin/users/:id/suspend
+func (h *AdminHandler) UnsuspendUser(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	err = h.adminUsecase.UnsuspendUser(c.Request.Context(), c.GetUint("userID"), uint(userID))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User unsuspended successfully"})
+}
+
+// SetUserRole PUT /api/v1/admin/users/:id/role
+func (h *AdminHandler) SetUserRole(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req dto.SetUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.adminUsecase.SetUserRole(c.Request.Context(), c.GetUint("userID"), uint(userID), req.Role)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User role updated successfully"})
+}
+
+// ImpersonateUser POST /api/v1/admin/users/:id/impersonate
+func (h *AdminHandler) ImpersonateUser(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req dto.ImpersonateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.adminUsecase.ImpersonateUser(c.Request.Context(), c.GetUint("userID"), c.GetUint("sessionID"), uint(userID), req.Reason)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// DeleteUser DELETE /api/v1/admin/users/:id
+func (h *AdminHandler) DeleteUser(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	err = h.adminUsecase.DeleteUser(c.Request.Context(), c.GetUint("userID"), uint(userID))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User deleted successfully"})
+}
+
+// DeleteItem DELETE /api/v1/admin/items/:id
+func (h *AdminHandler) DeleteItem(c *gin.Context) {
+	itemID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		return
+	}
+
+	err = h.adminUsecase.DeleteItem(c.Request.Context(), c.GetUint("userID"), uint(itemID))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Item deleted successfully"})
+}
+
+// DeleteCoordinate DELETE /api/v1/admin/coordinates/:id
+func (h *AdminHandler) DeleteCoordinate(c *gin.Context) {
+	coordinateID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid coordinate ID"})
+		return
+	}
+
+	err = h.adminUsecase.DeleteCoordinate(c.Request.Context(), c.GetUint("userID"), uint(coordinateID))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Coordinate deleted successfully"})
+}
+
+// ListAuditEvents GET /api/v1/admin/audit-events
+func (h *AdminHandler) ListAuditEvents(c *gin.Context) {
+	var req dto.AuditEventSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	events, total, err := h.adminUsecase.ListAuditEvents(c.Request.Context(), repository.AuditEventFilter{
+		ActorID:    req.ActorID,
+		Action:     req.Action,
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		IPAddress:  req.IPAddress,
+		From:       req.From,
+		To:         req.To,
+		Limit:      req.PerPage,
+		Offset:     (req.Page - 1) * req.PerPage,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	eventResponses := make([]dto.AuditEventResponse, len(events))
+	for i, event := range events {
+		eventResponses[i] = auditEventToResponse(event)
+	}
+
+	c.JSON(http.StatusOK, dto.AuditEventListResponse{
+		Events:     eventResponses,
+		TotalCount: total,
+		Page:       req.Page,
+		PerPage:    req.PerPage,
+	})
+}
+
+// handleError maps admin usecase errors to HTTP responses
+func (h *AdminHandler) handleError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "user not found", "item not found", "coordinate not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "cannot modify own account", "user is not suspended", "suspension end must be in the future", "invalid role",
+		"cannot impersonate own account", "user is suspended", "session required", "invalid time range":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case "cannot impersonate staff accounts":
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// adminUserToResponse converts a user including moderation state
+func adminUserToResponse(user *domain.User) dto.AdminUserResponse {
+	return dto.AdminUserResponse{
+		ID:               user.ID,
+		Name:             user.Name,
+		Email:            user.Email,
+		Picture:          user.Picture,
+		Admin:            user.Admin,
+		Role:             user.Role,
+		Activated:        user.Activated,
+		SuspendedAt:      user.SuspendedAt,
+		SuspendedUntil:   user.SuspendedUntil,
+		SuspensionReason: user.SuspensionReason,
+		SuspendedBy:      user.SuspendedBy,
+		CreatedAt:        user.CreatedAt,
+	}
+}
+
+// auditEventToResponse converts an audit event, passing its JSON columns through
+func auditEventToResponse(event *domain.AuditEvent) dto.AuditEventResponse {
+	resp := dto.AuditEventResponse{
+		ID:         event.ID,
+		ActorID:    event.ActorID,
+		Action:     event.Action,
+		TargetType: event.TargetType,
+		TargetID:   event.TargetID,
+		IPAddress:  event.IPAddress,
+		UserAgent:  event.UserAgent,
+		CreatedAt:  event.CreatedAt,
+	}
+	if event.Metadata != "" {
+		resp.Metadata = json.RawMessage(event.Metadata)
+	}
+	if event.Changes != "" {
+		resp.Changes = json.RawMessage(event.Changes)
+	}
+	return resp
+}
+
+// itemToResponse converts domain item to response DTO
+func itemToResponse(item *domain.Item) dto.ItemResponse {
+	var purchasedOn *string
+	if item.PurchasedOn != nil {
+		date := item.PurchasedOn.Format(domain.DateLayout)
+		purchasedOn = &date
+	}
+	resp := dto.ItemResponse{
+		ID:              item.ID,
+		UserID:          item.UserID,
+		CoordinateIDs:   item.CoordinateIDs(),
+		CategoryID:      item.CategoryID,
+		SuperItem:       item.SuperItem,
+		Season:          item.Season,
+		TPO:             item.TPO,
+		Color:           item.Color,
+		Content:         item.Content,
+		Memo:            item.Memo,
+		Picture:         item.Picture,
+		Rating:          item.Rating,
+		PurchasePrice:   item.PurchasePrice,
+		Currency:        item.Currency,
+		PurchasedOn:     purchasedOn,
+		Status:          item.Status,
+		StatusChangedAt: item.StatusChangedAt,
+		WearCount:       item.WearCount,
+		LastWornAt:      item.LastWornAt,
+		CreatedAt:       item.CreatedAt,
+		UpdatedAt:       item.UpdatedAt,
+	}
+	for _, change := range item.StatusChanges {
+		resp.StatusHistory = append(resp.StatusHistory, dto.ItemStatusChangeResponse{
+			FromStatus: change.FromStatus,
+			ToStatus:   change.ToStatus,
+			ChangedAt:  change.ChangedAt,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,397 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/dto"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+)
+
+// Mock usecase
+type mockAdminUsecase struct {
+	mock.Mock
+}
+
+func (m *mockAdminUsecase) SearchUsers(ctx context.Context, query string, limit, offset int) ([]*domain.User, int64, error) {
+	args := m.Called(ctx, query, limit, offset)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domain.User), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *mockAdminUsecase) SuspendUser(ctx context.Context, actorID, userID uint, until *time.Time, reason string) error {
+	args := m.Called(ctx, actorID, userID, until, reason)
+	return args.Error(0)
+}
+
+func (m *mockAdminUsecase) UnsuspendUser(ctx context.Context, actorID, userID uint) error {
+	args := m.Called(ctx, actorID, userID)
+	return args.Error(0)
+}
+
+func (m *mockAdminUsecase) DeleteUser(ctx context.Context, actorID, userID uint) error {
+	args := m.Called(ctx, actorID, userID)
+	return args.Error(0)
+}
+
+func (m *mockAdminUsecase) SetUserRole(ctx context.Context, actorID, userID uint, role string) error {
+	args := m.Called(ctx, actorID, userID, role)
+	return args.Error(0)
+}
+
+func (m *mockAdminUsecase) ImpersonateUser(ctx context.Context, actorID, sessionID, userID uint, reason string) (*dto.ImpersonationResponse, error) {
+	args := m.Called(ctx, actorID, sessionID, userID, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ImpersonationResponse), args.Error(1)
+}
+
+func (m *mockAdminUsecase) GetUserContent(ctx context.Context, actorID, userID uint, limit, offset int) (*usecase.UserContent, error) {
+	args := m.Called(ctx, actorID, userID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.UserContent), args.Error(1)
+}
+
+func (m *mockAdminUsecase) DeleteItem(ctx context.Context, actorID, itemID uint) error {
+	args := m.Called(ctx, actorID, itemID)
+	return args.Error(0)
+}
+
+func (m *mockAdminUsecase) DeleteCoordinate(ctx context.Context, actorID, coordinateID uint) error {
+	args := m.Called(ctx, actorID, coordinateID)
+	return args.Error(0)
+}
+
+func (m *mockAdminUsecase) ListAuditEvents(ctx context.Context, filter repository.AuditEventFilter) ([]*domain.AuditEvent, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domain.AuditEvent), args.Get(1).(int64), args.Error(2)
+}
+
+func TestAdminHandler_ListAllUsers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := new(mockAdminUsecase)
+	suspendedAt := time.Now()
+	mockUsecase.On("SearchUsers", mock.Anything, "alice", 20, 0).Return([]*domain.User{
+		{BaseModel: domain.BaseModel{ID: 2}, Name: "Alice", Email: "alice@example.com", Role: domain.RoleUser, SuspendedAt: &suspendedAt},
+	}, int64(1), nil)
+
+	handler := NewAdminHandler(mockUsecase)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/admin/users?q=alice", nil)
+
+	handler.ListAllUsers(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var responseBody map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &responseBody)
+	assert.Equal(t, float64(1), responseBody["total_count"])
+	users := responseBody["users"].([]interface{})
+	assert.Len(t, users, 1)
+	assert.Equal(t, "alice@example.com", users[0].(map[string]interface{})["email"])
+	assert.NotNil(t, users[0].(map[string]interface{})["suspended_at"])
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestAdminHandler_SuspendUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		userID       string
+		requestBody  map[string]interface{}
+		mockSetup    func(*mockAdminUsecase)
+		expectedCode int
+	}{
+		{
+			name:        "successful suspension",
+			userID:      "2",
+			requestBody: map[string]interface{}{"reason": "spam"},
+			mockSetup: func(m *mockAdminUsecase) {
+				m.On("SuspendUser", mock.Anything, uint(1), uint(2), (*time.Time)(nil), "spam").Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:        "cannot suspend self",
+			userID:      "1",
+			requestBody: map[string]interface{}{"reason": "test"},
+			mockSetup: func(m *mockAdminUsecase) {
+				m.On("SuspendUser", mock.Anything, uint(1), uint(1), (*time.Time)(nil), "test").Return(errors.New("cannot modify own account"))
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "user not found",
+			userID:      "99",
+			requestBody: map[string]interface{}{},
+			mockSetup: func(m *mockAdminUsecase) {
+				m.On("SuspendUser", mock.Anything, uint(1), uint(99), (*time.Time)(nil), "").Return(errors.New("user not found"))
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockAdminUsecase)
+			tt.mockSetup(mockUsecase)
+
+			handler := NewAdminHandler(mockUsecase)
+
+			jsonBody, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/"+tt.userID+"/suspend", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Params = gin.Params{{Key: "id", Value: tt.userID}}
+			c.Set("userID", uint(1))
+
+			handler.SuspendUser(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAdminHandler_ImpersonateUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		userID       string
+		requestBody  map[string]interface{}
+		mockSetup    func(*mockAdminUsecase)
+		expectedCode int
+	}{
+		{
+			name:        "issues read-only token",
+			userID:      "2",
+			requestBody: map[string]interface{}{"reason": "ticket #42: empty timeline"},
+			mockSetup: func(m *mockAdminUsecase) {
+				m.On("ImpersonateUser", mock.Anything, uint(1), uint(5), uint(2), "ticket #42: empty timeline").Return(&dto.ImpersonationResponse{
+					Token:     "impersonation-token",
+					ExpiresAt: time.Now().Add(15 * time.Minute),
+					UserID:    2,
+					ReadOnly:  true,
+				}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "reason required",
+			userID:       "2",
+			requestBody:  map[string]interface{}{},
+			mockSetup:    func(m *mockAdminUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "staff account",
+			userID:      "3",
+			requestBody: map[string]interface{}{"reason": "test"},
+			mockSetup: func(m *mockAdminUsecase) {
+				m.On("ImpersonateUser", mock.Anything, uint(1), uint(5), uint(3), "test").Return(nil, errors.New("cannot impersonate staff accounts"))
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:        "user not found",
+			userID:      "99",
+			requestBody: map[string]interface{}{"reason": "test"},
+			mockSetup: func(m *mockAdminUsecase) {
+				m.On("ImpersonateUser", mock.Anything, uint(1), uint(5), uint(99), "test").Return(nil, errors.New("user not found"))
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockAdminUsecase)
+			tt.mockSetup(mockUsecase)
+
+			handler := NewAdminHandler(mockUsecase)
+
+			jsonBody, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/"+tt.userID+"/impersonate", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Params = gin.Params{{Key: "id", Value: tt.userID}}
+			c.Set("userID", uint(1))
+			c.Set("sessionID", uint(5))
+
+			handler.ImpersonateUser(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode == http.StatusCreated {
+				var body map[string]interface{}
+				json.Unmarshal(w.Body.Bytes(), &body)
+				assert.Equal(t, true, body["read_only"])
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAdminHandler_DeleteItem(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		itemID       string
+		mockSetup    func(*mockAdminUsecase)
+		expectedCode int
+	}{
+		{
+			name:   "successful takedown",
+			itemID: "10",
+			mockSetup: func(m *mockAdminUsecase) {
+				m.On("DeleteItem", mock.Anything, uint(1), uint(10)).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "item not found",
+			itemID: "11",
+			mockSetup: func(m *mockAdminUsecase) {
+				m.On("DeleteItem", mock.Anything, uint(1), uint(11)).Return(errors.New("item not found"))
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "invalid item ID",
+			itemID:       "abc",
+			mockSetup:    func(m *mockAdminUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockAdminUsecase)
+			tt.mockSetup(mockUsecase)
+
+			handler := NewAdminHandler(mockUsecase)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodDelete, "/api/v1/admin/items/"+tt.itemID, nil)
+			c.Params = gin.Params{{Key: "id", Value: tt.itemID}}
+			c.Set("userID", uint(1))
+
+			handler.DeleteItem(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAdminHandler_ListAuditEvents(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	actorID := uint(2)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		query        string
+		mockSetup    func(*mockAdminUsecase)
+		expectedCode int
+	}{
+		{
+			name:  "filters by actor, action and time",
+			query: "actor_id=2&action=item.update&from=2024-01-01T00:00:00Z&page=2&per_page=10",
+			mockSetup: func(m *mockAdminUsecase) {
+				m.On("ListAuditEvents", mock.Anything, mock.MatchedBy(func(f repository.AuditEventFilter) bool {
+					return f.ActorID != nil && *f.ActorID == actorID &&
+						f.Action == domain.AuditActionItemUpdate &&
+						f.From != nil && f.From.Equal(from) && f.To == nil &&
+						f.Limit == 10 && f.Offset == 10
+				})).Return([]*domain.AuditEvent{
+					{
+						ID:         7,
+						ActorID:    2,
+						Action:     domain.AuditActionItemUpdate,
+						TargetType: domain.AuditTargetItem,
+						TargetID:   30,
+						Changes:    `{"memo":{"from":"old","to":"new"}}`,
+						IPAddress:  "192.0.2.1",
+					},
+				}, int64(11), nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "invalid time",
+			query:        "from=yesterday",
+			mockSetup:    func(m *mockAdminUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:  "invalid time range",
+			query: "from=2024-02-01T00:00:00Z&to=2024-01-01T00:00:00Z",
+			mockSetup: func(m *mockAdminUsecase) {
+				m.On("ListAuditEvents", mock.Anything, mock.Anything).Return(nil, int64(0), errors.New("invalid time range"))
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockAdminUsecase)
+			tt.mockSetup(mockUsecase)
+
+			handler := NewAdminHandler(mockUsecase)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/admin/audit-events?"+tt.query, nil)
+			c.Set("userID", uint(1))
+
+			handler.ListAuditEvents(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode == http.StatusOK {
+				var body map[string]interface{}
+				json.Unmarshal(w.Body.Bytes(), &body)
+				assert.Equal(t, float64(11), body["total_count"])
+				events := body["events"].([]interface{})
+				assert.Len(t, events, 1)
+				changes := events[0].(map[string]interface{})["changes"].(map[string]interface{})
+				assert.Equal(t, "new", changes["memo"].(map[string]interface{})["to"])
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
@@ -2,12 +2,12 @@ package handler
 
 import (
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/House-lovers7/speadwear-go/internal/dto"
 	"github.com/House-lovers7/speadwear-go/internal/usecase"
 	"github.com/House-lovers7/speadwear-go/pkg/config"
+	"github.com/House-lovers7/speadwear-go/pkg/password"
 )
 
 type AuthHandler struct {
@@ -31,16 +31,63 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	authResponse, err := h.userUsecase.Login(c.Request.Context(), req.Email, req.Password)
+	authResponse, challenge, err := h.userUsecase.Login(c.Request.Context(), req.Email, req.Password, dto.ClientInfo{
+		IPAddress:  c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+		DeviceName: req.DeviceName,
+	})
 	if err != nil {
 		if err.Error() == "invalid email or password" || err.Error() == "account not activated" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			return
 		}
+		if err.Error() == "account suspended" {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "too many login attempts" {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many login attempts. Please try again later"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to login"})
 		return
 	}
 
+	if challenge != nil {
+		c.JSON(http.StatusOK, challenge)
+		return
+	}
+
+	c.JSON(http.StatusOK, authResponse)
+}
+
+// LoginTwoFactor completes a login with a TOTP or recovery code
+func (h *AuthHandler) LoginTwoFactor(c *gin.Context) {
+	var req dto.LoginTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	authResponse, err := h.userUsecase.LoginTwoFactor(c.Request.Context(), req.ChallengeToken, req.Code, dto.ClientInfo{
+		IPAddress:  c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+		DeviceName: req.DeviceName,
+	})
+	if err != nil {
+		switch err.Error() {
+		case "invalid challenge token", "invalid two-factor code":
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		case "account suspended":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case "too many login attempts":
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many login attempts. Please try again later"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to login"})
+		}
+		return
+	}
+
 	c.JSON(http.StatusOK, authResponse)
 }
 
@@ -52,53 +99,198 @@ func (h *AuthHandler) Signup(c *gin.Context) {
 		return
 	}
 
-	authResponse, err := h.userUsecase.Signup(c.Request.Context(), &req)
+	user, err := h.userUsecase.Signup(c.Request.Context(), &req)
 	if err != nil {
 		if err.Error() == "email already exists" {
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 			return
 		}
+		if password.IsPolicyError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user"})
 		return
 	}
 
-	c.JSON(http.StatusCreated, authResponse)
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Account created. Please check your email to activate your account",
+		"user":    user,
+	})
 }
 
 // Logout handles user logout
 func (h *AuthHandler) Logout(c *gin.Context) {
-	// クライアント側でトークンを削除する必要があるため、
-	// サーバー側では特に処理は不要
+	if err := h.userUsecase.Logout(c.Request.Context(), c.GetUint("sessionID")); err != nil {
+		if err.Error() == "session not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to logout"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
 }
 
 // RefreshToken handles token refresh
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
-	tokenString := c.GetHeader("Authorization")
-	if tokenString == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token required"})
+	var req dto.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	authResponse, err := h.userUsecase.RefreshToken(c.Request.Context(), req.RefreshToken, dto.ClientInfo{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	if err != nil {
+		if err.Error() == "invalid refresh token" || err.Error() == "refresh token reuse detected" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		if err.Error() == "account suspended" {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, authResponse)
+}
+
+// RequestMagicLink POST /api/v1/auth/magic-link
+func (h *AuthHandler) RequestMagicLink(c *gin.Context) {
+	var req dto.MagicLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.userUsecase.RequestMagicLink(c.Request.Context(), req.Email)
+	if err != nil && err.Error() == "too many requests" {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many login link requests. Please try again later"})
+		return
+	}
+
+	// Don't reveal if email exists or not
+	c.JSON(http.StatusOK, gin.H{"message": "If the email exists, a login link has been sent"})
+}
+
+// MagicLinkLogin POST /api/v1/auth/magic-link/verify
+func (h *AuthHandler) MagicLinkLogin(c *gin.Context) {
+	var req dto.MagicLinkLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	authResponse, challenge, err := h.userUsecase.MagicLinkLogin(c.Request.Context(), req.Token, dto.ClientInfo{
+		IPAddress:  c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+		DeviceName: req.DeviceName,
+	})
+	if err != nil {
+		switch err.Error() {
+		case "invalid or expired token", "account not activated":
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		case "account suspended":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to login"})
+		}
 		return
 	}
 
-	// "Bearer " プレフィックスを削除
-	if len(tokenString) > 7 && tokenString[:7] == "Bearer " {
-		tokenString = tokenString[7:]
+	if challenge != nil {
+		c.JSON(http.StatusOK, challenge)
+		return
 	}
 
-	newToken, err := h.userUsecase.RefreshToken(c.Request.Context(), tokenString)
+	c.JSON(http.StatusOK, authResponse)
+}
+
+// BeginOIDCLogin GET /api/v1/auth/oidc/:provider
+func (h *AuthHandler) BeginOIDCLogin(c *gin.Context) {
+	authorization, err := h.userUsecase.BeginOIDCLogin(c.Request.Context(), c.Param("provider"), nil)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		respondOIDCError(c, err)
 		return
 	}
 
-	// Calculate expiration time
-	duration, _ := time.ParseDuration(h.config.JWT.Expiration)
-	expiresAt := time.Now().Add(duration)
+	c.JSON(http.StatusOK, authorization)
+}
+
+// OIDCCallback POST /api/v1/auth/oidc/:provider/callback
+func (h *AuthHandler) OIDCCallback(c *gin.Context) {
+	var req dto.OIDCCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"token":      newToken,
-		"expires_at": expiresAt,
+	authResponse, challenge, err := h.userUsecase.OIDCLogin(c.Request.Context(), c.Param("provider"), req.Code, req.State, dto.ClientInfo{
+		IPAddress:  c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+		DeviceName: req.DeviceName,
 	})
+	if err != nil {
+		if err.Error() == "link confirmation required" {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   err.Error(),
+				"message": "An account with this email already exists. Check your email to link this sign-in method",
+			})
+			return
+		}
+		respondOIDCError(c, err)
+		return
+	}
+
+	if challenge != nil {
+		c.JSON(http.StatusOK, challenge)
+		return
+	}
+
+	c.JSON(http.StatusOK, authResponse)
+}
+
+// ConfirmIdentityLink POST /api/v1/auth/oidc/link/confirm
+func (h *AuthHandler) ConfirmIdentityLink(c *gin.Context) {
+	var req struct {
+		Token string `json:"token" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.userUsecase.ConfirmIdentityLink(c.Request.Context(), req.Token); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Account linked successfully"})
+}
+
+// respondOIDCError maps OIDC login and linking errors to HTTP responses
+func respondOIDCError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "unknown provider":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "invalid state", "email not verified by provider":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case "oidc authentication failed":
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case "account suspended":
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case "identity already linked":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to sign in with provider"})
+	}
 }
 
 // Me returns current user information
@@ -16,6 +16,7 @@ import (
 	"github.com/House-lovers7/speadwear-go/internal/domain"
 	"github.com/House-lovers7/speadwear-go/internal/dto"
 	"github.com/House-lovers7/speadwear-go/pkg/config"
+	"github.com/House-lovers7/speadwear-go/pkg/password"
 )
 
 // Mock usecase
@@ -23,25 +24,46 @@ type mockUserUsecase struct {
 	mock.Mock
 }
 
-func (m *mockUserUsecase) Login(ctx context.Context, email, password string) (*dto.AuthResponse, error) {
-	args := m.Called(ctx, email, password)
+func (m *mockUserUsecase) Login(ctx context.Context, email, password string, client dto.ClientInfo) (*dto.AuthResponse, *dto.TwoFactorChallengeResponse, error) {
+	args := m.Called(ctx, email, password, client)
+	var resp *dto.AuthResponse
+	if args.Get(0) != nil {
+		resp = args.Get(0).(*dto.AuthResponse)
+	}
+	var challenge *dto.TwoFactorChallengeResponse
+	if args.Get(1) != nil {
+		challenge = args.Get(1).(*dto.TwoFactorChallengeResponse)
+	}
+	return resp, challenge, args.Error(2)
+}
+
+func (m *mockUserUsecase) LoginTwoFactor(ctx context.Context, challengeToken, code string, client dto.ClientInfo) (*dto.AuthResponse, error) {
+	args := m.Called(ctx, challengeToken, code, client)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dto.AuthResponse), args.Error(1)
 }
 
-func (m *mockUserUsecase) Signup(ctx context.Context, req *dto.SignupRequest) (*dto.AuthResponse, error) {
+func (m *mockUserUsecase) Signup(ctx context.Context, req *dto.SignupRequest) (*dto.UserResponse, error) {
 	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
+	return args.Get(0).(*dto.UserResponse), args.Error(1)
+}
+
+func (m *mockUserUsecase) RefreshToken(ctx context.Context, refreshToken string, client dto.ClientInfo) (*dto.AuthResponse, error) {
+	args := m.Called(ctx, refreshToken, client)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).(*dto.AuthResponse), args.Error(1)
 }
 
-func (m *mockUserUsecase) RefreshToken(ctx context.Context, tokenString string) (string, error) {
-	args := m.Called(ctx, tokenString)
-	return args.String(0), args.Error(1)
+func (m *mockUserUsecase) Logout(ctx context.Context, sessionID uint) error {
+	args := m.Called(ctx, sessionID)
+	return args.Error(0)
 }
 
 func (m *mockUserUsecase) GetUser(ctx context.Context, userID uint) (*domain.User, error) {
@@ -65,9 +87,9 @@ func (m *mockUserUsecase) UpdateUser(ctx context.Context, userID uint, updates m
 	return args.Error(0)
 }
 
-func (m *mockUserUsecase) DeleteUser(ctx context.Context, userID uint) error {
+func (m *mockUserUsecase) DeleteUser(ctx context.Context, userID uint) (time.Time, error) {
 	args := m.Called(ctx, userID)
-	return args.Error(0)
+	return args.Get(0).(time.Time), args.Error(1)
 }
 
 func (m *mockUserUsecase) ListUsers(ctx context.Context, limit, offset int) ([]*domain.User, int64, error) {
@@ -78,8 +100,26 @@ func (m *mockUserUsecase) ListUsers(ctx context.Context, limit, offset int) ([]*
 	return args.Get(0).([]*domain.User), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *mockUserUsecase) ChangePassword(ctx context.Context, userID uint, oldPassword, newPassword string) error {
-	args := m.Called(ctx, userID, oldPassword, newPassword)
+func (m *mockUserUsecase) ChangePassword(ctx context.Context, userID, currentSessionID uint, oldPassword, newPassword string, revokeOtherSessions bool) error {
+	args := m.Called(ctx, userID, currentSessionID, oldPassword, newPassword, revokeOtherSessions)
+	return args.Error(0)
+}
+
+func (m *mockUserUsecase) ListSessions(ctx context.Context, userID uint) ([]*domain.Session, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Session), args.Error(1)
+}
+
+func (m *mockUserUsecase) RevokeSession(ctx context.Context, userID, sessionID uint) error {
+	args := m.Called(ctx, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *mockUserUsecase) RevokeOtherSessions(ctx context.Context, userID, currentSessionID uint) error {
+	args := m.Called(ctx, userID, currentSessionID)
 	return args.Error(0)
 }
 
@@ -103,6 +143,104 @@ func (m *mockUserUsecase) ResendActivationEmail(ctx context.Context, email strin
 	return args.Error(0)
 }
 
+func (m *mockUserUsecase) RequestMagicLink(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *mockUserUsecase) MagicLinkLogin(ctx context.Context, token string, client dto.ClientInfo) (*dto.AuthResponse, *dto.TwoFactorChallengeResponse, error) {
+	args := m.Called(ctx, token, client)
+	var resp *dto.AuthResponse
+	if args.Get(0) != nil {
+		resp = args.Get(0).(*dto.AuthResponse)
+	}
+	var challenge *dto.TwoFactorChallengeResponse
+	if args.Get(1) != nil {
+		challenge = args.Get(1).(*dto.TwoFactorChallengeResponse)
+	}
+	return resp, challenge, args.Error(2)
+}
+
+func (m *mockUserUsecase) ConfirmEmailChange(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *mockUserUsecase) CancelEmailChange(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *mockUserUsecase) UnlockAccount(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *mockUserUsecase) BeginOIDCLogin(ctx context.Context, provider string, userID *uint) (*dto.OIDCAuthorizationResponse, error) {
+	args := m.Called(ctx, provider, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OIDCAuthorizationResponse), args.Error(1)
+}
+
+func (m *mockUserUsecase) OIDCLogin(ctx context.Context, provider, code, state string, client dto.ClientInfo) (*dto.AuthResponse, *dto.TwoFactorChallengeResponse, error) {
+	args := m.Called(ctx, provider, code, state, client)
+	var resp *dto.AuthResponse
+	if args.Get(0) != nil {
+		resp = args.Get(0).(*dto.AuthResponse)
+	}
+	var challenge *dto.TwoFactorChallengeResponse
+	if args.Get(1) != nil {
+		challenge = args.Get(1).(*dto.TwoFactorChallengeResponse)
+	}
+	return resp, challenge, args.Error(2)
+}
+
+func (m *mockUserUsecase) LinkOIDCIdentity(ctx context.Context, userID uint, provider, code, state string) (*domain.UserIdentity, error) {
+	args := m.Called(ctx, userID, provider, code, state)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserIdentity), args.Error(1)
+}
+
+func (m *mockUserUsecase) ConfirmIdentityLink(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *mockUserUsecase) ListIdentities(ctx context.Context, userID uint) ([]*domain.UserIdentity, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.UserIdentity), args.Error(1)
+}
+
+func (m *mockUserUsecase) UnlinkIdentity(ctx context.Context, userID, identityID uint) error {
+	args := m.Called(ctx, userID, identityID)
+	return args.Error(0)
+}
+
+func (m *mockUserUsecase) EnrollTwoFactor(ctx context.Context, userID uint) (*dto.TwoFactorEnrollResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.TwoFactorEnrollResponse), args.Error(1)
+}
+
+func (m *mockUserUsecase) VerifyTwoFactor(ctx context.Context, userID uint, code string) error {
+	args := m.Called(ctx, userID, code)
+	return args.Error(0)
+}
+
+func (m *mockUserUsecase) DisableTwoFactor(ctx context.Context, userID uint, password, code string) error {
+	args := m.Called(ctx, userID, password, code)
+	return args.Error(0)
+}
+
 func (m *mockUserUsecase) UpdateProfile(ctx context.Context, userID uint, name, picture string) error {
 	args := m.Called(ctx, userID, name, picture)
 	return args.Error(0)
@@ -125,19 +263,21 @@ func TestAuthHandler_Login(t *testing.T) {
 				Password: "password123",
 			},
 			mockSetup: func(m *mockUserUsecase) {
-				m.On("Login", mock.Anything, "test@example.com", "password123").Return(&dto.AuthResponse{
-					Token:     "test-token",
+				m.On("Login", mock.Anything, "test@example.com", "password123", mock.Anything).Return(&dto.AuthResponse{
+					Token:        "test-token",
+					RefreshToken: "test-refresh-token",
 					ExpiresAt: time.Now().Add(24 * time.Hour),
 					User: dto.UserResponse{
 						ID:    1,
 						Name:  "Test User",
 						Email: "test@example.com",
 					},
-				}, nil)
+				}, nil, nil)
 			},
 			expectedCode: http.StatusOK,
 			checkBody: func(t *testing.T, body map[string]interface{}) {
 				assert.Equal(t, "test-token", body["token"])
+				assert.Equal(t, "test-refresh-token", body["refresh_token"])
 				user := body["user"].(map[string]interface{})
 				assert.Equal(t, float64(1), user["id"])
 				assert.Equal(t, "Test User", user["name"])
@@ -151,13 +291,61 @@ func TestAuthHandler_Login(t *testing.T) {
 				Password: "wrongpassword",
 			},
 			mockSetup: func(m *mockUserUsecase) {
-				m.On("Login", mock.Anything, "test@example.com", "wrongpassword").Return(nil, errors.New("invalid email or password"))
+				m.On("Login", mock.Anything, "test@example.com", "wrongpassword", mock.Anything).Return(nil, nil, errors.New("invalid email or password"))
 			},
 			expectedCode: http.StatusUnauthorized,
 			checkBody: func(t *testing.T, body map[string]interface{}) {
 				assert.Equal(t, "invalid email or password", body["error"])
 			},
 		},
+		{
+			name: "suspended account",
+			requestBody: dto.LoginRequest{
+				Email:    "banned@example.com",
+				Password: "password123",
+			},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("Login", mock.Anything, "banned@example.com", "password123", mock.Anything).Return(nil, nil, errors.New("account suspended"))
+			},
+			expectedCode: http.StatusForbidden,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "account suspended", body["error"])
+			},
+		},
+		{
+			name: "two-factor challenge",
+			requestBody: dto.LoginRequest{
+				Email:    "2fa@example.com",
+				Password: "password123",
+			},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("Login", mock.Anything, "2fa@example.com", "password123", mock.Anything).Return(nil, &dto.TwoFactorChallengeResponse{
+					TwoFactorRequired: true,
+					ChallengeToken:    "challenge-token",
+					ExpiresAt:         time.Now().Add(5 * time.Minute),
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, true, body["two_factor_required"])
+				assert.Equal(t, "challenge-token", body["challenge_token"])
+				assert.Nil(t, body["token"])
+			},
+		},
+		{
+			name: "too many attempts",
+			requestBody: dto.LoginRequest{
+				Email:    "test@example.com",
+				Password: "password123",
+			},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("Login", mock.Anything, "test@example.com", "password123", mock.Anything).Return(nil, nil, errors.New("too many login attempts"))
+			},
+			expectedCode: http.StatusTooManyRequests,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.NotNil(t, body["error"])
+			},
+		},
 		{
 			name: "invalid request body",
 			requestBody: dto.LoginRequest{
@@ -235,22 +423,21 @@ func TestAuthHandler_Signup(t *testing.T) {
 					Name:     "New User",
 					Email:    "newuser@example.com",
 					Password: "password123",
-				}).Return(&dto.AuthResponse{
-					Token:     "new-token",
-					ExpiresAt: time.Now().Add(24 * time.Hour),
-					User: dto.UserResponse{
-						ID:    2,
-						Name:  "New User",
-						Email: "newuser@example.com",
-					},
+				}).Return(&dto.UserResponse{
+					ID:        2,
+					Name:      "New User",
+					Email:     "newuser@example.com",
+					Activated: false,
 				}, nil)
 			},
 			expectedCode: http.StatusCreated,
 			checkBody: func(t *testing.T, body map[string]interface{}) {
-				assert.Equal(t, "new-token", body["token"])
+				assert.Nil(t, body["token"])
+				assert.NotNil(t, body["message"])
 				user := body["user"].(map[string]interface{})
 				assert.Equal(t, float64(2), user["id"])
 				assert.Equal(t, "New User", user["name"])
+				assert.Equal(t, false, user["activated"])
 			},
 		},
 		{
@@ -272,6 +459,21 @@ func TestAuthHandler_Signup(t *testing.T) {
 				assert.Equal(t, "email already exists", body["error"])
 			},
 		},
+		{
+			name: "breached password",
+			requestBody: dto.SignupRequest{
+				Name:     "New User",
+				Email:    "newuser@example.com",
+				Password: "password123",
+			},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("Signup", mock.Anything, mock.Anything).Return(nil, password.ErrBreached)
+			},
+			expectedCode: http.StatusBadRequest,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "password has appeared in a data breach", body["error"])
+			},
+		},
 		{
 			name: "invalid request body",
 			requestBody: dto.SignupRequest{
@@ -333,41 +535,57 @@ func TestAuthHandler_RefreshToken(t *testing.T) {
 	
 	tests := []struct {
 		name         string
-		token        string
+		requestBody  interface{}
 		mockSetup    func(*mockUserUsecase)
 		expectedCode int
 		checkBody    func(*testing.T, map[string]interface{})
 	}{
 		{
-			name:  "successful token refresh",
-			token: "old-token",
+			name:        "successful token refresh",
+			requestBody: dto.RefreshTokenRequest{RefreshToken: "old-refresh-token"},
 			mockSetup: func(m *mockUserUsecase) {
-				m.On("RefreshToken", mock.Anything, "old-token").Return("new-refreshed-token", nil)
+				m.On("RefreshToken", mock.Anything, "old-refresh-token", mock.Anything).Return(&dto.AuthResponse{
+					Token:        "new-access-token",
+					RefreshToken: "new-refresh-token",
+					ExpiresAt:    time.Now().Add(15 * time.Minute),
+				}, nil)
 			},
 			expectedCode: http.StatusOK,
 			checkBody: func(t *testing.T, body map[string]interface{}) {
-				assert.Equal(t, "new-refreshed-token", body["token"])
+				assert.Equal(t, "new-access-token", body["token"])
+				assert.Equal(t, "new-refresh-token", body["refresh_token"])
 				assert.NotNil(t, body["expires_at"])
 			},
 		},
 		{
-			name:  "invalid token",
-			token: "invalid-token",
+			name:        "invalid refresh token",
+			requestBody: dto.RefreshTokenRequest{RefreshToken: "invalid-token"},
 			mockSetup: func(m *mockUserUsecase) {
-				m.On("RefreshToken", mock.Anything, "invalid-token").Return("", errors.New("invalid token"))
+				m.On("RefreshToken", mock.Anything, "invalid-token", mock.Anything).Return(nil, errors.New("invalid refresh token"))
 			},
 			expectedCode: http.StatusUnauthorized,
 			checkBody: func(t *testing.T, body map[string]interface{}) {
-				assert.Equal(t, "Invalid token", body["error"])
+				assert.Equal(t, "invalid refresh token", body["error"])
 			},
 		},
 		{
-			name:         "missing authorization header",
-			token:        "",
-			mockSetup:    func(m *mockUserUsecase) {},
+			name:        "reused refresh token",
+			requestBody: dto.RefreshTokenRequest{RefreshToken: "used-token"},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("RefreshToken", mock.Anything, "used-token", mock.Anything).Return(nil, errors.New("refresh token reuse detected"))
+			},
 			expectedCode: http.StatusUnauthorized,
 			checkBody: func(t *testing.T, body map[string]interface{}) {
-				assert.Equal(t, "Token required", body["error"])
+				assert.Equal(t, "refresh token reuse detected", body["error"])
+			},
+		},
+		{
+			name:         "missing refresh token",
+			requestBody:  map[string]string{},
+			mockSetup:    func(m *mockUserUsecase) {},
+			expectedCode: http.StatusBadRequest,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.NotNil(t, body["error"])
 			},
 		},
 	}
@@ -381,17 +599,16 @@ func TestAuthHandler_RefreshToken(t *testing.T) {
 			cfg := &config.Config{
 				JWT: config.JWTConfig{
 					Secret:     "test-secret",
-					Expiration: "24h",
+					Expiration: "15m",
 				},
 			}
 			
 			handler := NewAuthHandler(cfg, mockUsecase)
 			
 			// Create request
-			req := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
-			if tt.token != "" {
-				req.Header.Set("Authorization", "Bearer "+tt.token)
-			}
+			jsonBody, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/auth/refresh", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
 			
 			// Create response recorder
 			w := httptest.NewRecorder()
@@ -413,4 +630,285 @@ func TestAuthHandler_RefreshToken(t *testing.T) {
 			mockUsecase.AssertExpectations(t)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestAuthHandler_Logout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	
+	tests := []struct {
+		name         string
+		sessionID    uint
+		mockSetup    func(*mockUserUsecase)
+		expectedCode int
+	}{
+		{
+			name:      "successful logout",
+			sessionID: 10,
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("Logout", mock.Anything, uint(10)).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:      "unknown session",
+			sessionID: 99,
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("Logout", mock.Anything, uint(99)).Return(errors.New("session not found"))
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockUserUsecase)
+			tt.mockSetup(mockUsecase)
+			
+			handler := NewAuthHandler(&config.Config{}, mockUsecase)
+			
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+			c.Set("userID", uint(1))
+			c.Set("sessionID", tt.sessionID)
+			
+			handler.Logout(c)
+			
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_LoginTwoFactor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	
+	tests := []struct {
+		name         string
+		requestBody  map[string]string
+		mockSetup    func(*mockUserUsecase)
+		expectedCode int
+	}{
+		{
+			name:        "successful login",
+			requestBody: map[string]string{"challenge_token": "challenge", "code": "123456"},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("LoginTwoFactor", mock.Anything, "challenge", "123456", mock.Anything).Return(&dto.AuthResponse{
+					Token:        "test-token",
+					RefreshToken: "test-refresh-token",
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:        "invalid code",
+			requestBody: map[string]string{"challenge_token": "challenge", "code": "000000"},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("LoginTwoFactor", mock.Anything, "challenge", "000000", mock.Anything).Return(nil, errors.New("invalid two-factor code"))
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:        "too many attempts",
+			requestBody: map[string]string{"challenge_token": "challenge", "code": "111111"},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("LoginTwoFactor", mock.Anything, "challenge", "111111", mock.Anything).Return(nil, errors.New("too many login attempts"))
+			},
+			expectedCode: http.StatusTooManyRequests,
+		},
+		{
+			name:         "missing code",
+			requestBody:  map[string]string{"challenge_token": "challenge"},
+			mockSetup:    func(m *mockUserUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockUserUsecase)
+			tt.mockSetup(mockUsecase)
+			
+			handler := NewAuthHandler(&config.Config{}, mockUsecase)
+			
+			jsonBody, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/auth/login/2fa", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			
+			handler.LoginTwoFactor(c)
+			
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_MagicLink(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	
+	tests := []struct {
+		name         string
+		path         string
+		requestBody  map[string]string
+		mockSetup    func(*mockUserUsecase)
+		expectedCode int
+	}{
+		{
+			name:        "request for unknown email looks successful",
+			path:        "/auth/magic-link",
+			requestBody: map[string]string{"email": "nobody@example.com"},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("RequestMagicLink", mock.Anything, "nobody@example.com").Return(errors.New("user not found"))
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:        "request rate limited",
+			path:        "/auth/magic-link",
+			requestBody: map[string]string{"email": "test@example.com"},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("RequestMagicLink", mock.Anything, "test@example.com").Return(errors.New("too many requests"))
+			},
+			expectedCode: http.StatusTooManyRequests,
+		},
+		{
+			name:        "successful login",
+			path:        "/auth/magic-link/verify",
+			requestBody: map[string]string{"token": "link-token"},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("MagicLinkLogin", mock.Anything, "link-token", mock.Anything).Return(&dto.AuthResponse{
+					Token:        "test-token",
+					RefreshToken: "test-refresh-token",
+				}, nil, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:        "used or expired link",
+			path:        "/auth/magic-link/verify",
+			requestBody: map[string]string{"token": "used-token"},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("MagicLinkLogin", mock.Anything, "used-token", mock.Anything).Return(nil, nil, errors.New("invalid or expired token"))
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:        "suspended account",
+			path:        "/auth/magic-link/verify",
+			requestBody: map[string]string{"token": "link-token"},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("MagicLinkLogin", mock.Anything, "link-token", mock.Anything).Return(nil, nil, errors.New("account suspended"))
+			},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockUserUsecase)
+			tt.mockSetup(mockUsecase)
+			
+			handler := NewAuthHandler(&config.Config{}, mockUsecase)
+			
+			jsonBody, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			
+			if tt.path == "/auth/magic-link" {
+				handler.RequestMagicLink(c)
+			} else {
+				handler.MagicLinkLogin(c)
+			}
+			
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_OIDCCallback(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	
+	tests := []struct {
+		name         string
+		provider     string
+		requestBody  map[string]string
+		mockSetup    func(*mockUserUsecase)
+		expectedCode int
+	}{
+		{
+			name:        "successful sign-in",
+			provider:    "google",
+			requestBody: map[string]string{"code": "code", "state": "state"},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("OIDCLogin", mock.Anything, "google", "code", "state", mock.Anything).Return(&dto.AuthResponse{Token: "test-token"}, nil, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:        "existing account needs confirmation",
+			provider:    "google",
+			requestBody: map[string]string{"code": "code", "state": "state"},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("OIDCLogin", mock.Anything, "google", "code", "state", mock.Anything).Return(nil, nil, errors.New("link confirmation required"))
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:        "unknown provider",
+			provider:    "myspace",
+			requestBody: map[string]string{"code": "code", "state": "state"},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("OIDCLogin", mock.Anything, "myspace", "code", "state", mock.Anything).Return(nil, nil, errors.New("unknown provider"))
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:        "rejected ID token",
+			provider:    "google",
+			requestBody: map[string]string{"code": "bad", "state": "state"},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("OIDCLogin", mock.Anything, "google", "bad", "state", mock.Anything).Return(nil, nil, errors.New("oidc authentication failed"))
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "missing state",
+			provider:     "google",
+			requestBody:  map[string]string{"code": "code"},
+			mockSetup:    func(m *mockUserUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockUserUsecase)
+			tt.mockSetup(mockUsecase)
+			
+			handler := NewAuthHandler(&config.Config{}, mockUsecase)
+			
+			jsonBody, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/auth/oidc/"+tt.provider+"/callback", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Params = gin.Params{{Key: "provider", Value: tt.provider}}
+			
+			handler.OIDCCallback(c)
+			
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
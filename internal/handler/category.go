@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/dto"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type CategoryHandler struct {
+	categoryUsecase usecase.CategoryUsecase
+}
+
+// NewCategoryHandler creates a new category handler
+func NewCategoryHandler(categoryUsecase usecase.CategoryUsecase) *CategoryHandler {
+	return &CategoryHandler{
+		categoryUsecase: categoryUsecase,
+	}
+}
+
+// GetCategories GET /api/v1/categories
+func (h *CategoryHandler) GetCategories(c *gin.Context) {
+	h.respondWithTree(c, c.GetUint("userID"))
+}
+
+// CreateCategory POST /api/v1/categories
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	userID := c.GetUint("userID") // From auth middleware
+
+	var req dto.CreateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category := &domain.Category{
+		Name:     req.Name,
+		ParentID: req.ParentID,
+	}
+	if err := h.categoryUsecase.CreateCategory(c.Request.Context(), userID, category); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, categoryToResponse(&usecase.CategoryNode{Category: category}))
+}
+
+// RenameCategory PUT /api/v1/categories/:id
+func (h *CategoryHandler) RenameCategory(c *gin.Context) {
+	userID := c.GetUint("userID") // From auth middleware
+
+	categoryID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+
+	var req dto.RenameCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.categoryUsecase.RenameCategory(c.Request.Context(), userID, uint(categoryID), req.Name); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.respondWithTree(c, userID)
+}
+
+// ReorderCategories PUT /api/v1/categories/order
+func (h *CategoryHandler) ReorderCategories(c *gin.Context) {
+	userID := c.GetUint("userID") // From auth middleware
+
+	var req dto.ReorderCategoriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.categoryUsecase.ReorderCategories(c.Request.Context(), userID, req.ParentID, req.CategoryIDs); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.respondWithTree(c, userID)
+}
+
+// MergeCategory POST /api/v1/categories/:id/merge
+func (h *CategoryHandler) MergeCategory(c *gin.Context) {
+	userID := c.GetUint("userID") // From auth middleware
+
+	categoryID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+
+	var req dto.MergeCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.categoryUsecase.MergeCategories(c.Request.Context(), userID, uint(categoryID), req.IntoID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	h.respondWithTree(c, userID)
+}
+
+// respondWithTree responds with the user's category tree
+func (h *CategoryHandler) respondWithTree(c *gin.Context, userID uint) {
+	nodes, err := h.categoryUsecase.GetCategoryTree(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CategoryTreeResponse{Categories: categoriesToResponse(nodes)})
+}
+
+// handleError maps category usecase errors to HTTP responses
+func (h *CategoryHandler) handleError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "category not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "category already exists":
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case "category name required", "category tree too deep", "categories do not match",
+		"cannot merge a category into itself", "cannot merge a category into its own sub-category":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// categoriesToResponse converts category tree nodes to response DTOs
+func categoriesToResponse(nodes []*usecase.CategoryNode) []dto.CategoryResponse {
+	categories := make([]dto.CategoryResponse, len(nodes))
+	for i, node := range nodes {
+		categories[i] = categoryToResponse(node)
+	}
+	return categories
+}
+
+// categoryToResponse converts a category tree node to response DTO
+func categoryToResponse(node *usecase.CategoryNode) dto.CategoryResponse {
+	return dto.CategoryResponse{
+		ID:       node.Category.ID,
+		ParentID: node.Category.ParentID,
+		Name:     node.Category.Name,
+		Position: node.Category.Position,
+		Children: categoriesToResponse(node.Children),
+	}
+}
@@ -0,0 +1,331 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock usecase
+type mockCategoryUsecase struct {
+	mock.Mock
+}
+
+func (m *mockCategoryUsecase) GetCategoryTree(ctx context.Context, userID uint) ([]*usecase.CategoryNode, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*usecase.CategoryNode), args.Error(1)
+}
+
+func (m *mockCategoryUsecase) CreateCategory(ctx context.Context, userID uint, category *domain.Category) error {
+	args := m.Called(ctx, userID, category)
+	return args.Error(0)
+}
+
+func (m *mockCategoryUsecase) RenameCategory(ctx context.Context, userID uint, categoryID uint, name string) error {
+	args := m.Called(ctx, userID, categoryID, name)
+	return args.Error(0)
+}
+
+func (m *mockCategoryUsecase) ReorderCategories(ctx context.Context, userID uint, parentID *uint, categoryIDs []uint) error {
+	args := m.Called(ctx, userID, parentID, categoryIDs)
+	return args.Error(0)
+}
+
+func (m *mockCategoryUsecase) MergeCategories(ctx context.Context, userID uint, sourceID, targetID uint) error {
+	args := m.Called(ctx, userID, sourceID, targetID)
+	return args.Error(0)
+}
+
+// testCategoryTree is トップス → シャツ → オックスフォード followed by ボトムス
+func testCategoryTree() []*usecase.CategoryNode {
+	topsID, shirtsID := uint(1), uint(2)
+	return []*usecase.CategoryNode{
+		{
+			Category: &domain.Category{BaseModel: domain.BaseModel{ID: topsID}, Name: "トップス"},
+			Children: []*usecase.CategoryNode{
+				{
+					Category: &domain.Category{BaseModel: domain.BaseModel{ID: shirtsID}, ParentID: &topsID, Name: "シャツ"},
+					Children: []*usecase.CategoryNode{
+						{Category: &domain.Category{BaseModel: domain.BaseModel{ID: 3}, ParentID: &shirtsID, Name: "オックスフォード"}},
+					},
+				},
+			},
+		},
+		{Category: &domain.Category{BaseModel: domain.BaseModel{ID: 4}, Name: "ボトムス", Position: 1}},
+	}
+}
+
+func TestCategoryHandler_GetCategories(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := new(mockCategoryUsecase)
+	mockUsecase.On("GetCategoryTree", mock.Anything, uint(1)).Return(testCategoryTree(), nil)
+
+	handler := NewCategoryHandler(mockUsecase)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/categories", nil)
+	c.Set("userID", uint(1))
+
+	handler.GetCategories(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var body map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &body)
+	categories := body["categories"].([]interface{})
+	assert.Len(t, categories, 2)
+	tops := categories[0].(map[string]interface{})
+	assert.Equal(t, "トップス", tops["name"])
+	assert.Nil(t, tops["parent_id"])
+	shirts := tops["children"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, float64(1), shirts["parent_id"])
+	oxford := shirts["children"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "オックスフォード", oxford["name"])
+	assert.Empty(t, oxford["children"])
+
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestCategoryHandler_CreateCategory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		body         string
+		mockSetup    func(*mockCategoryUsecase)
+		expectedCode int
+		checkBody    func(*testing.T, map[string]interface{})
+	}{
+		{
+			name: "sub-category",
+			body: `{"name":"シャツ","parent_id":1}`,
+			mockSetup: func(m *mockCategoryUsecase) {
+				m.On("CreateCategory", mock.Anything, uint(1), mock.MatchedBy(func(c *domain.Category) bool {
+					return c.Name == "シャツ" && c.ParentID != nil && *c.ParentID == 1
+				})).Run(func(args mock.Arguments) {
+					category := args.Get(2).(*domain.Category)
+					category.ID = 10
+					category.Position = 2
+				}).Return(nil)
+			},
+			expectedCode: http.StatusCreated,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, float64(10), body["id"])
+				assert.Equal(t, float64(1), body["parent_id"])
+				assert.Equal(t, float64(2), body["position"])
+			},
+		},
+		{
+			name: "duplicate name",
+			body: `{"name":"トップス"}`,
+			mockSetup: func(m *mockCategoryUsecase) {
+				m.On("CreateCategory", mock.Anything, uint(1), mock.Anything).Return(errors.New("category already exists"))
+			},
+			expectedCode: http.StatusConflict,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "category already exists", body["error"])
+			},
+		},
+		{
+			name: "unknown parent",
+			body: `{"name":"シャツ","parent_id":99}`,
+			mockSetup: func(m *mockCategoryUsecase) {
+				m.On("CreateCategory", mock.Anything, uint(1), mock.Anything).Return(errors.New("category not found"))
+			},
+			expectedCode: http.StatusNotFound,
+			checkBody:    func(t *testing.T, body map[string]interface{}) {},
+		},
+		{
+			name: "too deep",
+			body: `{"name":"ボタンダウン","parent_id":5}`,
+			mockSetup: func(m *mockCategoryUsecase) {
+				m.On("CreateCategory", mock.Anything, uint(1), mock.Anything).Return(errors.New("category tree too deep"))
+			},
+			expectedCode: http.StatusBadRequest,
+			checkBody:    func(t *testing.T, body map[string]interface{}) {},
+		},
+		{
+			name:         "missing name",
+			body:         `{"parent_id":1}`,
+			mockSetup:    func(m *mockCategoryUsecase) {},
+			expectedCode: http.StatusBadRequest,
+			checkBody:    func(t *testing.T, body map[string]interface{}) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockCategoryUsecase)
+			tt.mockSetup(mockUsecase)
+
+			handler := NewCategoryHandler(mockUsecase)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/categories", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("userID", uint(1))
+
+			handler.CreateCategory(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			var responseBody map[string]interface{}
+			json.Unmarshal(w.Body.Bytes(), &responseBody)
+			tt.checkBody(t, responseBody)
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCategoryHandler_ReorderCategories(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	parentID := uint(1)
+	tests := []struct {
+		name         string
+		body         string
+		mockSetup    func(*mockCategoryUsecase)
+		expectedCode int
+	}{
+		{
+			name: "top-level categories",
+			body: `{"category_ids":[4,1]}`,
+			mockSetup: func(m *mockCategoryUsecase) {
+				m.On("ReorderCategories", mock.Anything, uint(1), (*uint)(nil), []uint{4, 1}).Return(nil)
+				m.On("GetCategoryTree", mock.Anything, uint(1)).Return(testCategoryTree(), nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "sub-categories",
+			body: `{"parent_id":1,"category_ids":[2]}`,
+			mockSetup: func(m *mockCategoryUsecase) {
+				m.On("ReorderCategories", mock.Anything, uint(1), &parentID, []uint{2}).Return(nil)
+				m.On("GetCategoryTree", mock.Anything, uint(1)).Return(testCategoryTree(), nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "incomplete list",
+			body: `{"category_ids":[4]}`,
+			mockSetup: func(m *mockCategoryUsecase) {
+				m.On("ReorderCategories", mock.Anything, uint(1), (*uint)(nil), []uint{4}).Return(errors.New("categories do not match"))
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "empty list",
+			body:         `{"category_ids":[]}`,
+			mockSetup:    func(m *mockCategoryUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockCategoryUsecase)
+			tt.mockSetup(mockUsecase)
+
+			handler := NewCategoryHandler(mockUsecase)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/api/v1/categories/order", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("userID", uint(1))
+
+			handler.ReorderCategories(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCategoryHandler_MergeCategory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		categoryID   string
+		body         string
+		mockSetup    func(*mockCategoryUsecase)
+		expectedCode int
+	}{
+		{
+			name:       "successful merge",
+			categoryID: "4",
+			body:       `{"into_id":1}`,
+			mockSetup: func(m *mockCategoryUsecase) {
+				m.On("MergeCategories", mock.Anything, uint(1), uint(4), uint(1)).Return(nil)
+				m.On("GetCategoryTree", mock.Anything, uint(1)).Return(testCategoryTree(), nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:       "into own sub-category",
+			categoryID: "1",
+			body:       `{"into_id":3}`,
+			mockSetup: func(m *mockCategoryUsecase) {
+				m.On("MergeCategories", mock.Anything, uint(1), uint(1), uint(3)).Return(errors.New("cannot merge a category into its own sub-category"))
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:       "someone else's category",
+			categoryID: "50",
+			body:       `{"into_id":1}`,
+			mockSetup: func(m *mockCategoryUsecase) {
+				m.On("MergeCategories", mock.Anything, uint(1), uint(50), uint(1)).Return(errors.New("category not found"))
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "invalid category ID",
+			categoryID:   "tops",
+			body:         `{"into_id":1}`,
+			mockSetup:    func(m *mockCategoryUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockCategoryUsecase)
+			tt.mockSetup(mockUsecase)
+
+			handler := NewCategoryHandler(mockUsecase)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/categories/"+tt.categoryID+"/merge", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("userID", uint(1))
+			c.Params = gin.Params{
+				gin.Param{Key: "id", Value: tt.categoryID},
+			}
+
+			handler.MergeCategory(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
@@ -59,9 +59,17 @@ func (h *CoordinateHandler) CreateCoordinate(c *gin.Context) {
 		Rating:         req.Rating,
 	}
 
-	err := h.coordinateUsecase.CreateCoordinate(c.Request.Context(), userID, coordinate, req.ItemIDs, file)
+	items := coordinateItemsFromRequest(req.ItemIDs, req.Items)
+	err := h.coordinateUsecase.CreateCoordinate(c.Request.Context(), userID, coordinate, items, file)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		switch err.Error() {
+		case "coordinate needs at least one item", "item not found", "duplicate item in coordinate", "item not available":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "unauthorized: item does not belong to user":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -145,6 +153,10 @@ func (h *CoordinateHandler) GetUserCoordinates(c *gin.Context) {
 
 	coordinates, total, err := h.coordinateUsecase.GetUserCoordinates(c.Request.Context(), uint(userID), limit, offset)
 	if err != nil {
+		if err.Error() == "user not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -292,13 +304,17 @@ func (h *CoordinateHandler) UpdateCoordinate(c *gin.Context) {
 		updates["rating"] = *req.Rating
 	}
 
-	err = h.coordinateUsecase.UpdateCoordinate(c.Request.Context(), userID, uint(coordinateID), updates, req.ItemIDs, file)
+	items := coordinateItemsFromRequest(req.ItemIDs, req.Items)
+	err = h.coordinateUsecase.UpdateCoordinate(c.Request.Context(), userID, uint(coordinateID), updates, items, file)
 	if err != nil {
-		if err.Error() == "unauthorized" {
+		switch err.Error() {
+		case "unauthorized", "unauthorized: item does not belong to user":
 			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
-			return
+		case "item not found", "duplicate item in coordinate", "item not available":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
@@ -388,6 +404,16 @@ func (h *CoordinateHandler) GetCoordinateComments(c *gin.Context) {
 		return
 	}
 
+	// Comments are only shown while the coordinate itself is visible
+	if _, err := h.coordinateUsecase.GetCoordinate(c.Request.Context(), uint(coordinateID)); err != nil {
+		if err.Error() == "coordinate not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	limit := pagination.PerPage
 	offset := (pagination.Page - 1) * pagination.PerPage
 
@@ -419,6 +445,10 @@ func (h *CoordinateHandler) GetCoordinateComments(c *gin.Context) {
 			CreatedAt: comment.CreatedAt,
 			UpdatedAt: comment.UpdatedAt,
 		}
+		// Comments of purged accounts have no author left to show
+		if comment.UserID == nil {
+			commentResponses[i].User = dto.UserResponse{Name: domain.DeletedUserName}
+		}
 	}
 
 	c.JSON(http.StatusOK, dto.CommentListResponse{
@@ -455,24 +485,7 @@ func (h *CoordinateHandler) coordinateToResponse(c *gin.Context, coordinate *dom
 	commentCount, _ := h.commentRepo.CountByCoordinateID(c.Request.Context(), coordinate.ID)
 
 	// Convert items
-	itemResponses := make([]dto.ItemResponse, len(coordinate.Items))
-	for i, item := range coordinate.Items {
-		itemResponses[i] = dto.ItemResponse{
-			ID:           item.ID,
-			UserID:       item.UserID,
-			CoordinateID: item.CoordinateID,
-			SuperItem:    item.SuperItem,
-			Season:       item.Season,
-			TPO:          item.TPO,
-			Color:        item.Color,
-			Content:      item.Content,
-			Memo:         item.Memo,
-			Picture:      item.Picture,
-			Rating:       item.Rating,
-			CreatedAt:    item.CreatedAt,
-			UpdatedAt:    item.UpdatedAt,
-		}
-	}
+	itemResponses := coordinateItemsToResponse(coordinate.Items)
 
 	return &dto.CoordinateResponse{
 		ID:             coordinate.ID,
@@ -492,6 +505,8 @@ func (h *CoordinateHandler) coordinateToResponse(c *gin.Context, coordinate *dom
 		Memo:           coordinate.Memo,
 		Rating:         coordinate.Rating,
 		Items:          itemResponses,
+		WearCount:      coordinate.WearCount,
+		LastWornAt:     coordinate.LastWornAt,
 		LikeCount:      likeCount,
 		CommentCount:   commentCount,
 		IsLiked:        isLiked,
@@ -507,4 +522,34 @@ func (h *CoordinateHandler) coordinateToResponse(c *gin.Context, coordinate *dom
 		CreatedAt: coordinate.CreatedAt,
 		UpdatedAt: coordinate.UpdatedAt,
 	}
-}
\ No newline at end of file
+}
+
+// coordinateItemsFromRequest lists the requested items in order, preferring
+// the items form (with roles) over the bare item_ids list
+func coordinateItemsFromRequest(itemIDs []uint, items []dto.CoordinateItemRequest) []domain.CoordinateItem {
+	if len(items) > 0 {
+		links := make([]domain.CoordinateItem, len(items))
+		for i, item := range items {
+			links[i] = domain.CoordinateItem{ItemID: item.ItemID, Role: item.Role}
+		}
+		return links
+	}
+	links := make([]domain.CoordinateItem, len(itemIDs))
+	for i, itemID := range itemIDs {
+		links[i] = domain.CoordinateItem{ItemID: itemID}
+	}
+	return links
+}
+
+// coordinateItemsToResponse converts a coordinate's item links to response DTOs
+func coordinateItemsToResponse(links []domain.CoordinateItem) []dto.CoordinateItemResponse {
+	items := make([]dto.CoordinateItemResponse, len(links))
+	for i := range links {
+		items[i] = dto.CoordinateItemResponse{
+			ItemResponse: itemToResponse(&links[i].Item),
+			Position:     links[i].Position,
+			Role:         links[i].Role,
+		}
+	}
+	return items
+}
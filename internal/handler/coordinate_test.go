@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -22,8 +23,8 @@ type mockCoordinateUsecase struct {
 	mock.Mock
 }
 
-func (m *mockCoordinateUsecase) CreateCoordinate(ctx context.Context, userID uint, coordinate *domain.Coordinate, itemIDs []uint, image *multipart.FileHeader) error {
-	args := m.Called(ctx, userID, coordinate, itemIDs, image)
+func (m *mockCoordinateUsecase) CreateCoordinate(ctx context.Context, userID uint, coordinate *domain.Coordinate, items []domain.CoordinateItem, image *multipart.FileHeader) error {
+	args := m.Called(ctx, userID, coordinate, items, image)
 	return args.Error(0)
 }
 
@@ -43,8 +44,8 @@ func (m *mockCoordinateUsecase) GetCoordinateWithDetails(ctx context.Context, co
 	return args.Get(0).(*domain.Coordinate), args.Error(1)
 }
 
-func (m *mockCoordinateUsecase) UpdateCoordinate(ctx context.Context, userID uint, coordinateID uint, updates map[string]interface{}, itemIDs []uint, image *multipart.FileHeader) error {
-	args := m.Called(ctx, userID, coordinateID, updates, itemIDs, image)
+func (m *mockCoordinateUsecase) UpdateCoordinate(ctx context.Context, userID uint, coordinateID uint, updates map[string]interface{}, items []domain.CoordinateItem, image *multipart.FileHeader) error {
+	args := m.Called(ctx, userID, coordinateID, updates, items, image)
 	return args.Error(0)
 }
 
@@ -134,6 +135,14 @@ func (m *mockCommentRepository) FindByCoordinateID(ctx context.Context, coordina
 	return args.Get(0).([]*domain.Comment), args.Error(1)
 }
 
+func (m *mockCommentRepository) FindByUserID(ctx context.Context, userID uint) ([]*domain.Comment, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Comment), args.Error(1)
+}
+
 func (m *mockCommentRepository) CountByCoordinateID(ctx context.Context, coordinateID uint) (int64, error) {
 	args := m.Called(ctx, coordinateID)
 	return args.Get(0).(int64), args.Error(1)
@@ -179,6 +188,14 @@ func (m *mockLikeCoordinateRepository) FindByCoordinateID(ctx context.Context, c
 	return args.Get(0).([]*domain.LikeCoordinate), args.Error(1)
 }
 
+func (m *mockLikeCoordinateRepository) FindByUserID(ctx context.Context, userID uint) ([]*domain.LikeCoordinate, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.LikeCoordinate), args.Error(1)
+}
+
 func (m *mockLikeCoordinateRepository) FindByUserAndCoordinate(ctx context.Context, userID, coordinateID uint) (*domain.LikeCoordinate, error) {
 	args := m.Called(ctx, userID, coordinateID)
 	if args.Get(0) == nil {
@@ -232,13 +249,18 @@ func TestCoordinateHandler_GetCoordinate(t *testing.T) {
 					Picture: "/uploads/coordinate.jpg",
 					Memo:    "Summer casual outfit",
 					Rating:  5,
-					Items: []domain.Item{
+					Items: []domain.CoordinateItem{
 						{
-							BaseModel: domain.BaseModel{
-								ID: 1,
+							CoordinateID: 1,
+							ItemID:       1,
+							Role:         domain.CoordinateItemRoleTop,
+							Item: domain.Item{
+								BaseModel: domain.BaseModel{
+									ID: 1,
+								},
+								SuperItem: "Tシャツ",
+								Color:     domain.ColorBlue,
 							},
-							SuperItem: "Tシャツ",
-							Color:     domain.ColorBlue,
 						},
 					},
 					User: domain.User{
@@ -270,6 +292,11 @@ func TestCoordinateHandler_GetCoordinate(t *testing.T) {
 				
 				items := body["items"].([]interface{})
 				assert.Len(t, items, 1)
+				item := items[0].(map[string]interface{})
+				assert.Equal(t, float64(1), item["id"])
+				assert.Equal(t, "Tシャツ", item["super_item"])
+				assert.Equal(t, float64(0), item["position"])
+				assert.Equal(t, domain.CoordinateItemRoleTop, item["role"])
 				
 				user := body["user"].(map[string]interface{})
 				assert.Equal(t, float64(1), user["id"])
@@ -468,6 +495,106 @@ func TestCoordinateHandler_GetMyCoordinates(t *testing.T) {
 	}
 }
 
+func TestCoordinateHandler_GetCoordinateComments(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	
+	tests := []struct {
+		name         string
+		coordinateID string
+		mockSetup    func(*mockCoordinateUsecase, *mockCommentRepository)
+		expectedCode int
+		checkBody    func(*testing.T, map[string]interface{})
+	}{
+		{
+			name:         "successful get comments",
+			coordinateID: "1",
+			mockSetup: func(m *mockCoordinateUsecase, commentRepo *mockCommentRepository) {
+				commenterID := uint(2)
+				comments := []*domain.Comment{
+					{
+						BaseModel:    domain.BaseModel{ID: 1},
+						UserID:       &commenterID,
+						CoordinateID: 1,
+						Comment:      "Nice outfit",
+						User: domain.User{
+							BaseModel: domain.BaseModel{ID: 2},
+							Name:      "Commenter",
+						},
+					},
+					{
+						BaseModel:    domain.BaseModel{ID: 2},
+						CoordinateID: 1,
+						Comment:      "Left by a purged account",
+					},
+				}
+				m.On("GetCoordinate", mock.Anything, uint(1)).Return(&domain.Coordinate{BaseModel: domain.BaseModel{ID: 1}}, nil)
+				commentRepo.On("FindByCoordinateID", mock.Anything, uint(1), 20, 0).Return(comments, nil)
+				commentRepo.On("CountByCoordinateID", mock.Anything, uint(1)).Return(int64(2), nil)
+			},
+			expectedCode: http.StatusOK,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				comments := body["comments"].([]interface{})
+				assert.Len(t, comments, 2)
+				assert.Equal(t, float64(2), body["total_count"])
+				
+				author := comments[0].(map[string]interface{})
+				assert.Equal(t, float64(2), author["user_id"])
+				assert.Equal(t, "Commenter", author["user"].(map[string]interface{})["name"])
+				
+				purged := comments[1].(map[string]interface{})
+				assert.Nil(t, purged["user_id"])
+				assert.Equal(t, domain.DeletedUserName, purged["user"].(map[string]interface{})["name"])
+			},
+		},
+		{
+			name:         "coordinate of a hidden user",
+			coordinateID: "2",
+			mockSetup: func(m *mockCoordinateUsecase, commentRepo *mockCommentRepository) {
+				m.On("GetCoordinate", mock.Anything, uint(2)).Return(nil, errors.New("coordinate not found"))
+			},
+			expectedCode: http.StatusNotFound,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "coordinate not found", body["error"])
+			},
+		},
+		{
+			name:         "invalid coordinate ID",
+			coordinateID: "invalid",
+			mockSetup:    func(m *mockCoordinateUsecase, commentRepo *mockCommentRepository) {},
+			expectedCode: http.StatusBadRequest,
+			checkBody:    func(t *testing.T, body map[string]interface{}) {},
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockCoordinateUsecase)
+			mockCommentRepo := new(mockCommentRepository)
+			tt.mockSetup(mockUsecase, mockCommentRepo)
+			
+			handler := NewCoordinateHandler(mockUsecase, mockCommentRepo, new(mockLikeCoordinateRepository))
+			
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/coordinates/"+tt.coordinateID+"/comments", nil)
+			c.Params = gin.Params{
+				gin.Param{Key: "id", Value: tt.coordinateID},
+			}
+			
+			handler.GetCoordinateComments(c)
+			
+			assert.Equal(t, tt.expectedCode, w.Code)
+			
+			var responseBody map[string]interface{}
+			json.Unmarshal(w.Body.Bytes(), &responseBody)
+			tt.checkBody(t, responseBody)
+			
+			mockUsecase.AssertExpectations(t)
+			mockCommentRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestCoordinateHandler_LikeCoordinate(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	
@@ -664,4 +791,86 @@ func TestCoordinateHandler_DeleteCoordinate(t *testing.T) {
 			mockUsecase.AssertExpectations(t)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestCoordinateHandler_UpdateCoordinateItems(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		body         string
+		mockSetup    func(*mockCoordinateUsecase)
+		expectedCode int
+	}{
+		{
+			name: "items with roles take precedence over item_ids",
+			body: `{"item_ids": [9], "items": [{"item_id": 4, "role": "outer"}, {"item_id": 1}]}`,
+			mockSetup: func(m *mockCoordinateUsecase) {
+				items := []domain.CoordinateItem{
+					{ItemID: 4, Role: domain.CoordinateItemRoleOuter},
+					{ItemID: 1},
+				}
+				m.On("UpdateCoordinate", mock.Anything, uint(1), uint(1), map[string]interface{}{}, items, (*multipart.FileHeader)(nil)).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "bare item_ids keep their order",
+			body: `{"item_ids": [3, 2]}`,
+			mockSetup: func(m *mockCoordinateUsecase) {
+				items := []domain.CoordinateItem{{ItemID: 3}, {ItemID: 2}}
+				m.On("UpdateCoordinate", mock.Anything, uint(1), uint(1), map[string]interface{}{}, items, (*multipart.FileHeader)(nil)).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "unknown role",
+			body:         `{"items": [{"item_id": 4, "role": "hat"}]}`,
+			mockSetup:    func(m *mockCoordinateUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "item of another user",
+			body: `{"item_ids": [7]}`,
+			mockSetup: func(m *mockCoordinateUsecase) {
+				m.On("UpdateCoordinate", mock.Anything, uint(1), uint(1), mock.Anything, mock.Anything, mock.Anything).
+					Return(errors.New("unauthorized: item does not belong to user"))
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name: "duplicate item",
+			body: `{"item_ids": [2, 2]}`,
+			mockSetup: func(m *mockCoordinateUsecase) {
+				m.On("UpdateCoordinate", mock.Anything, uint(1), uint(1), mock.Anything, mock.Anything, mock.Anything).
+					Return(errors.New("duplicate item in coordinate"))
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockCoordinateUsecase)
+			tt.mockSetup(mockUsecase)
+
+			handler := NewCoordinateHandler(mockUsecase, new(mockCommentRepository), new(mockLikeCoordinateRepository))
+
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/coordinates/1", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Set("userID", uint(1))
+			c.Params = gin.Params{
+				gin.Param{Key: "id", Value: "1"},
+			}
+
+			handler.UpdateCoordinate(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/House-lovers7/speadwear-go/internal/dto"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type DeclutterHandler struct {
+	declutterUsecase usecase.DeclutterUsecase
+}
+
+// NewDeclutterHandler creates a new declutter handler
+func NewDeclutterHandler(declutterUsecase usecase.DeclutterUsecase) *DeclutterHandler {
+	return &DeclutterHandler{
+		declutterUsecase: declutterUsecase,
+	}
+}
+
+// GetForgottenItems GET /api/v1/items/forgotten
+func (h *DeclutterHandler) GetForgottenItems(c *gin.Context) {
+	userID := c.GetUint("userID") // From auth middleware
+
+	var req dto.ForgottenItemsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	groups, err := h.declutterUsecase.GetForgottenItems(c.Request.Context(), userID, req.Days)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := dto.ForgottenItemsResponse{
+		Days:   req.Days,
+		Groups: make([]dto.ForgottenItemGroupResponse, len(groups)),
+	}
+	for i, group := range groups {
+		items := make([]dto.ForgottenItemResponse, len(group.Items))
+		for j, forgotten := range group.Items {
+			items[j] = dto.ForgottenItemResponse{
+				Item:           itemToResponse(forgotten.Item),
+				LastUsedAt:     forgotten.LastUsedAt,
+				UnusedDays:     forgotten.UnusedDays,
+				DeclutterScore: forgotten.DeclutterScore,
+			}
+		}
+		resp.Groups[i] = dto.ForgottenItemGroupResponse{
+			SuperItem: group.SuperItem,
+			Items:     items,
+		}
+		resp.TotalCount += len(items)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateDigest PUT /api/v1/items/forgotten/digest
+func (h *DeclutterHandler) UpdateDigest(c *gin.Context) {
+	userID := c.GetUint("userID") // From auth middleware
+
+	var req dto.DeclutterDigestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.declutterUsecase.SetDigest(c.Request.Context(), userID, *req.Days); err != nil {
+		if err.Error() == "user not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.DeclutterDigestResponse{Days: *req.Days})
+}
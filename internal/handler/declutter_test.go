@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock usecase
+type mockDeclutterUsecase struct {
+	mock.Mock
+}
+
+func (m *mockDeclutterUsecase) GetForgottenItems(ctx context.Context, userID uint, days int) ([]usecase.ForgottenItemGroup, error) {
+	args := m.Called(ctx, userID, days)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]usecase.ForgottenItemGroup), args.Error(1)
+}
+
+func (m *mockDeclutterUsecase) SetDigest(ctx context.Context, userID uint, days int) error {
+	args := m.Called(ctx, userID, days)
+	return args.Error(0)
+}
+
+func (m *mockDeclutterUsecase) SendDigests(ctx context.Context, now time.Time) (int, error) {
+	args := m.Called(ctx, now)
+	return args.Int(0), args.Error(1)
+}
+
+func TestDeclutterHandler_GetForgottenItems(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	lastUsed := time.Now().AddDate(-1, 0, -3)
+	groups := []usecase.ForgottenItemGroup{
+		{SuperItem: "アウター", Items: []usecase.ForgottenItem{
+			{Item: &domain.Item{BaseModel: domain.BaseModel{ID: 1}, SuperItem: "アウター"}, LastUsedAt: lastUsed, UnusedDays: 368, DeclutterScore: 81},
+		}},
+		{SuperItem: "トップス", Items: []usecase.ForgottenItem{
+			{Item: &domain.Item{BaseModel: domain.BaseModel{ID: 2}, SuperItem: "トップス"}, LastUsedAt: lastUsed, UnusedDays: 368, DeclutterScore: 60},
+			{Item: &domain.Item{BaseModel: domain.BaseModel{ID: 3}, SuperItem: "トップス"}, LastUsedAt: lastUsed, UnusedDays: 368, DeclutterScore: 42},
+		}},
+	}
+
+	tests := []struct {
+		name         string
+		query        string
+		mockSetup    func(*mockDeclutterUsecase)
+		expectedCode int
+		checkBody    func(*testing.T, map[string]interface{})
+	}{
+		{
+			name:  "default window",
+			query: "",
+			mockSetup: func(m *mockDeclutterUsecase) {
+				m.On("GetForgottenItems", mock.Anything, uint(1), 365).Return(groups, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, float64(365), body["days"])
+				assert.Equal(t, float64(3), body["total_count"])
+				first := body["groups"].([]interface{})[0].(map[string]interface{})
+				assert.Equal(t, "アウター", first["super_item"])
+				item := first["items"].([]interface{})[0].(map[string]interface{})
+				assert.Equal(t, float64(81), item["declutter_score"])
+				assert.Equal(t, float64(368), item["unused_days"])
+			},
+		},
+		{
+			name:  "custom window",
+			query: "?days=90",
+			mockSetup: func(m *mockDeclutterUsecase) {
+				m.On("GetForgottenItems", mock.Anything, uint(1), 90).Return([]usecase.ForgottenItemGroup{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, float64(0), body["total_count"])
+			},
+		},
+		{
+			name:         "invalid window",
+			query:        "?days=0",
+			mockSetup:    func(m *mockDeclutterUsecase) {},
+			expectedCode: http.StatusBadRequest,
+			checkBody:    func(t *testing.T, body map[string]interface{}) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockDeclutterUsecase)
+			tt.mockSetup(mockUsecase)
+
+			handler := NewDeclutterHandler(mockUsecase)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/items/forgotten"+tt.query, nil)
+			c.Set("userID", uint(1))
+
+			handler.GetForgottenItems(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			var responseBody map[string]interface{}
+			json.Unmarshal(w.Body.Bytes(), &responseBody)
+			tt.checkBody(t, responseBody)
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeclutterHandler_UpdateDigest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		body         string
+		mockSetup    func(*mockDeclutterUsecase)
+		expectedCode int
+	}{
+		{
+			name: "enable",
+			body: `{"days": 180}`,
+			mockSetup: func(m *mockDeclutterUsecase) {
+				m.On("SetDigest", mock.Anything, uint(1), 180).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "disable",
+			body: `{"days": 0}`,
+			mockSetup: func(m *mockDeclutterUsecase) {
+				m.On("SetDigest", mock.Anything, uint(1), 0).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "missing days",
+			body:         `{}`,
+			mockSetup:    func(m *mockDeclutterUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockDeclutterUsecase)
+			tt.mockSetup(mockUsecase)
+
+			handler := NewDeclutterHandler(mockUsecase)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/api/v1/items/forgotten/digest", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("userID", uint(1))
+
+			handler.UpdateDigest(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
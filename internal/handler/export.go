@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/dto"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+)
+
+type ExportHandler struct {
+	exportUsecase usecase.ExportUsecase
+}
+
+// NewExportHandler creates a new account data export handler
+func NewExportHandler(exportUsecase usecase.ExportUsecase) *ExportHandler {
+	return &ExportHandler{
+		exportUsecase: exportUsecase,
+	}
+}
+
+// RequestExport POST /api/v1/users/me/export
+func (h *ExportHandler) RequestExport(c *gin.Context) {
+	export, err := h.exportUsecase.RequestExport(c.Request.Context(), c.GetUint("userID"))
+	if err != nil {
+		if err.Error() == "export already in progress" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start export"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, exportToResponse(export))
+}
+
+// GetExport GET /api/v1/users/me/export
+func (h *ExportHandler) GetExport(c *gin.Context) {
+	export, err := h.exportUsecase.GetLatestExport(c.Request.Context(), c.GetUint("userID"))
+	if err != nil {
+		if err.Error() == "export not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, exportToResponse(export))
+}
+
+// DownloadExport GET /api/v1/exports/download?token=
+func (h *ExportHandler) DownloadExport(c *gin.Context) {
+	export, err := h.exportUsecase.OpenExport(c.Request.Context(), c.Query("token"))
+	if err != nil {
+		if err.Error() == "invalid or expired token" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.FileAttachment(export.FilePath, fmt.Sprintf("speadwear-export-%s.zip", export.CompletedAt.Format("20060102")))
+}
+
+// exportToResponse converts a data export to response DTO
+func exportToResponse(export *domain.DataExport) dto.DataExportResponse {
+	return dto.DataExportResponse{
+		ID:          export.ID,
+		Status:      export.Status,
+		FileSize:    export.FileSize,
+		CreatedAt:   export.CreatedAt,
+		CompletedAt: export.CompletedAt,
+		ExpiresAt:   export.ExpiresAt,
+		Error:       export.Error,
+	}
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+)
+
+// Mock usecase
+type mockExportUsecase struct {
+	mock.Mock
+}
+
+func (m *mockExportUsecase) RequestExport(ctx context.Context, userID uint) (*domain.DataExport, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DataExport), args.Error(1)
+}
+
+func (m *mockExportUsecase) GetLatestExport(ctx context.Context, userID uint) (*domain.DataExport, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DataExport), args.Error(1)
+}
+
+func (m *mockExportUsecase) OpenExport(ctx context.Context, token string) (*domain.DataExport, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DataExport), args.Error(1)
+}
+
+func TestExportHandler_RequestExport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		mockSetup    func(*mockExportUsecase)
+		expectedCode int
+	}{
+		{
+			name: "export queued",
+			mockSetup: func(m *mockExportUsecase) {
+				m.On("RequestExport", mock.Anything, uint(1)).
+					Return(&domain.DataExport{BaseModel: domain.BaseModel{ID: 3}, UserID: 1, Status: domain.ExportStatusPending}, nil)
+			},
+			expectedCode: http.StatusAccepted,
+		},
+		{
+			name: "export already running",
+			mockSetup: func(m *mockExportUsecase) {
+				m.On("RequestExport", mock.Anything, uint(1)).Return(nil, errors.New("export already in progress"))
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockExportUsecase)
+			tt.mockSetup(mockUsecase)
+
+			handler := NewExportHandler(mockUsecase)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/users/me/export", nil)
+			c.Set("userID", uint(1))
+
+			handler.RequestExport(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestExportHandler_DownloadExport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	archive := filepath.Join(t.TempDir(), "export.zip")
+	if err := os.WriteFile(archive, []byte("zip-data"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	completedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		token        string
+		mockSetup    func(*mockExportUsecase)
+		expectedCode int
+		expectFile   bool
+	}{
+		{
+			name:  "valid link",
+			token: "valid-token",
+			mockSetup: func(m *mockExportUsecase) {
+				m.On("OpenExport", mock.Anything, "valid-token").
+					Return(&domain.DataExport{Status: domain.ExportStatusCompleted, FilePath: archive, CompletedAt: &completedAt}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectFile:   true,
+		},
+		{
+			name:  "expired link",
+			token: "old-token",
+			mockSetup: func(m *mockExportUsecase) {
+				m.On("OpenExport", mock.Anything, "old-token").Return(nil, errors.New("invalid or expired token"))
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockExportUsecase)
+			tt.mockSetup(mockUsecase)
+
+			handler := NewExportHandler(mockUsecase)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/exports/download?token="+tt.token, nil)
+
+			handler.DownloadExport(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectFile {
+				assert.Equal(t, "zip-data", w.Body.String())
+				assert.Contains(t, w.Header().Get("Content-Disposition"), "speadwear-export-20240501.zip")
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/House-lovers7/speadwear-go/internal/domain"
@@ -36,35 +37,34 @@ func (h *ItemHandler) CreateItem(c *gin.Context) {
 	file, _ := c.FormFile("picture")
 
 	item := &domain.Item{
-		SuperItem: req.SuperItem,
-		Season:    req.Season,
-		TPO:       req.TPO,
-		Color:     req.Color,
-		Content:   req.Content,
-		Memo:      req.Memo,
-		Rating:    req.Rating,
+		SuperItem:     req.SuperItem,
+		Season:        req.Season,
+		TPO:           req.TPO,
+		Color:         req.Color,
+		Content:       req.Content,
+		Memo:          req.Memo,
+		Rating:        req.Rating,
+		PurchasePrice: req.PurchasePrice,
+		Currency:      req.Currency,
+		CategoryID:    req.CategoryID,
+	}
+	if req.PurchasedOn != "" {
+		purchasedOn, _ := time.ParseInLocation(domain.DateLayout, req.PurchasedOn, time.Local) // validated by binding
+		item.PurchasedOn = &purchasedOn
 	}
 
 	err := h.itemUsecase.CreateItem(c.Request.Context(), userID, item, file)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		switch err.Error() {
+		case "category required", "category not found":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
-	c.JSON(http.StatusCreated, dto.ItemResponse{
-		ID:        item.ID,
-		UserID:    item.UserID,
-		SuperItem: item.SuperItem,
-		Season:    item.Season,
-		TPO:       item.TPO,
-		Color:     item.Color,
-		Content:   item.Content,
-		Memo:      item.Memo,
-		Picture:   item.Picture,
-		Rating:    item.Rating,
-		CreatedAt: item.CreatedAt,
-		UpdatedAt: item.UpdatedAt,
-	})
+	c.JSON(http.StatusCreated, itemToResponse(item))
 }
 
 // GetItem GET /api/v1/items/:id
@@ -81,21 +81,7 @@ func (h *ItemHandler) GetItem(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.ItemResponse{
-		ID:           item.ID,
-		UserID:       item.UserID,
-		CoordinateID: item.CoordinateID,
-		SuperItem:    item.SuperItem,
-		Season:       item.Season,
-		TPO:          item.TPO,
-		Color:        item.Color,
-		Content:      item.Content,
-		Memo:         item.Memo,
-		Picture:      item.Picture,
-		Rating:       item.Rating,
-		CreatedAt:    item.CreatedAt,
-		UpdatedAt:    item.UpdatedAt,
-	})
+	c.JSON(http.StatusOK, itemToResponse(item))
 }
 
 // GetMyItems GET /api/v1/items
@@ -119,21 +105,7 @@ func (h *ItemHandler) GetMyItems(c *gin.Context) {
 
 	itemResponses := make([]dto.ItemResponse, len(items))
 	for i, item := range items {
-		itemResponses[i] = dto.ItemResponse{
-			ID:           item.ID,
-			UserID:       item.UserID,
-			CoordinateID: item.CoordinateID,
-			SuperItem:    item.SuperItem,
-			Season:       item.Season,
-			TPO:          item.TPO,
-			Color:        item.Color,
-			Content:      item.Content,
-			Memo:         item.Memo,
-			Picture:      item.Picture,
-			Rating:       item.Rating,
-			CreatedAt:    item.CreatedAt,
-			UpdatedAt:    item.UpdatedAt,
-		}
+		itemResponses[i] = itemToResponse(item)
 	}
 
 	c.JSON(http.StatusOK, dto.ItemListResponse{
@@ -163,27 +135,17 @@ func (h *ItemHandler) GetUserItems(c *gin.Context) {
 
 	items, total, err := h.itemUsecase.GetUserItems(c.Request.Context(), uint(userID), limit, offset)
 	if err != nil {
+		if err.Error() == "user not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	itemResponses := make([]dto.ItemResponse, len(items))
 	for i, item := range items {
-		itemResponses[i] = dto.ItemResponse{
-			ID:           item.ID,
-			UserID:       item.UserID,
-			CoordinateID: item.CoordinateID,
-			SuperItem:    item.SuperItem,
-			Season:       item.Season,
-			TPO:          item.TPO,
-			Color:        item.Color,
-			Content:      item.Content,
-			Memo:         item.Memo,
-			Picture:      item.Picture,
-			Rating:       item.Rating,
-			CreatedAt:    item.CreatedAt,
-			UpdatedAt:    item.UpdatedAt,
-		}
+		itemResponses[i] = itemToResponse(item)
 	}
 
 	c.JSON(http.StatusOK, dto.ItemListResponse{
@@ -210,9 +172,13 @@ func (h *ItemHandler) SearchItems(c *gin.Context) {
 		SuperItem: filter.SuperItem,
 		MinRating: filter.MinRating,
 		MaxRating: filter.MaxRating,
+		Statuses:  filter.Status,
 		Limit:     filter.PerPage,
 		Offset:    (filter.Page - 1) * filter.PerPage,
 	}
+	if filter.CategoryID != nil {
+		repoFilter.CategoryIDs = []uint{*filter.CategoryID}
+	}
 
 	items, err := h.itemUsecase.SearchItems(c.Request.Context(), repoFilter)
 	if err != nil {
@@ -222,21 +188,7 @@ func (h *ItemHandler) SearchItems(c *gin.Context) {
 
 	itemResponses := make([]dto.ItemResponse, len(items))
 	for i, item := range items {
-		itemResponses[i] = dto.ItemResponse{
-			ID:           item.ID,
-			UserID:       item.UserID,
-			CoordinateID: item.CoordinateID,
-			SuperItem:    item.SuperItem,
-			Season:       item.Season,
-			TPO:          item.TPO,
-			Color:        item.Color,
-			Content:      item.Content,
-			Memo:         item.Memo,
-			Picture:      item.Picture,
-			Rating:       item.Rating,
-			CreatedAt:    item.CreatedAt,
-			UpdatedAt:    item.UpdatedAt,
-		}
+		itemResponses[i] = itemToResponse(item)
 	}
 
 	c.JSON(http.StatusOK, dto.ItemListResponse{
@@ -289,20 +241,75 @@ func (h *ItemHandler) UpdateItem(c *gin.Context) {
 	if req.Rating != nil {
 		updates["rating"] = *req.Rating
 	}
+	if req.PurchasePrice != nil {
+		updates["purchase_price"] = *req.PurchasePrice
+	}
+	if req.Currency != nil {
+		updates["currency"] = *req.Currency
+	}
+	if req.PurchasedOn != nil {
+		updates["purchased_on"], _ = time.ParseInLocation(domain.DateLayout, *req.PurchasedOn, time.Local)
+	}
+	if req.CategoryID != nil {
+		updates["category_id"] = *req.CategoryID
+	}
 
 	err = h.itemUsecase.UpdateItem(c.Request.Context(), userID, uint(itemID), updates, file)
 	if err != nil {
-		if err.Error() == "unauthorized" {
+		switch err.Error() {
+		case "unauthorized":
 			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
-			return
+		case "category not found":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Item updated successfully"})
 }
 
+// ChangeItemStatus PUT /api/v1/items/:id/status
+func (h *ItemHandler) ChangeItemStatus(c *gin.Context) {
+	userID := c.GetUint("userID") // From auth middleware
+
+	itemID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		return
+	}
+
+	var req dto.ChangeItemStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.itemUsecase.ChangeItemStatus(c.Request.Context(), userID, uint(itemID), req.Status)
+	if err != nil {
+		switch err.Error() {
+		case "item not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "unauthorized":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case "invalid item status", "item already has this status":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	item, err := h.itemUsecase.GetItem(c.Request.Context(), uint(itemID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, itemToResponse(item))
+}
+
 // DeleteItem DELETE /api/v1/items/:id
 func (h *ItemHandler) DeleteItem(c *gin.Context) {
 	userID := c.GetUint("userID") // From auth middleware
@@ -363,4 +370,55 @@ func (h *ItemHandler) GetItemStatistics(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, stats)
-}
\ No newline at end of file
+}
+
+// GetWardrobeAnalytics GET /api/v1/items/analytics
+func (h *ItemHandler) GetWardrobeAnalytics(c *gin.Context) {
+	userID := c.GetUint("userID") // From auth middleware
+
+	var req dto.WardrobeAnalyticsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	analytics, err := h.itemUsecase.GetWardrobeAnalytics(c.Request.Context(), userID, req.Currency)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := dto.WardrobeAnalyticsResponse{
+		Currency:         analytics.Currency,
+		Currencies:       analytics.Currencies,
+		ItemCount:        analytics.ItemCount,
+		PricedItemCount:  analytics.PricedItemCount,
+		TotalValue:       analytics.TotalValue,
+		TotalWears:       analytics.TotalWears,
+		CostPerWear:      analytics.CostPerWear,
+		MonthlySpending:  make([]dto.MonthlySpendingResponse, len(analytics.MonthlySpending)),
+		CategorySpending: make([]dto.CategorySpendingResponse, len(analytics.CategorySpending)),
+		BestValue:        itemValuesToResponse(analytics.BestValue),
+		WorstValue:       itemValuesToResponse(analytics.WorstValue),
+	}
+	for i, bucket := range analytics.MonthlySpending {
+		resp.MonthlySpending[i] = dto.MonthlySpendingResponse{Month: bucket.Key, Amount: bucket.Amount, ItemCount: bucket.ItemCount}
+	}
+	for i, bucket := range analytics.CategorySpending {
+		resp.CategorySpending[i] = dto.CategorySpendingResponse{Category: bucket.Key, Amount: bucket.Amount, ItemCount: bucket.ItemCount}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// itemValuesToResponse converts ranked item values to response DTOs
+func itemValuesToResponse(values []usecase.ItemValue) []dto.ItemValueResponse {
+	resp := make([]dto.ItemValueResponse, len(values))
+	for i, value := range values {
+		resp[i] = dto.ItemValueResponse{
+			Item:        itemToResponse(value.Item),
+			CostPerWear: value.CostPerWear,
+		}
+	}
+	return resp
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/House-lovers7/speadwear-go/internal/domain"
 	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
 )
 
 // Mock usecase
@@ -75,6 +76,19 @@ func (m *mockItemUsecase) GetUserItemStatistics(ctx context.Context, userID uint
 	return args.Get(0).(map[string]interface{}), args.Error(1)
 }
 
+func (m *mockItemUsecase) GetWardrobeAnalytics(ctx context.Context, userID uint, currency string) (*usecase.WardrobeAnalytics, error) {
+	args := m.Called(ctx, userID, currency)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.WardrobeAnalytics), args.Error(1)
+}
+
+func (m *mockItemUsecase) ChangeItemStatus(ctx context.Context, userID uint, itemID uint, status string) error {
+	args := m.Called(ctx, userID, itemID, status)
+	return args.Error(0)
+}
+
 func TestItemHandler_GetItem(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	
@@ -566,4 +580,206 @@ func TestItemHandler_GetItemStatistics(t *testing.T) {
 			mockUsecase.AssertExpectations(t)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestItemHandler_GetWardrobeAnalytics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	price := 12000.0
+	tests := []struct {
+		name         string
+		query        string
+		mockSetup    func(*mockItemUsecase)
+		expectedCode int
+		checkBody    func(*testing.T, map[string]interface{})
+	}{
+		{
+			name:  "default currency",
+			query: "",
+			mockSetup: func(m *mockItemUsecase) {
+				coat := &domain.Item{
+					BaseModel:     domain.BaseModel{ID: 3},
+					SuperItem:     "アウター",
+					PurchasePrice: &price,
+					Currency:      "JPY",
+					WearStats:     domain.WearStats{WearCount: 4},
+				}
+				m.On("GetWardrobeAnalytics", mock.Anything, uint(1), "").Return(&usecase.WardrobeAnalytics{
+					Currency:         "JPY",
+					Currencies:       []string{"JPY"},
+					ItemCount:        2,
+					PricedItemCount:  1,
+					TotalValue:       12000,
+					TotalWears:       4,
+					CostPerWear:      3000,
+					MonthlySpending:  []usecase.SpendingBucket{{Key: "2024-10", Amount: 12000, ItemCount: 1}},
+					CategorySpending: []usecase.SpendingBucket{{Key: "アウター", Amount: 12000, ItemCount: 1}},
+					BestValue:        []usecase.ItemValue{{Item: coat, CostPerWear: 3000}},
+					WorstValue:       []usecase.ItemValue{{Item: coat, CostPerWear: 3000}},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "JPY", body["currency"])
+				assert.Equal(t, float64(12000), body["total_value"])
+				assert.Equal(t, float64(3000), body["cost_per_wear"])
+				monthly := body["monthly_spending"].([]interface{})
+				assert.Equal(t, "2024-10", monthly[0].(map[string]interface{})["month"])
+				category := body["category_spending"].([]interface{})
+				assert.Equal(t, "アウター", category[0].(map[string]interface{})["category"])
+				best := body["best_value"].([]interface{})[0].(map[string]interface{})
+				assert.Equal(t, float64(3000), best["cost_per_wear"])
+				assert.Equal(t, float64(12000), best["item"].(map[string]interface{})["purchase_price"])
+			},
+		},
+		{
+			name:  "explicit currency",
+			query: "?currency=usd",
+			mockSetup: func(m *mockItemUsecase) {
+				m.On("GetWardrobeAnalytics", mock.Anything, uint(1), "usd").Return(&usecase.WardrobeAnalytics{Currency: "USD"}, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "USD", body["currency"])
+			},
+		},
+		{
+			name:         "invalid currency",
+			query:        "?currency=dollars",
+			mockSetup:    func(m *mockItemUsecase) {},
+			expectedCode: http.StatusBadRequest,
+			checkBody:    func(t *testing.T, body map[string]interface{}) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockItemUsecase)
+			tt.mockSetup(mockUsecase)
+
+			handler := NewItemHandler(mockUsecase)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/items/analytics"+tt.query, nil)
+			c.Set("userID", uint(1))
+
+			handler.GetWardrobeAnalytics(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			var responseBody map[string]interface{}
+			json.Unmarshal(w.Body.Bytes(), &responseBody)
+			tt.checkBody(t, responseBody)
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_ChangeItemStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	changedAt := time.Date(2024, 11, 2, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		itemID       string
+		body         string
+		mockSetup    func(*mockItemUsecase)
+		expectedCode int
+		checkBody    func(*testing.T, map[string]interface{})
+	}{
+		{
+			name:   "successful change",
+			itemID: "1",
+			body:   `{"status":"donated"}`,
+			mockSetup: func(m *mockItemUsecase) {
+				m.On("ChangeItemStatus", mock.Anything, uint(1), uint(1), "donated").Return(nil)
+				m.On("GetItem", mock.Anything, uint(1)).Return(&domain.Item{
+					BaseModel:       domain.BaseModel{ID: 1},
+					UserID:          1,
+					Status:          domain.ItemStatusDonated,
+					StatusChangedAt: &changedAt,
+					StatusChanges: []domain.ItemStatusChange{
+						{ItemID: 1, FromStatus: domain.ItemStatusActive, ToStatus: domain.ItemStatusDonated, ChangedAt: changedAt},
+					},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "donated", body["status"])
+				history := body["status_history"].([]interface{})
+				assert.Len(t, history, 1)
+				assert.Equal(t, "active", history[0].(map[string]interface{})["from_status"])
+			},
+		},
+		{
+			name:   "same status",
+			itemID: "1",
+			body:   `{"status":"active"}`,
+			mockSetup: func(m *mockItemUsecase) {
+				m.On("ChangeItemStatus", mock.Anything, uint(1), uint(1), "active").Return(errors.New("item already has this status"))
+			},
+			expectedCode: http.StatusBadRequest,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "item already has this status", body["error"])
+			},
+		},
+		{
+			name:   "someone else's item",
+			itemID: "2",
+			body:   `{"status":"in_laundry"}`,
+			mockSetup: func(m *mockItemUsecase) {
+				m.On("ChangeItemStatus", mock.Anything, uint(1), uint(2), "in_laundry").Return(errors.New("unauthorized"))
+			},
+			expectedCode: http.StatusForbidden,
+			checkBody:    func(t *testing.T, body map[string]interface{}) {},
+		},
+		{
+			name:   "item not found",
+			itemID: "999",
+			body:   `{"status":"archived"}`,
+			mockSetup: func(m *mockItemUsecase) {
+				m.On("ChangeItemStatus", mock.Anything, uint(1), uint(999), "archived").Return(errors.New("item not found"))
+			},
+			expectedCode: http.StatusNotFound,
+			checkBody:    func(t *testing.T, body map[string]interface{}) {},
+		},
+		{
+			name:         "unknown status",
+			itemID:       "1",
+			body:         `{"status":"lost"}`,
+			mockSetup:    func(m *mockItemUsecase) {},
+			expectedCode: http.StatusBadRequest,
+			checkBody:    func(t *testing.T, body map[string]interface{}) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockItemUsecase)
+			tt.mockSetup(mockUsecase)
+
+			handler := NewItemHandler(mockUsecase)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/api/v1/items/"+tt.itemID+"/status", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("userID", uint(1))
+			c.Params = gin.Params{
+				gin.Param{Key: "id", Value: tt.itemID},
+			}
+
+			handler.ChangeItemStatus(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			var responseBody map[string]interface{}
+			json.Unmarshal(w.Body.Bytes(), &responseBody)
+			tt.checkBody(t, responseBody)
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/House-lovers7/speadwear-go/pkg/utils"
+)
+
+type KeysHandler struct {
+	keys *utils.KeySet
+}
+
+// NewKeysHandler creates a new handler publishing the JWT verification keys
+func NewKeysHandler(keys *utils.KeySet) *KeysHandler {
+	return &KeysHandler{
+		keys: keys,
+	}
+}
+
+// JWKS GET /.well-known/jwks.json
+func (h *KeysHandler) JWKS(c *gin.Context) {
+	// Short cache so verifiers pick up a rotated key quickly
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.keys.JWKS())
+}
@@ -140,6 +140,7 @@ func (m *mockSocialUsecase) CreateNotification(ctx context.Context, notification
 func TestSocialHandler_CreateComment(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	
+	authorID := uint(1)
 	tests := []struct {
 		name         string
 		userID       uint
@@ -162,7 +163,7 @@ func TestSocialHandler_CreateComment(t *testing.T) {
 						CreatedAt: time.Now(),
 						UpdatedAt: time.Now(),
 					},
-					UserID:       1,
+					UserID:       &authorID,
 					CoordinateID: 1,
 					Comment:      "Nice outfit!",
 				}, nil)
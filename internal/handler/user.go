@@ -5,8 +5,10 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/House-lovers7/speadwear-go/internal/domain"
 	"github.com/House-lovers7/speadwear-go/internal/dto"
 	"github.com/House-lovers7/speadwear-go/internal/usecase"
+	"github.com/House-lovers7/speadwear-go/pkg/password"
 )
 
 type UserHandler struct {
@@ -62,13 +64,14 @@ func (h *UserHandler) GetMe(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, dto.UserResponse{
-		ID:        user.ID,
-		Name:      user.Name,
-		Email:     user.Email,
-		Picture:   user.Picture,
-		Admin:     user.Admin,
-		Activated: user.Activated,
-		CreatedAt: user.CreatedAt,
+		ID:               user.ID,
+		Name:             user.Name,
+		Email:            user.Email,
+		Picture:          user.Picture,
+		Admin:            user.Admin,
+		Activated:        user.Activated,
+		CreatedAt:        user.CreatedAt,
+		UnconfirmedEmail: user.UnconfirmedEmail,
 	})
 }
 
@@ -142,9 +145,7 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	}
 
 	// Check if user can update (must be same user or admin)
-	currentUserID := c.GetUint("userID")
-	if currentUserID != uint(userID) {
-		// TODO: Check admin permission
+	if !canManageUser(c, uint(userID)) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
 		return
 	}
@@ -157,7 +158,19 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 
 	err = h.userUsecase.UpdateUser(c.Request.Context(), uint(userID), updates)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		switch err.Error() {
+		case "user not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "email already exists", "email recently changed":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	if _, ok := updates["email"]; ok {
+		c.JSON(http.StatusOK, gin.H{"message": "User updated. If the email changed, confirm it from the link sent to the new address"})
 		return
 	}
 
@@ -173,39 +186,41 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	}
 
 	// Check if user can delete (must be same user or admin)
-	currentUserID := c.GetUint("userID")
-	if currentUserID != uint(userID) {
-		// TODO: Check admin permission
+	if !canManageUser(c, uint(userID)) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
 		return
 	}
 
-	err = h.userUsecase.DeleteUser(c.Request.Context(), uint(userID))
+	scheduledAt, err := h.userUsecase.DeleteUser(c.Request.Context(), uint(userID))
 	if err != nil {
+		if err.Error() == "user not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "User deleted successfully"})
+	c.JSON(http.StatusOK, gin.H{
+		"message":               "Account scheduled for deletion. Log in before the scheduled time to cancel.",
+		"deletion_scheduled_at": scheduledAt,
+	})
 }
 
 // ChangePassword PUT /api/v1/users/password
 func (h *UserHandler) ChangePassword(c *gin.Context) {
 	userID := c.GetUint("userID") // From auth middleware
 
-	var req struct {
-		OldPassword string `json:"old_password" binding:"required"`
-		NewPassword string `json:"new_password" binding:"required,min=6"`
-	}
-
+	var req dto.ChangePasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	err := h.userUsecase.ChangePassword(c.Request.Context(), userID, req.OldPassword, req.NewPassword)
+	revokeOtherSessions := req.RevokeOtherSessions == nil || *req.RevokeOtherSessions
+	err := h.userUsecase.ChangePassword(c.Request.Context(), userID, c.GetUint("sessionID"), req.OldPassword, req.NewPassword, revokeOtherSessions)
 	if err != nil {
-		if err.Error() == "invalid old password" {
+		if err.Error() == "invalid old password" || password.IsPolicyError(err) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
@@ -226,6 +241,10 @@ func (h *UserHandler) ResetPasswordRequest(c *gin.Context) {
 
 	err := h.userUsecase.ResetPasswordRequest(c.Request.Context(), req.Email)
 	if err != nil {
+		if err.Error() == "too many requests" {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many password reset requests. Please try again later"})
+			return
+		}
 		// Don't reveal if email exists or not
 		c.JSON(http.StatusOK, gin.H{"message": "If the email exists, a password reset link has been sent"})
 		return
@@ -244,6 +263,11 @@ func (h *UserHandler) ResetPassword(c *gin.Context) {
 
 	err := h.userUsecase.ResetPassword(c.Request.Context(), req.Token, req.NewPassword)
 	if err != nil {
+		// The token stays valid, so the user can retry with another password
+		if password.IsPolicyError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired token"})
 		return
 	}
@@ -271,6 +295,74 @@ func (h *UserHandler) ActivateAccount(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Account activated successfully"})
 }
 
+// ConfirmEmailChange POST /api/v1/users/email/confirm
+func (h *UserHandler) ConfirmEmailChange(c *gin.Context) {
+	var req struct {
+		Token string `json:"token" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.userUsecase.ConfirmEmailChange(c.Request.Context(), req.Token)
+	if err != nil {
+		if err.Error() == "email already exists" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email changed successfully"})
+}
+
+// CancelEmailChange POST /api/v1/users/email/cancel
+func (h *UserHandler) CancelEmailChange(c *gin.Context) {
+	var req struct {
+		Token string `json:"token" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.userUsecase.CancelEmailChange(c.Request.Context(), req.Token)
+	if err != nil {
+		if err.Error() == "email already exists" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email change cancelled"})
+}
+
+// UnlockAccount POST /api/v1/users/unlock
+func (h *UserHandler) UnlockAccount(c *gin.Context) {
+	var req struct {
+		Token string `json:"token" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.userUsecase.UnlockAccount(c.Request.Context(), req.Token)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Account unlocked successfully"})
+}
+
 // ResendActivationEmail POST /api/v1/users/activate/resend
 func (h *UserHandler) ResendActivationEmail(c *gin.Context) {
 	var req struct {
@@ -290,4 +382,223 @@ func (h *UserHandler) ResendActivationEmail(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "If the email exists and account is not activated, an activation link has been sent"})
-}
\ No newline at end of file
+}
+
+// EnrollTwoFactor POST /api/v1/users/me/2fa
+func (h *UserHandler) EnrollTwoFactor(c *gin.Context) {
+	enrollment, err := h.userUsecase.EnrollTwoFactor(c.Request.Context(), c.GetUint("userID"))
+	if err != nil {
+		if err.Error() == "two-factor already enabled" {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// VerifyTwoFactor POST /api/v1/users/me/2fa/verify
+func (h *UserHandler) VerifyTwoFactor(c *gin.Context) {
+	var req dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.userUsecase.VerifyTwoFactor(c.Request.Context(), c.GetUint("userID"), req.Code)
+	if err != nil {
+		switch err.Error() {
+		case "invalid two-factor code", "two-factor not enrolled":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case "two-factor already enabled":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication enabled"})
+}
+
+// DisableTwoFactor DELETE /api/v1/users/me/2fa
+func (h *UserHandler) DisableTwoFactor(c *gin.Context) {
+	var req dto.DisableTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.userUsecase.DisableTwoFactor(c.Request.Context(), c.GetUint("userID"), req.Password, req.Code)
+	if err != nil {
+		switch err.Error() {
+		case "invalid password", "invalid two-factor code", "two-factor not enabled":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+// ListIdentities GET /api/v1/users/me/identities
+func (h *UserHandler) ListIdentities(c *gin.Context) {
+	identities, err := h.userUsecase.ListIdentities(c.Request.Context(), c.GetUint("userID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	identityResponses := make([]dto.IdentityResponse, len(identities))
+	for i, identity := range identities {
+		identityResponses[i] = identityToResponse(identity)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"identities": identityResponses})
+}
+
+// BeginIdentityLink POST /api/v1/users/me/identities/:provider
+func (h *UserHandler) BeginIdentityLink(c *gin.Context) {
+	userID := c.GetUint("userID")
+
+	authorization, err := h.userUsecase.BeginOIDCLogin(c.Request.Context(), c.Param("provider"), &userID)
+	if err != nil {
+		respondOIDCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, authorization)
+}
+
+// LinkIdentity POST /api/v1/users/me/identities/:provider/callback
+func (h *UserHandler) LinkIdentity(c *gin.Context) {
+	var req dto.OIDCCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	identity, err := h.userUsecase.LinkOIDCIdentity(c.Request.Context(), c.GetUint("userID"), c.Param("provider"), req.Code, req.State)
+	if err != nil {
+		respondOIDCError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, identityToResponse(identity))
+}
+
+// UnlinkIdentity DELETE /api/v1/users/me/identities/:id
+func (h *UserHandler) UnlinkIdentity(c *gin.Context) {
+	identityID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid identity ID"})
+		return
+	}
+
+	err = h.userUsecase.UnlinkIdentity(c.Request.Context(), c.GetUint("userID"), uint(identityID))
+	if err != nil {
+		switch err.Error() {
+		case "identity not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "cannot remove last login method":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Identity unlinked successfully"})
+}
+
+// ListSessions GET /api/v1/users/me/sessions
+func (h *UserHandler) ListSessions(c *gin.Context) {
+	userID := c.GetUint("userID") // From auth middleware
+	currentSessionID := c.GetUint("sessionID")
+
+	sessions, err := h.userUsecase.ListSessions(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	sessionResponses := make([]dto.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		sessionResponses[i] = dto.SessionResponse{
+			ID:         session.ID,
+			DeviceName: session.DeviceName,
+			IPAddress:  session.IPAddress,
+			UserAgent:  session.UserAgent,
+			Current:    session.ID == currentSessionID,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			CreatedAt:  session.CreatedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sessions": sessionResponses})
+}
+
+// RevokeSession DELETE /api/v1/users/me/sessions/:id
+func (h *UserHandler) RevokeSession(c *gin.Context) {
+	userID := c.GetUint("userID") // From auth middleware
+
+	sessionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		return
+	}
+
+	err = h.userUsecase.RevokeSession(c.Request.Context(), userID, uint(sessionID))
+	if err != nil {
+		if err.Error() == "session not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked successfully"})
+}
+
+// RevokeOtherSessions DELETE /api/v1/users/me/sessions
+func (h *UserHandler) RevokeOtherSessions(c *gin.Context) {
+	userID := c.GetUint("userID") // From auth middleware
+
+	err := h.userUsecase.RevokeOtherSessions(c.Request.Context(), userID, c.GetUint("sessionID"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Other sessions revoked successfully"})
+}
+
+// canManageUser reports whether the current user may modify the target user
+func canManageUser(c *gin.Context, targetUserID uint) bool {
+	if c.GetUint("userID") == targetUserID {
+		return true
+	}
+	value, exists := c.Get("user")
+	if !exists {
+		return false
+	}
+	user, ok := value.(*domain.User)
+	return ok && user.HasPermission(domain.PermissionUsersManage)
+}
+
+// identityToResponse converts a linked identity to response DTO
+func identityToResponse(identity *domain.UserIdentity) dto.IdentityResponse {
+	return dto.IdentityResponse{
+		ID:          identity.ID,
+		Provider:    identity.Provider,
+		Email:       identity.Email,
+		ConfirmedAt: identity.ConfirmedAt,
+		CreatedAt:   identity.CreatedAt,
+	}
+}
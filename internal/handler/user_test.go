@@ -13,6 +13,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/middleware"
+	"github.com/House-lovers7/speadwear-go/pkg/password"
 )
 
 func TestUserHandler_GetUser(t *testing.T) {
@@ -298,4 +300,473 @@ func TestUserHandler_UpdateProfile(t *testing.T) {
 			mockUsecase.AssertExpectations(t)
 		})
 	}
-}
\ No newline at end of file
+}
+func TestUserHandler_ResetPasswordRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	
+	tests := []struct {
+		name         string
+		requestBody  map[string]string
+		mockSetup    func(*mockUserUsecase)
+		expectedCode int
+		checkBody    func(*testing.T, map[string]interface{})
+	}{
+		{
+			name: "existing email",
+			requestBody: map[string]string{
+				"email": "test@example.com",
+			},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("ResetPasswordRequest", mock.Anything, "test@example.com").Return(nil)
+			},
+			expectedCode: http.StatusOK,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "If the email exists, a password reset link has been sent", body["message"])
+			},
+		},
+		{
+			name: "unknown email does not leak",
+			requestBody: map[string]string{
+				"email": "unknown@example.com",
+			},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("ResetPasswordRequest", mock.Anything, "unknown@example.com").Return(errors.New("user not found"))
+			},
+			expectedCode: http.StatusOK,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, "If the email exists, a password reset link has been sent", body["message"])
+			},
+		},
+		{
+			name: "rate limited",
+			requestBody: map[string]string{
+				"email": "test@example.com",
+			},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("ResetPasswordRequest", mock.Anything, "test@example.com").Return(errors.New("too many requests"))
+			},
+			expectedCode: http.StatusTooManyRequests,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.NotNil(t, body["error"])
+			},
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			mockUsecase := new(mockUserUsecase)
+			tt.mockSetup(mockUsecase)
+			
+			handler := NewUserHandler(mockUsecase)
+			
+			// Create request
+			jsonBody, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/users/password/reset", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			
+			// Create response recorder
+			w := httptest.NewRecorder()
+			
+			// Setup gin context
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			
+			// Execute
+			handler.ResetPasswordRequest(c)
+			
+			// Assert
+			assert.Equal(t, tt.expectedCode, w.Code)
+			
+			var responseBody map[string]interface{}
+			json.Unmarshal(w.Body.Bytes(), &responseBody)
+			tt.checkBody(t, responseBody)
+			
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_ResetPassword(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	
+	tests := []struct {
+		name          string
+		mockErr       error
+		expectedCode  int
+		expectedError string
+	}{
+		{"success", nil, http.StatusOK, ""},
+		{"invalid token", errors.New("invalid or expired token"), http.StatusBadRequest, "Invalid or expired token"},
+		{"password too short", password.ErrTooShort, http.StatusBadRequest, "password is too short"},
+		{"reused password", password.ErrReused, http.StatusBadRequest, "password was used recently"},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockUserUsecase)
+			mockUsecase.On("ResetPassword", mock.Anything, "reset-token", "newpassword1").Return(tt.mockErr)
+			
+			handler := NewUserHandler(mockUsecase)
+			
+			jsonBody, _ := json.Marshal(map[string]string{"token": "reset-token", "new_password": "newpassword1"})
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/users/password/reset", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			
+			handler.ResetPassword(c)
+			
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedError != "" {
+				var responseBody map[string]interface{}
+				json.Unmarshal(w.Body.Bytes(), &responseBody)
+				assert.Equal(t, tt.expectedError, responseBody["error"])
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_ListSessions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	
+	sessions := []*domain.Session{
+		{BaseModel: domain.BaseModel{ID: 1}, UserID: 1, DeviceName: "iPhone", LastUsedAt: time.Now()},
+		{BaseModel: domain.BaseModel{ID: 2}, UserID: 1, DeviceName: "iPad", LastUsedAt: time.Now()},
+	}
+	
+	mockUsecase := new(mockUserUsecase)
+	mockUsecase.On("ListSessions", mock.Anything, uint(1)).Return(sessions, nil)
+	
+	handler := NewUserHandler(mockUsecase)
+	
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/users/me/sessions", nil)
+	c.Set("userID", uint(1))
+	c.Set("sessionID", uint(2))
+	
+	handler.ListSessions(c)
+	
+	assert.Equal(t, http.StatusOK, w.Code)
+	
+	var responseBody map[string][]map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &responseBody)
+	assert.Len(t, responseBody["sessions"], 2)
+	assert.Equal(t, false, responseBody["sessions"][0]["current"])
+	assert.Equal(t, true, responseBody["sessions"][1]["current"])
+	
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestUserHandler_AccountEndpointsRequireSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	
+	tests := []struct {
+		name         string
+		setContext   func(*gin.Context)
+		expectedCode int
+	}{
+		{
+			name:         "login session",
+			setContext:   func(c *gin.Context) {},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "impersonation token",
+			setContext: func(c *gin.Context) {
+				c.Set("impersonatorID", uint(9))
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name: "personal access token",
+			setContext: func(c *gin.Context) {
+				c.Set("accessToken", &domain.PersonalAccessToken{UserID: 1})
+			},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockUserUsecase)
+			if tt.expectedCode == http.StatusOK {
+				mockUsecase.On("ListSessions", mock.Anything, uint(1)).Return([]*domain.Session{}, nil)
+			}
+			handler := NewUserHandler(mockUsecase)
+			
+			router := gin.New()
+			router.GET("/api/v1/users/me/sessions", func(c *gin.Context) {
+				c.Set("userID", uint(1))
+				c.Set("sessionID", uint(2))
+				tt.setContext(c)
+			}, middleware.SessionRequired(), handler.ListSessions)
+			
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/users/me/sessions", nil))
+			
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_RevokeSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	
+	tests := []struct {
+		name         string
+		sessionID    string
+		mockSetup    func(*mockUserUsecase)
+		expectedCode int
+	}{
+		{
+			name:      "own session",
+			sessionID: "5",
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("RevokeSession", mock.Anything, uint(1), uint(5)).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:      "session of another user",
+			sessionID: "6",
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("RevokeSession", mock.Anything, uint(1), uint(6)).Return(errors.New("session not found"))
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "invalid session ID",
+			sessionID:    "abc",
+			mockSetup:    func(m *mockUserUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockUserUsecase)
+			tt.mockSetup(mockUsecase)
+			
+			handler := NewUserHandler(mockUsecase)
+			
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodDelete, "/api/v1/users/me/sessions/"+tt.sessionID, nil)
+			c.Params = gin.Params{{Key: "id", Value: tt.sessionID}}
+			c.Set("userID", uint(1))
+			
+			handler.RevokeSession(c)
+			
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_ChangePassword(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	
+	tests := []struct {
+		name         string
+		requestBody  map[string]interface{}
+		mockSetup    func(*mockUserUsecase)
+		expectedCode int
+	}{
+		{
+			name: "revokes other sessions by default",
+			requestBody: map[string]interface{}{
+				"old_password": "oldpassword",
+				"new_password": "newpassword",
+			},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("ChangePassword", mock.Anything, uint(1), uint(3), "oldpassword", "newpassword", true).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "keeps other sessions when requested",
+			requestBody: map[string]interface{}{
+				"old_password":          "oldpassword",
+				"new_password":          "newpassword",
+				"revoke_other_sessions": false,
+			},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("ChangePassword", mock.Anything, uint(1), uint(3), "oldpassword", "newpassword", false).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "wrong old password",
+			requestBody: map[string]interface{}{
+				"old_password": "wrongpassword",
+				"new_password": "newpassword",
+			},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("ChangePassword", mock.Anything, uint(1), uint(3), "wrongpassword", "newpassword", true).Return(errors.New("invalid old password"))
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "reused password",
+			requestBody: map[string]interface{}{
+				"old_password": "oldpassword",
+				"new_password": "oldpassword",
+			},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("ChangePassword", mock.Anything, uint(1), uint(3), "oldpassword", "oldpassword", true).Return(password.ErrReused)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockUserUsecase)
+			tt.mockSetup(mockUsecase)
+			
+			handler := NewUserHandler(mockUsecase)
+			
+			jsonBody, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/users/password", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Set("userID", uint(1))
+			c.Set("sessionID", uint(3))
+			
+			handler.ChangePassword(c)
+			
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_DeleteUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	
+	tests := []struct {
+		name         string
+		currentUser  *domain.User
+		targetID     string
+		mockSetup    func(*mockUserUsecase)
+		expectedCode int
+	}{
+		{
+			name:        "delete own account",
+			currentUser: &domain.User{BaseModel: domain.BaseModel{ID: 1}, Role: domain.RoleUser},
+			targetID:    "1",
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("DeleteUser", mock.Anything, uint(1)).Return(time.Now().Add(720*time.Hour), nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "regular user cannot delete others",
+			currentUser:  &domain.User{BaseModel: domain.BaseModel{ID: 1}, Role: domain.RoleUser},
+			targetID:     "2",
+			mockSetup:    func(m *mockUserUsecase) {},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "moderator cannot delete others",
+			currentUser:  &domain.User{BaseModel: domain.BaseModel{ID: 1}, Role: domain.RoleModerator},
+			targetID:     "2",
+			mockSetup:    func(m *mockUserUsecase) {},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:        "admin can delete others",
+			currentUser: &domain.User{BaseModel: domain.BaseModel{ID: 1}, Admin: true},
+			targetID:    "2",
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("DeleteUser", mock.Anything, uint(2)).Return(time.Now().Add(720*time.Hour), nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockUserUsecase)
+			tt.mockSetup(mockUsecase)
+			
+			handler := NewUserHandler(mockUsecase)
+			
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodDelete, "/api/v1/users/"+tt.targetID, nil)
+			c.Params = gin.Params{{Key: "id", Value: tt.targetID}}
+			c.Set("userID", tt.currentUser.ID)
+			c.Set("user", tt.currentUser)
+			
+			handler.DeleteUser(c)
+			
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_UpdateUserEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	
+	currentUser := &domain.User{BaseModel: domain.BaseModel{ID: 1}, Role: domain.RoleUser}
+	
+	tests := []struct {
+		name         string
+		requestBody  map[string]interface{}
+		mockSetup    func(*mockUserUsecase)
+		expectedCode int
+	}{
+		{
+			name:        "email change pending confirmation",
+			requestBody: map[string]interface{}{"email": "new@example.com"},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("UpdateUser", mock.Anything, uint(1), map[string]interface{}{"email": "new@example.com"}).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:        "email taken",
+			requestBody: map[string]interface{}{"email": "taken@example.com"},
+			mockSetup: func(m *mockUserUsecase) {
+				m.On("UpdateUser", mock.Anything, uint(1), map[string]interface{}{"email": "taken@example.com"}).Return(errors.New("email already exists"))
+			},
+			expectedCode: http.StatusConflict,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockUserUsecase)
+			tt.mockSetup(mockUsecase)
+			
+			handler := NewUserHandler(mockUsecase)
+			
+			jsonBody, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/users/1", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Params = gin.Params{{Key: "id", Value: "1"}}
+			c.Set("userID", currentUser.ID)
+			c.Set("user", currentUser)
+			
+			handler.UpdateUser(c)
+			
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
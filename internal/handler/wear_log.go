@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/dto"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type WearLogHandler struct {
+	wearLogUsecase usecase.WearLogUsecase
+}
+
+// NewWearLogHandler creates a new wear log handler
+func NewWearLogHandler(wearLogUsecase usecase.WearLogUsecase) *WearLogHandler {
+	return &WearLogHandler{
+		wearLogUsecase: wearLogUsecase,
+	}
+}
+
+// CreateWearLog POST /api/v1/wear-logs
+func (h *WearLogHandler) CreateWearLog(c *gin.Context) {
+	userID := c.GetUint("userID") // From auth middleware
+
+	var req dto.CreateWearLogRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	wornOn, _ := time.ParseInLocation(domain.DateLayout, req.WornOn, time.Local) // validated by binding
+	wearLog := &domain.WearLog{
+		WornOn:       wornOn,
+		CoordinateID: req.CoordinateID,
+		Weather:      req.Weather,
+		Mood:         req.Mood,
+	}
+
+	if err := h.wearLogUsecase.LogWear(c.Request.Context(), userID, wearLog, req.ItemIDs); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Get the created wear log with its items
+	created, err := h.wearLogUsecase.GetWearLog(c.Request.Context(), userID, wearLog.ID)
+	if err == nil {
+		wearLog = created
+	}
+
+	c.JSON(http.StatusCreated, wearLogToResponse(wearLog))
+}
+
+// GetWearLog GET /api/v1/wear-logs/:id
+func (h *WearLogHandler) GetWearLog(c *gin.Context) {
+	wearLogID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wear log ID"})
+		return
+	}
+
+	wearLog, err := h.wearLogUsecase.GetWearLog(c.Request.Context(), c.GetUint("userID"), uint(wearLogID))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, wearLogToResponse(wearLog))
+}
+
+// ListWearLogs GET /api/v1/wear-logs?from=&to=
+func (h *WearLogHandler) ListWearLogs(c *gin.Context) {
+	var req dto.WearLogFilterRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	wearLogs, total, err := h.wearLogUsecase.ListWearLogs(c.Request.Context(), repository.WearLogFilter{
+		UserID: c.GetUint("userID"),
+		From:   req.From,
+		To:     req.To,
+		Limit:  req.PerPage,
+		Offset: (req.Page - 1) * req.PerPage,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	wearLogResponses := make([]dto.WearLogResponse, len(wearLogs))
+	for i, wearLog := range wearLogs {
+		wearLogResponses[i] = wearLogToResponse(wearLog)
+	}
+
+	c.JSON(http.StatusOK, dto.WearLogListResponse{
+		WearLogs:   wearLogResponses,
+		TotalCount: total,
+		Page:       req.Page,
+		PerPage:    req.PerPage,
+	})
+}
+
+// UpdateWearLog PUT /api/v1/wear-logs/:id
+func (h *WearLogHandler) UpdateWearLog(c *gin.Context) {
+	userID := c.GetUint("userID") // From auth middleware
+
+	wearLogID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wear log ID"})
+		return
+	}
+
+	var req dto.UpdateWearLogRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Convert request to map
+	updates := make(map[string]interface{})
+	if req.WornOn != nil {
+		updates["worn_on"], _ = time.ParseInLocation(domain.DateLayout, *req.WornOn, time.Local)
+	}
+	if req.CoordinateID != nil {
+		updates["coordinate_id"] = *req.CoordinateID
+	}
+	if req.Weather != nil {
+		updates["weather"] = *req.Weather
+	}
+	if req.Mood != nil {
+		updates["mood"] = *req.Mood
+	}
+
+	if err := h.wearLogUsecase.UpdateWearLog(c.Request.Context(), userID, uint(wearLogID), updates, req.ItemIDs); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	wearLog, err := h.wearLogUsecase.GetWearLog(c.Request.Context(), userID, uint(wearLogID))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, wearLogToResponse(wearLog))
+}
+
+// DeleteWearLog DELETE /api/v1/wear-logs/:id
+func (h *WearLogHandler) DeleteWearLog(c *gin.Context) {
+	wearLogID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid wear log ID"})
+		return
+	}
+
+	if err := h.wearLogUsecase.DeleteWearLog(c.Request.Context(), c.GetUint("userID"), uint(wearLogID)); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Wear log deleted successfully"})
+}
+
+// handleError maps wear log usecase errors to HTTP responses
+func (h *WearLogHandler) handleError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "wear log not found":
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case "coordinate or items required", "coordinate not found", "item not found", "invalid date range":
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case "unauthorized: coordinate does not belong to user", "unauthorized: item does not belong to user":
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// wearLogToResponse converts a wear log to response DTO
+func wearLogToResponse(wearLog *domain.WearLog) dto.WearLogResponse {
+	items := make([]dto.ItemResponse, len(wearLog.Items))
+	for i := range wearLog.Items {
+		items[i] = itemToResponse(&wearLog.Items[i].Item)
+	}
+	return dto.WearLogResponse{
+		ID:           wearLog.ID,
+		UserID:       wearLog.UserID,
+		WornOn:       wearLog.WornOn.Format(domain.DateLayout),
+		CoordinateID: wearLog.CoordinateID,
+		Items:        items,
+		Weather:      wearLog.Weather,
+		Mood:         wearLog.Mood,
+		CreatedAt:    wearLog.CreatedAt,
+		UpdatedAt:    wearLog.UpdatedAt,
+	}
+}
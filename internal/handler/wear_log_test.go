@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock usecase
+type mockWearLogUsecase struct {
+	mock.Mock
+}
+
+func (m *mockWearLogUsecase) LogWear(ctx context.Context, userID uint, wearLog *domain.WearLog, itemIDs []uint) error {
+	args := m.Called(ctx, userID, wearLog, itemIDs)
+	return args.Error(0)
+}
+
+func (m *mockWearLogUsecase) GetWearLog(ctx context.Context, userID uint, wearLogID uint) (*domain.WearLog, error) {
+	args := m.Called(ctx, userID, wearLogID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WearLog), args.Error(1)
+}
+
+func (m *mockWearLogUsecase) UpdateWearLog(ctx context.Context, userID uint, wearLogID uint, updates map[string]interface{}, itemIDs []uint) error {
+	args := m.Called(ctx, userID, wearLogID, updates, itemIDs)
+	return args.Error(0)
+}
+
+func (m *mockWearLogUsecase) DeleteWearLog(ctx context.Context, userID uint, wearLogID uint) error {
+	args := m.Called(ctx, userID, wearLogID)
+	return args.Error(0)
+}
+
+func (m *mockWearLogUsecase) ListWearLogs(ctx context.Context, filter repository.WearLogFilter) ([]*domain.WearLog, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*domain.WearLog), args.Get(1).(int64), args.Error(2)
+}
+
+func TestWearLogHandler_CreateWearLog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	wornOn := time.Date(2024, 10, 1, 0, 0, 0, 0, time.Local)
+	coordinateID := uint(5)
+	lastWorn := wornOn
+
+	tests := []struct {
+		name         string
+		body         string
+		mockSetup    func(*mockWearLogUsecase)
+		expectedCode int
+		checkBody    func(*testing.T, map[string]interface{})
+	}{
+		{
+			name: "log a coordinate",
+			body: `{"worn_on": "2024-10-01", "coordinate_id": 5, "weather": "晴れ", "mood": 4}`,
+			mockSetup: func(m *mockWearLogUsecase) {
+				m.On("LogWear", mock.Anything, uint(1), mock.MatchedBy(func(w *domain.WearLog) bool {
+					return w.WornOn.Equal(wornOn) && w.CoordinateID != nil && *w.CoordinateID == 5 && w.Weather == "晴れ" && w.Mood == 4
+				}), []uint(nil)).Run(func(args mock.Arguments) {
+					args.Get(2).(*domain.WearLog).ID = 9
+				}).Return(nil)
+				m.On("GetWearLog", mock.Anything, uint(1), uint(9)).Return(&domain.WearLog{
+					BaseModel:    domain.BaseModel{ID: 9},
+					UserID:       1,
+					WornOn:       wornOn,
+					CoordinateID: &coordinateID,
+					Weather:      "晴れ",
+					Mood:         4,
+					Items: []domain.WearLogItem{
+						{WearLogID: 9, ItemID: 2, Item: domain.Item{
+							BaseModel: domain.BaseModel{ID: 2},
+							WearStats: domain.WearStats{WearCount: 3, LastWornAt: &lastWorn},
+						}},
+					},
+				}, nil)
+			},
+			expectedCode: http.StatusCreated,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				assert.Equal(t, float64(9), body["id"])
+				assert.Equal(t, "2024-10-01", body["worn_on"])
+				assert.Equal(t, float64(5), body["coordinate_id"])
+				items := body["items"].([]interface{})
+				assert.Len(t, items, 1)
+				assert.Equal(t, float64(3), items[0].(map[string]interface{})["wear_count"])
+			},
+		},
+		{
+			name:         "neither coordinate nor items",
+			body:         `{"worn_on": "2024-10-01"}`,
+			mockSetup:    func(m *mockWearLogUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid date",
+			body:         `{"worn_on": "2024/10/01", "item_ids": [1]}`,
+			mockSetup:    func(m *mockWearLogUsecase) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "item of another user",
+			body: `{"worn_on": "2024-10-01", "item_ids": [7]}`,
+			mockSetup: func(m *mockWearLogUsecase) {
+				m.On("LogWear", mock.Anything, uint(1), mock.Anything, []uint{7}).
+					Return(errors.New("unauthorized: item does not belong to user"))
+			},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockWearLogUsecase)
+			tt.mockSetup(mockUsecase)
+
+			handler := NewWearLogHandler(mockUsecase)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/wear-logs", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("userID", uint(1))
+
+			handler.CreateWearLog(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.checkBody != nil {
+				var body map[string]interface{}
+				json.Unmarshal(w.Body.Bytes(), &body)
+				tt.checkBody(t, body)
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWearLogHandler_ListWearLogs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	from := time.Date(2024, 10, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2024, 10, 31, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name         string
+		query        string
+		mockSetup    func(*mockWearLogUsecase)
+		expectedCode int
+	}{
+		{
+			name:  "date range",
+			query: "?from=2024-10-01&to=2024-10-31&page=2&per_page=10",
+			mockSetup: func(m *mockWearLogUsecase) {
+				m.On("ListWearLogs", mock.Anything, mock.MatchedBy(func(f repository.WearLogFilter) bool {
+					return f.UserID == 1 && f.From.Equal(from) && f.To.Equal(to) && f.Limit == 10 && f.Offset == 10
+				})).Return([]*domain.WearLog{{BaseModel: domain.BaseModel{ID: 1}, WornOn: from}}, int64(11), nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:  "reversed range",
+			query: "?from=2024-10-31&to=2024-10-01",
+			mockSetup: func(m *mockWearLogUsecase) {
+				m.On("ListWearLogs", mock.Anything, mock.Anything).Return(nil, int64(0), errors.New("invalid date range"))
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockWearLogUsecase)
+			tt.mockSetup(mockUsecase)
+
+			handler := NewWearLogHandler(mockUsecase)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/wear-logs"+tt.query, nil)
+			c.Set("userID", uint(1))
+
+			handler.ListWearLogs(c)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWearLogHandler_DeleteWearLog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockUsecase := new(mockWearLogUsecase)
+	mockUsecase.On("DeleteWearLog", mock.Anything, uint(1), uint(4)).Return(errors.New("wear log not found"))
+
+	handler := NewWearLogHandler(mockUsecase)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodDelete, "/api/v1/wear-logs/4", nil)
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "4"}}
+	c.Set("userID", uint(1))
+
+	handler.DeleteWearLog(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
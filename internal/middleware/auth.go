@@ -1,15 +1,23 @@
 package middleware
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/House-lovers7/speadwear-go/pkg/config"
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
 	"github.com/House-lovers7/speadwear-go/pkg/utils"
 )
 
-func AuthRequired(cfg *config.Config) gin.HandlerFunc {
+// Personal access token usage is recorded at most this often to limit writes
+const accessTokenTouchInterval = time.Minute
+
+func AuthRequired(keys *utils.KeySet, repos *repository.Container) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -27,21 +35,32 @@ func AuthRequired(cfg *config.Config) gin.HandlerFunc {
 		}
 
 		tokenString := parts[1]
-		claims, err := utils.ValidateToken(tokenString, cfg.JWT.Secret)
-		if err != nil {
+		if err := signIn(c, keys, repos, tokenString); err != nil {
+			if err.Error() == "account suspended" {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Account suspended"})
+				c.Abort()
+				return
+			}
+			if err.Error() == "account scheduled for deletion" {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Account scheduled for deletion"})
+				c.Abort()
+				return
+			}
+			if err.Error() == "impersonation is read-only" {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Impersonation tokens are read-only"})
+				c.Abort()
+				return
+			}
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
 			c.Abort()
 			return
 		}
 
-		// ユーザー情報をコンテキストに保存
-		c.Set("userID", claims.UserID)
-		c.Set("email", claims.Email)
 		c.Next()
 	}
 }
 
-func OptionalAuth(cfg *config.Config) gin.HandlerFunc {
+func OptionalAuth(keys *utils.KeySet, repos *repository.Container) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -56,11 +75,7 @@ func OptionalAuth(cfg *config.Config) gin.HandlerFunc {
 		}
 
 		tokenString := parts[1]
-		claims, err := utils.ValidateToken(tokenString, cfg.JWT.Secret)
-		if err == nil {
-			c.Set("userID", claims.UserID)
-			c.Set("email", claims.Email)
-		}
+		signIn(c, keys, repos, tokenString)
 		c.Next()
 	}
 }
@@ -68,17 +83,276 @@ func OptionalAuth(cfg *config.Config) gin.HandlerFunc {
 func AdminRequired() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// AuthRequiredの後に実行されることを前提
-		userID, exists := c.Get("userID")
-		if !exists {
+		user, ok := CurrentUser(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			c.Abort()
+			return
+		}
+
+		if !user.IsAdmin() {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Admin privileges required"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequirePermission requires the authenticated user to hold all given permissions
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// AuthRequiredの後に実行されることを前提
+		user, ok := CurrentUser(c)
+		if !ok {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
 			c.Abort()
 			return
 		}
 
-		// TODO: データベースからユーザー情報を取得してAdmin権限をチェック
-		// 現時点では仮実装
-		_ = userID
-		
+		for _, permission := range permissions {
+			if !user.HasPermission(permission) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// RequireScope requires personal access tokens to carry all given scopes.
+// Session tokens act with the user's full rights and always pass.
+func RequireScope(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// AuthRequiredの後に実行されることを前提
+		token, ok := CurrentAccessToken(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		for _, scope := range scopes {
+			if !token.HasScope(scope) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient token scope", "required_scope": scope})
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// SessionRequired rejects personal access tokens and impersonation tokens for
+// account-level endpoints, so staff can't read a user's sessions, credentials
+// or data export while impersonating them
+func SessionRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := CurrentAccessToken(c); ok {
+			c.JSON(http.StatusForbidden, gin.H{"error": "This endpoint cannot be used with a personal access token"})
+			c.Abort()
+			return
+		}
+		if c.GetUint("impersonatorID") != 0 {
+			c.JSON(http.StatusForbidden, gin.H{"error": "This endpoint cannot be used while impersonating"})
+			c.Abort()
+			return
+		}
+
 		c.Next()
 	}
-}
\ No newline at end of file
+}
+
+// CurrentAccessToken returns the personal access token used to authenticate, if any
+func CurrentAccessToken(c *gin.Context) (*domain.PersonalAccessToken, bool) {
+	value, exists := c.Get("accessToken")
+	if !exists {
+		return nil, false
+	}
+	token, ok := value.(*domain.PersonalAccessToken)
+	return token, ok && token != nil
+}
+
+// CurrentUser returns the authenticated user stored by AuthRequired or OptionalAuth
+func CurrentUser(c *gin.Context) (*domain.User, bool) {
+	value, exists := c.Get("user")
+	if !exists {
+		return nil, false
+	}
+	user, ok := value.(*domain.User)
+	return user, ok && user != nil
+}
+
+// signIn authenticates a bearer token and stores the principal in the request context
+func signIn(c *gin.Context, keys *utils.KeySet, repos *repository.Container, tokenString string) error {
+	if strings.HasPrefix(tokenString, domain.AccessTokenPrefix) {
+		token, user, err := authenticateAccessToken(c.Request.Context(), repos, tokenString, c.ClientIP())
+		if err != nil {
+			return err
+		}
+		setAccessTokenContext(c, token, user)
+		return nil
+	}
+
+	claims, user, err := authenticate(c.Request.Context(), keys, repos, tokenString)
+	if err != nil {
+		return err
+	}
+	if claims.ImpersonatorID != 0 {
+		if err := auditImpersonatedRequest(c, repos, claims); err != nil {
+			return err
+		}
+		if !readOnlyMethod(c.Request.Method) {
+			return errors.New("impersonation is read-only")
+		}
+	}
+	// ユーザー情報をコンテキストに保存
+	setAuthContext(c, claims, user)
+	return nil
+}
+
+// setAuthContext stores the authenticated principal in the request context
+func setAuthContext(c *gin.Context, claims *utils.Claims, user *domain.User) {
+	c.Set("userID", claims.UserID)
+	c.Set("email", claims.Email)
+	c.Set("sessionID", claims.SessionID)
+	c.Set("impersonatorID", claims.ImpersonatorID)
+	c.Set("user", user)
+	c.Set("role", user.Role)
+	c.Set("admin", user.IsAdmin())
+}
+
+// readOnlyMethod reports whether an HTTP method can't change state
+func readOnlyMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// auditImpersonatedRequest records every request made with an impersonation
+// token, including the writes that are then refused
+func auditImpersonatedRequest(c *gin.Context, repos *repository.Container, claims *utils.Claims) error {
+	metadata, err := json.Marshal(map[string]interface{}{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"session_id": claims.SessionID,
+		"read_only":  readOnlyMethod(c.Request.Method),
+	})
+	if err != nil {
+		return err
+	}
+
+	return repos.AuditEvent.Create(c.Request.Context(), &domain.AuditEvent{
+		ActorID:    claims.ImpersonatorID,
+		Action:     domain.AuditActionImpersonatedRequest,
+		TargetType: domain.AuditTargetUser,
+		TargetID:   claims.UserID,
+		Metadata:   string(metadata),
+		IPAddress:  c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+	})
+}
+
+// setAccessTokenContext stores a principal authenticated by personal access token.
+// There is no session, so sessionID is 0.
+func setAccessTokenContext(c *gin.Context, token *domain.PersonalAccessToken, user *domain.User) {
+	c.Set("userID", user.ID)
+	c.Set("email", user.Email)
+	c.Set("sessionID", uint(0))
+	c.Set("accessToken", token)
+	c.Set("user", user)
+	c.Set("role", user.Role)
+	c.Set("admin", user.IsAdmin())
+}
+
+// authenticateAccessToken looks up a personal access token and its owner
+func authenticateAccessToken(ctx context.Context, repos *repository.Container, tokenString, ip string) (*domain.PersonalAccessToken, *domain.User, error) {
+	token, err := repos.AccessToken.FindByDigest(ctx, utils.HashToken(tokenString))
+	if err != nil {
+		return nil, nil, err
+	}
+	if token == nil || !utils.CompareTokenHash(tokenString, token.TokenDigest) || !token.IsActive(time.Now()) {
+		return nil, nil, errors.New("invalid token")
+	}
+
+	user, err := repos.User.FindByID(ctx, token.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil {
+		return nil, nil, errors.New("user not found")
+	}
+	if user.IsSuspended(time.Now()) {
+		return nil, nil, errors.New("account suspended")
+	}
+	// Tokens stop working once deletion is requested; only a login cancels it
+	if user.DeletionPending() {
+		return nil, nil, errors.New("account scheduled for deletion")
+	}
+
+	if err := repos.AccessToken.TouchLastUsed(ctx, token.ID, ip, accessTokenTouchInterval); err != nil {
+		return nil, nil, err
+	}
+
+	return token, user, nil
+}
+
+// authenticate validates a token and checks it against the current user state
+func authenticate(ctx context.Context, keys *utils.KeySet, repos *repository.Container, tokenString string) (*utils.Claims, *domain.User, error) {
+	claims, err := keys.Validate(tokenString)
+	if err != nil {
+		return nil, nil, err
+	}
+	if claims.Purpose != "" {
+		return nil, nil, errors.New("invalid token")
+	}
+
+	user, err := repos.User.FindByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil {
+		return nil, nil, errors.New("user not found")
+	}
+
+	// Tokens issued before the last password change or reset are no longer valid
+	if user.PasswordChangedAt != nil && claims.IssuedAt != nil &&
+		claims.IssuedAt.Time.Before(user.PasswordChangedAt.Truncate(time.Second)) {
+		return nil, nil, errors.New("token revoked")
+	}
+
+	// Suspended accounts are locked out even with a valid token
+	if user.IsSuspended(time.Now()) {
+		return nil, nil, errors.New("account suspended")
+	}
+
+	// Impersonation tokens ride on the staff member's session and stop
+	// working as soon as they lose the permission
+	sessionOwnerID := user.ID
+	if claims.ImpersonatorID != 0 {
+		impersonator, err := repos.User.FindByID(ctx, claims.ImpersonatorID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if impersonator == nil || impersonator.IsSuspended(time.Now()) ||
+			!impersonator.HasPermission(domain.PermissionUsersImpersonate) {
+			return nil, nil, errors.New("impersonation revoked")
+		}
+		sessionOwnerID = impersonator.ID
+	}
+
+	// Access tokens are bound to a session that can be revoked server-side
+	if claims.SessionID == 0 {
+		return nil, nil, errors.New("session required")
+	}
+	session, err := repos.Session.FindByID(ctx, claims.SessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if session == nil || session.UserID != sessionOwnerID || !session.IsActive(time.Now()) {
+		return nil, nil, errors.New("session revoked")
+	}
+
+	return claims, user, nil
+}
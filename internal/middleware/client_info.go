@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/House-lovers7/speadwear-go/internal/dto"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+)
+
+// ClientInfo stores the caller's IP address and user agent in the request context
+// so usecases can record them (e.g. in the audit trail)
+func ClientInfo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := usecase.WithClientInfo(c.Request.Context(), dto.ClientInfo{
+			IPAddress: c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"gorm.io/gorm"
+)
+
+type auditEventRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditEventRepository creates a new audit event repository
+func NewAuditEventRepository(db *gorm.DB) AuditEventRepository {
+	return &auditEventRepository{db: db}
+}
+
+// Create appends an audit event
+func (r *auditEventRepository) Create(ctx context.Context, event *domain.AuditEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+// Search finds audit events matching the filter, newest first, with the total count
+func (r *auditEventRepository) Search(ctx context.Context, filter AuditEventFilter) ([]*domain.AuditEvent, int64, error) {
+	var events []*domain.AuditEvent
+	var total int64
+
+	db := r.db.WithContext(ctx).Model(&domain.AuditEvent{})
+	if filter.ActorID != nil {
+		db = db.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Action != "" {
+		db = db.Where("action = ?", filter.Action)
+	}
+	if filter.TargetType != "" {
+		db = db.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != nil {
+		db = db.Where("target_id = ?", *filter.TargetID)
+	}
+	if filter.IPAddress != "" {
+		db = db.Where("ip_address = ?", filter.IPAddress)
+	}
+	if filter.From != nil {
+		db = db.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		db = db.Where("created_at < ?", *filter.To)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filter.Limit > 0 {
+		db = db.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		db = db.Offset(filter.Offset)
+	}
+
+	if err := db.Order("id DESC").Find(&events).Error; err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
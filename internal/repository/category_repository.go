@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type categoryRepository struct {
+	db *gorm.DB
+}
+
+// NewCategoryRepository creates a new category repository
+func NewCategoryRepository(db *gorm.DB) CategoryRepository {
+	return &categoryRepository{db: db}
+}
+
+// Create creates a category
+func (r *categoryRepository) Create(ctx context.Context, category *domain.Category) error {
+	return r.db.WithContext(ctx).Create(category).Error
+}
+
+// FindByID finds a category by ID
+func (r *categoryRepository) FindByID(ctx context.Context, id uint) (*domain.Category, error) {
+	var category domain.Category
+	err := r.db.WithContext(ctx).First(&category, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+// Update updates a category
+func (r *categoryRepository) Update(ctx context.Context, category *domain.Category) error {
+	return r.db.WithContext(ctx).Save(category).Error
+}
+
+// Delete deletes a category
+func (r *categoryRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&domain.Category{}, id).Error
+}
+
+// FindByUserID finds all of a user's categories, ordered by position within their parent
+func (r *categoryRepository) FindByUserID(ctx context.Context, userID uint) ([]*domain.Category, error) {
+	var categories []*domain.Category
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("position, id").
+		Find(&categories).Error
+	return categories, err
+}
+
+// CreateDefaults creates a user's first top-level categories. A category is
+// added after them for every other super item the user's items use, and each
+// uncategorized item is filed under the category named after its super item.
+// If another request created the user's categories first, those are returned.
+func (r *categoryRepository) CreateDefaults(ctx context.Context, userID uint, names []string) ([]*domain.Category, error) {
+	var categories []*domain.Category
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The user row lock makes concurrent first requests wait for each other
+		var user domain.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&user, userID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Order("position, id").Find(&categories).Error; err != nil {
+			return err
+		}
+		if len(categories) > 0 {
+			return nil
+		}
+
+		var used []string
+		err := tx.Model(&domain.Item{}).
+			Where("user_id = ? AND category_id IS NULL AND super_item <> ''", userID).
+			Distinct().
+			Order("super_item").
+			Pluck("super_item", &used).Error
+		if err != nil {
+			return err
+		}
+
+		seen := make(map[string]bool, len(names)+len(used))
+		for _, name := range append(append([]string{}, names...), used...) {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			categories = append(categories, &domain.Category{
+				UserID:   userID,
+				Name:     name,
+				Position: len(categories),
+			})
+		}
+		if len(categories) == 0 {
+			return nil
+		}
+		if err := tx.Create(&categories).Error; err != nil {
+			return err
+		}
+
+		for _, category := range categories {
+			err := tx.Model(&domain.Item{}).
+				Where("user_id = ? AND category_id IS NULL AND super_item = ?", userID, category.Name).
+				Update("category_id", category.ID).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// Rename saves a category's name and sets the super item of the items filed
+// under categoryIDs
+func (r *categoryRepository) Rename(ctx context.Context, category *domain.Category, categoryIDs []uint, superItem string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(category).Update("name", category.Name).Error; err != nil {
+			return err
+		}
+		return setItemsSuperItem(tx, categoryIDs, superItem)
+	})
+}
+
+// UpdatePositions saves the position of each category
+func (r *categoryRepository) UpdatePositions(ctx context.Context, categories []*domain.Category) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, category := range categories {
+			if err := tx.Model(category).Update("position", category.Position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Merge moves source's items and sub-categories into target, after target's
+// own sub-categories, sets the super item of the items filed under
+// categoryIDs and deletes source
+func (r *categoryRepository) Merge(ctx context.Context, source, target *domain.Category, categoryIDs []uint, superItem string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := setItemsSuperItem(tx, categoryIDs, superItem); err != nil {
+			return err
+		}
+		err := tx.Unscoped().Model(&domain.Item{}).
+			Where("category_id = ?", source.ID).
+			Update("category_id", target.ID).Error
+		if err != nil {
+			return err
+		}
+
+		var position int64
+		if err := tx.Model(&domain.Category{}).Where("parent_id = ?", target.ID).Count(&position).Error; err != nil {
+			return err
+		}
+		var children []*domain.Category
+		if err := tx.Where("parent_id = ?", source.ID).Order("position, id").Find(&children).Error; err != nil {
+			return err
+		}
+		for _, child := range children {
+			err := tx.Model(child).Updates(map[string]interface{}{
+				"parent_id": target.ID,
+				"position":  position,
+			}).Error
+			if err != nil {
+				return err
+			}
+			position++
+		}
+		return tx.Delete(source).Error
+	})
+}
+
+// setItemsSuperItem sets the super item of the items filed under categoryIDs,
+// deleted items included
+func setItemsSuperItem(tx *gorm.DB, categoryIDs []uint, superItem string) error {
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+	return tx.Unscoped().Model(&domain.Item{}).
+		Where("category_id IN ?", categoryIDs).
+		Update("super_item", superItem).Error
+}
@@ -64,6 +64,16 @@ func (r *commentRepository) FindByCoordinateID(ctx context.Context, coordinateID
 	return comments, nil
 }
 
+// FindByUserID finds every comment written by a user, newest first
+func (r *commentRepository) FindByUserID(ctx context.Context, userID uint) ([]*domain.Comment, error) {
+	var comments []*domain.Comment
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
 // CountByCoordinateID counts comments by coordinate ID
 func (r *commentRepository) CountByCoordinateID(ctx context.Context, coordinateID uint) (int64, error) {
 	var count int64
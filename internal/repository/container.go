@@ -4,26 +4,50 @@ import "gorm.io/gorm"
 
 // Container holds all repositories
 type Container struct {
-	User             UserRepository
-	Item             ItemRepository
-	Coordinate       CoordinateRepository
-	Comment          CommentRepository
-	LikeCoordinate   LikeCoordinateRepository
-	Relationship     RelationshipRepository
-	Block            BlockRepository
-	Notification     NotificationRepository
+	User            UserRepository
+	Item            ItemRepository
+	Category        CategoryRepository
+	Coordinate      CoordinateRepository
+	WearLog         WearLogRepository
+	Comment         CommentRepository
+	LikeCoordinate  LikeCoordinateRepository
+	Relationship    RelationshipRepository
+	Block           BlockRepository
+	Notification    NotificationRepository
+	Session         SessionRepository
+	RefreshToken    RefreshTokenRepository
+	AuditEvent      AuditEventRepository
+	LoginAttempt    LoginAttemptStore
+	RecoveryCode    RecoveryCodeRepository
+	PasswordHistory PasswordHistoryRepository
+	UserIdentity    UserIdentityRepository
+	OAuthState      OAuthStateRepository
+	AccessToken     PersonalAccessTokenRepository
+	DataExport      DataExportRepository
 }
 
 // NewContainer creates a new repository container
 func NewContainer(db *gorm.DB) *Container {
 	return &Container{
-		User:           NewUserRepository(db),
-		Item:           NewItemRepository(db),
-		Coordinate:     NewCoordinateRepository(db),
-		Comment:        NewCommentRepository(db),
-		LikeCoordinate: NewLikeCoordinateRepository(db),
-		Relationship:   NewRelationshipRepository(db),
-		Block:          NewBlockRepository(db),
-		Notification:   NewNotificationRepository(db),
+		User:            NewUserRepository(db),
+		Item:            NewItemRepository(db),
+		Category:        NewCategoryRepository(db),
+		Coordinate:      NewCoordinateRepository(db),
+		WearLog:         NewWearLogRepository(db),
+		Comment:         NewCommentRepository(db),
+		LikeCoordinate:  NewLikeCoordinateRepository(db),
+		Relationship:    NewRelationshipRepository(db),
+		Block:           NewBlockRepository(db),
+		Notification:    NewNotificationRepository(db),
+		Session:         NewSessionRepository(db),
+		RefreshToken:    NewRefreshTokenRepository(db),
+		AuditEvent:      NewAuditEventRepository(db),
+		LoginAttempt:    NewLoginAttemptRepository(db),
+		RecoveryCode:    NewRecoveryCodeRepository(db),
+		PasswordHistory: NewPasswordHistoryRepository(db),
+		UserIdentity:    NewUserIdentityRepository(db),
+		OAuthState:      NewOAuthStateRepository(db),
+		AccessToken:     NewPersonalAccessTokenRepository(db),
+		DataExport:      NewDataExportRepository(db),
 	}
 }
\ No newline at end of file
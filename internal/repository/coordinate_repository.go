@@ -35,20 +35,28 @@ func (r *coordinateRepository) FindByID(ctx context.Context, id uint) (*domain.C
 	return &coordinate, nil
 }
 
-// Update updates a coordinate
+// Update updates a coordinate; its item links are replaced separately
 func (r *coordinateRepository) Update(ctx context.Context, coordinate *domain.Coordinate) error {
-	return r.db.WithContext(ctx).Save(coordinate).Error
+	return r.db.WithContext(ctx).Omit("Items").Save(coordinate).Error
 }
 
-// Delete deletes a coordinate
+// Delete deletes a coordinate along with its item links; wear logs keep the items worn
 func (r *coordinateRepository) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&domain.Coordinate{}, id).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("coordinate_id = ?", id).Delete(&domain.CoordinateItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.WearLog{}).Where("coordinate_id = ?", id).Update("coordinate_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Coordinate{}, id).Error
+	})
 }
 
 // FindByUserID finds coordinates by user ID with pagination
 func (r *coordinateRepository) FindByUserID(ctx context.Context, userID uint, limit, offset int) ([]*domain.Coordinate, error) {
 	var coordinates []*domain.Coordinate
-	query := r.db.WithContext(ctx).Where("user_id = ?", userID).Preload("User").Preload("Items")
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID).Preload("User").Scopes(withCoordinateItems)
 	
 	if limit > 0 {
 		query = query.Limit(limit)
@@ -67,7 +75,7 @@ func (r *coordinateRepository) FindByUserID(ctx context.Context, userID uint, li
 // FindByFilters finds coordinates by filters
 func (r *coordinateRepository) FindByFilters(ctx context.Context, filters CoordinateFilter) ([]*domain.Coordinate, error) {
 	var coordinates []*domain.Coordinate
-	query := r.db.WithContext(ctx).Preload("User").Preload("Items")
+	query := r.db.WithContext(ctx).Preload("User").Scopes(withCoordinateItems, ownerNotHidden)
 	
 	// Apply filters
 	if filters.UserID != nil {
@@ -102,13 +110,13 @@ func (r *coordinateRepository) FindByFilters(ctx context.Context, filters Coordi
 	return coordinates, nil
 }
 
-// FindWithItems finds a coordinate with its items
+// FindWithItems finds a coordinate with its items in outfit order
 func (r *coordinateRepository) FindWithItems(ctx context.Context, id uint) (*domain.Coordinate, error) {
 	var coordinate domain.Coordinate
 	err := r.db.WithContext(ctx).
 		Preload("User").
-		Preload("Items").
-		Preload("Items.User").
+		Scopes(withCoordinateItems).
+		Preload("Items.Item.User").
 		First(&coordinate, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -277,11 +277,17 @@ func TestCoordinateRepository_FindWithItems(t *testing.T) {
 		t.Error("FindWithItems() did not load items")
 	}
 
-	// Verify items belong to the coordinate
-	for _, item := range found.Items {
-		if item.CoordinateID == nil || *item.CoordinateID != coord.ID {
+	// Verify items belong to the coordinate and come in outfit order
+	for i, link := range found.Items {
+		if link.CoordinateID != coord.ID {
 			t.Errorf("FindWithItems() loaded item with wrong CoordinateID")
 		}
+		if link.ItemID != coord.Items[i].ItemID || link.Item.ID != link.ItemID {
+			t.Errorf("FindWithItems() item %d = %d, want %d", i, link.Item.ID, coord.Items[i].ItemID)
+		}
+		if link.Role != coord.Items[i].Role {
+			t.Errorf("FindWithItems() item %d role = %q, want %q", i, link.Role, coord.Items[i].Role)
+		}
 	}
 
 	// Test non-existent coordinate
@@ -294,6 +300,50 @@ func TestCoordinateRepository_FindWithItems(t *testing.T) {
 	}
 }
 
+func TestCoordinateRepository_SharedItem(t *testing.T) {
+	db := testutil.TestDB(t)
+	repo := NewCoordinateRepository(db)
+	ctx := context.Background()
+	fixtures := testutil.NewFixtures(t, db)
+
+	// The same jacket is worn in two outfits
+	user := fixtures.CreateUser()
+	jacket := fixtures.CreateItem(user.ID)
+	first := fixtures.CreateCoordinate(user.ID)
+	second := fixtures.CreateCoordinate(user.ID)
+	fixtures.LinkItems(first, []*domain.Item{jacket}, domain.CoordinateItemRoleOuter)
+	fixtures.LinkItems(second, []*domain.Item{jacket}, domain.CoordinateItemRoleOuter)
+
+	hasJacket := func(coordinateID uint) bool {
+		found, err := repo.FindWithItems(ctx, coordinateID)
+		if err != nil {
+			t.Fatalf("FindWithItems() error = %v", err)
+		}
+		for _, link := range found.Items {
+			if link.ItemID == jacket.ID {
+				return true
+			}
+		}
+		return false
+	}
+	if !hasJacket(first.ID) || !hasJacket(second.ID) {
+		t.Fatal("FindWithItems() should list the shared item in both coordinates")
+	}
+
+	// Deleting one outfit leaves the other intact
+	if err := repo.Delete(ctx, first.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if !hasJacket(second.ID) {
+		t.Error("Delete() removed the shared item from the other coordinate")
+	}
+	var links int64
+	db.Model(&domain.CoordinateItem{}).Where("coordinate_id = ?", first.ID).Count(&links)
+	if links != 0 {
+		t.Errorf("Delete() left %d item links behind", links)
+	}
+}
+
 func TestCoordinateRepository_Update(t *testing.T) {
 	db := testutil.TestDB(t)
 	repo := NewCoordinateRepository(db)
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type dataExportRepository struct {
+	db *gorm.DB
+}
+
+// NewDataExportRepository creates a new data export repository
+func NewDataExportRepository(db *gorm.DB) DataExportRepository {
+	return &dataExportRepository{db: db}
+}
+
+// Create creates a new data export
+func (r *dataExportRepository) Create(ctx context.Context, export *domain.DataExport) error {
+	return r.db.WithContext(ctx).Create(export).Error
+}
+
+// FindByID finds a data export by ID
+func (r *dataExportRepository) FindByID(ctx context.Context, id uint) (*domain.DataExport, error) {
+	var export domain.DataExport
+	err := r.db.WithContext(ctx).First(&export, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+// Update updates a data export
+func (r *dataExportRepository) Update(ctx context.Context, export *domain.DataExport) error {
+	return r.db.WithContext(ctx).Save(export).Error
+}
+
+// Delete deletes a data export
+func (r *dataExportRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&domain.DataExport{}, id).Error
+}
+
+// FindByUserID finds every data export of a user, newest first
+func (r *dataExportRepository) FindByUserID(ctx context.Context, userID uint) ([]*domain.DataExport, error) {
+	var exports []*domain.DataExport
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id DESC").Find(&exports).Error
+	if err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
+
+// FindLatestByUserID finds the most recently requested data export of a user
+func (r *dataExportRepository) FindLatestByUserID(ctx context.Context, userID uint) (*domain.DataExport, error) {
+	var export domain.DataExport
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id DESC").First(&export).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+// FindByDownloadDigest finds a data export by the digest of its download token
+func (r *dataExportRepository) FindByDownloadDigest(ctx context.Context, digest string) (*domain.DataExport, error) {
+	var export domain.DataExport
+	err := r.db.WithContext(ctx).Where("download_digest = ?", digest).First(&export).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &export, nil
+}
+
+// CreateIfIdle creates an export unless the user has one in progress that was
+// updated since staleBefore. The user row is locked so concurrent requests
+// can't both queue one.
+func (r *dataExportRepository) CreateIfIdle(ctx context.Context, export *domain.DataExport, staleBefore time.Time) (bool, error) {
+	created := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user domain.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&user, export.UserID).Error; err != nil {
+			return err
+		}
+
+		var running int64
+		if err := tx.Model(&domain.DataExport{}).
+			Where("user_id = ? AND status IN ? AND updated_at >= ?", export.UserID,
+				[]string{domain.ExportStatusPending, domain.ExportStatusProcessing}, staleBefore).
+			Count(&running).Error; err != nil {
+			return err
+		}
+		if running > 0 {
+			return nil
+		}
+
+		if err := tx.Create(export).Error; err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return created, nil
+}
+
+// MarkFailed records that an export job failed
+func (r *dataExportRepository) MarkFailed(ctx context.Context, id uint, reason string) error {
+	return r.db.WithContext(ctx).Model(&domain.DataExport{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status": domain.ExportStatusFailed,
+			"error":  reason,
+		}).Error
+}
@@ -25,7 +25,13 @@ func (r *itemRepository) Create(ctx context.Context, item *domain.Item) error {
 // FindByID finds an item by ID
 func (r *itemRepository) FindByID(ctx context.Context, id uint) (*domain.Item, error) {
 	var item domain.Item
-	err := r.db.WithContext(ctx).Preload("User").First(&item, id).Error
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Preload("CoordinateItems").
+		Preload("StatusChanges", func(db *gorm.DB) *gorm.DB {
+			return db.Order("changed_at, id")
+		}).
+		First(&item, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -40,15 +46,37 @@ func (r *itemRepository) Update(ctx context.Context, item *domain.Item) error {
 	return r.db.WithContext(ctx).Save(item).Error
 }
 
-// Delete deletes an item
+// Delete deletes an item with its status history and takes it out of any
+// coordinate and wear log
 func (r *itemRepository) Delete(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Delete(&domain.Item{}, id).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("item_id = ?", id).Delete(&domain.CoordinateItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("item_id = ?", id).Delete(&domain.WearLogItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("item_id = ?", id).Delete(&domain.ItemStatusChange{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Item{}, id).Error
+	})
 }
 
 // FindByUserID finds items by user ID with pagination
 func (r *itemRepository) FindByUserID(ctx context.Context, userID uint, limit, offset int) ([]*domain.Item, error) {
+	return r.findByUserID(r.db.WithContext(ctx), userID, limit, offset)
+}
+
+// FindInWardrobeByUserID finds the items a user still has, with pagination
+func (r *itemRepository) FindInWardrobeByUserID(ctx context.Context, userID uint, limit, offset int) ([]*domain.Item, error) {
+	return r.findByUserID(r.db.WithContext(ctx).Scopes(inWardrobe), userID, limit, offset)
+}
+
+// findByUserID finds items by user ID within query, newest first
+func (r *itemRepository) findByUserID(query *gorm.DB, userID uint, limit, offset int) ([]*domain.Item, error) {
 	var items []*domain.Item
-	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	query = query.Where("user_id = ?", userID).Preload("CoordinateItems")
 	
 	if limit > 0 {
 		query = query.Limit(limit)
@@ -67,7 +95,7 @@ func (r *itemRepository) FindByUserID(ctx context.Context, userID uint, limit, o
 // FindByFilters finds items by filters
 func (r *itemRepository) FindByFilters(ctx context.Context, filters ItemFilter) ([]*domain.Item, error) {
 	var items []*domain.Item
-	query := r.db.WithContext(ctx).Preload("User")
+	query := r.db.WithContext(ctx).Preload("User").Preload("CoordinateItems").Scopes(ownerNotHidden)
 	
 	// Apply filters
 	if filters.UserID != nil {
@@ -91,6 +119,12 @@ func (r *itemRepository) FindByFilters(ctx context.Context, filters ItemFilter)
 	if filters.MaxRating != nil {
 		query = query.Where("rating <= ?", *filters.MaxRating)
 	}
+	if len(filters.Statuses) > 0 {
+		query = query.Where("status IN ?", filters.Statuses)
+	}
+	if len(filters.CategoryIDs) > 0 {
+		query = query.Where("category_id IN ?", filters.CategoryIDs)
+	}
 	
 	// Apply pagination
 	if filters.Limit > 0 {
@@ -113,4 +147,25 @@ func (r *itemRepository) CountByUserID(ctx context.Context, userID uint) (int64,
 	var count int64
 	err := r.db.WithContext(ctx).Model(&domain.Item{}).Where("user_id = ?", userID).Count(&count).Error
 	return count, err
-}
\ No newline at end of file
+}
+
+// CountInWardrobeByUserID counts the items a user still has
+func (r *itemRepository) CountInWardrobeByUserID(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.Item{}).Scopes(inWardrobe).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// ChangeStatus updates the item's status and records the transition
+func (r *itemRepository) ChangeStatus(ctx context.Context, item *domain.Item, change *domain.ItemStatusChange) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(item).Updates(map[string]interface{}{
+			"status":            item.Status,
+			"status_changed_at": item.StatusChangedAt,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(change).Error
+	})
+}
@@ -55,6 +55,16 @@ func (r *likeCoordinateRepository) FindByCoordinateID(ctx context.Context, coord
 	return likes, nil
 }
 
+// FindByUserID finds every like given by a user, newest first
+func (r *likeCoordinateRepository) FindByUserID(ctx context.Context, userID uint) ([]*domain.LikeCoordinate, error) {
+	var likes []*domain.LikeCoordinate
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&likes).Error
+	if err != nil {
+		return nil, err
+	}
+	return likes, nil
+}
+
 // FindByUserAndCoordinate finds a like by user ID and coordinate ID
 func (r *likeCoordinateRepository) FindByUserAndCoordinate(ctx context.Context, userID, coordinateID uint) (*domain.LikeCoordinate, error) {
 	var like domain.LikeCoordinate
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+)
+
+type memoryLoginAttemptStore struct {
+	mu       sync.Mutex
+	attempts map[string]domain.LoginAttempt
+
+	// lastSweep is when expired records were last dropped
+	lastSweep time.Time
+}
+
+// NewMemoryLoginAttemptStore creates an in-process login attempt store.
+// Counts are not shared between server instances.
+func NewMemoryLoginAttemptStore() LoginAttemptStore {
+	return &memoryLoginAttemptStore{attempts: make(map[string]domain.LoginAttempt)}
+}
+
+// Get returns a copy of the attempt record of an identifier
+func (s *memoryLoginAttemptStore) Get(ctx context.Context, identifier string) (*domain.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt, ok := s.attempts[identifier]
+	if !ok {
+		return nil, nil
+	}
+	return &attempt, nil
+}
+
+// RecordFailure counts a failed attempt. Records whose window has passed are
+// dropped at most once per window, so the map only holds recent identifiers.
+func (s *memoryLoginAttemptStore) RecordFailure(ctx context.Context, identifier string, now time.Time, window time.Duration) (*domain.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) > window {
+		s.sweep(now, window)
+	}
+
+	attempt, ok := s.attempts[identifier]
+	if !ok || now.Sub(attempt.LastFailedAt) > window {
+		attempt = domain.LoginAttempt{Identifier: identifier, FirstFailedAt: now}
+	}
+	attempt.Failures++
+	attempt.LastFailedAt = now
+	s.attempts[identifier] = attempt
+
+	return &attempt, nil
+}
+
+// Reset clears the attempt record of an identifier
+func (s *memoryLoginAttemptStore) Reset(ctx context.Context, identifier string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, identifier)
+	return nil
+}
+
+// sweep drops records whose last failure is older than window
+func (s *memoryLoginAttemptStore) sweep(now time.Time, window time.Duration) {
+	for identifier, attempt := range s.attempts {
+		if now.Sub(attempt.LastFailedAt) > window {
+			delete(s.attempts, identifier)
+		}
+	}
+	s.lastSweep = now
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLoginAttemptStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryLoginAttemptStore()
+	now := time.Now()
+
+	attempt, err := store.Get(ctx, "email:test@example.com")
+	require.NoError(t, err)
+	assert.Nil(t, attempt)
+
+	t.Run("counts failures within the window", func(t *testing.T) {
+		store.RecordFailure(ctx, "email:test@example.com", now, time.Hour)
+		attempt, err := store.RecordFailure(ctx, "email:test@example.com", now.Add(time.Minute), time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, 2, attempt.Failures)
+		assert.Equal(t, now, attempt.FirstFailedAt)
+	})
+
+	t.Run("restarts the count after the window", func(t *testing.T) {
+		later := now.Add(2 * time.Hour)
+		attempt, err := store.RecordFailure(ctx, "email:test@example.com", later, time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, 1, attempt.Failures)
+		assert.Equal(t, later, attempt.FirstFailedAt)
+	})
+
+	t.Run("reset removes the record", func(t *testing.T) {
+		require.NoError(t, store.Reset(ctx, "email:test@example.com"))
+		attempt, err := store.Get(ctx, "email:test@example.com")
+		require.NoError(t, err)
+		assert.Nil(t, attempt)
+	})
+
+	t.Run("drops expired records", func(t *testing.T) {
+		store := NewMemoryLoginAttemptStore().(*memoryLoginAttemptStore)
+		for _, ip := range []string{"ip:192.0.2.1", "ip:192.0.2.2", "ip:192.0.2.3"} {
+			store.RecordFailure(ctx, ip, now, time.Hour)
+		}
+		store.RecordFailure(ctx, "ip:192.0.2.4", now.Add(30*time.Minute), time.Hour)
+		assert.Len(t, store.attempts, 4)
+
+		later := now.Add(2 * time.Hour)
+		_, err := store.RecordFailure(ctx, "ip:192.0.2.5", later, time.Hour)
+		require.NoError(t, err)
+		assert.Len(t, store.attempts, 1)
+
+		attempt, err := store.Get(ctx, "ip:192.0.2.1")
+		require.NoError(t, err)
+		assert.Nil(t, attempt)
+	})
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type loginAttemptRepository struct {
+	db *gorm.DB
+}
+
+// NewLoginAttemptRepository creates a DB-backed login attempt store
+func NewLoginAttemptRepository(db *gorm.DB) LoginAttemptStore {
+	return &loginAttemptRepository{db: db}
+}
+
+// Get finds the attempt record of an identifier
+func (r *loginAttemptRepository) Get(ctx context.Context, identifier string) (*domain.LoginAttempt, error) {
+	var attempt domain.LoginAttempt
+	err := r.db.WithContext(ctx).Where("identifier = ?", identifier).First(&attempt).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// RecordFailure counts a failed attempt under a row lock so concurrent
+// instances don't lose updates
+func (r *loginAttemptRepository) RecordFailure(ctx context.Context, identifier string, now time.Time, window time.Duration) (*domain.LoginAttempt, error) {
+	var attempt domain.LoginAttempt
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		seed := domain.LoginAttempt{Identifier: identifier, FirstFailedAt: now, LastFailedAt: now}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&seed).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("identifier = ?", identifier).
+			First(&attempt).Error; err != nil {
+			return err
+		}
+
+		if attempt.Failures > 0 && now.Sub(attempt.LastFailedAt) > window {
+			attempt.Failures = 0
+			attempt.FirstFailedAt = now
+		}
+		attempt.Failures++
+		attempt.LastFailedAt = now
+		return tx.Save(&attempt).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// Reset clears the attempt record of an identifier
+func (r *loginAttemptRepository) Reset(ctx context.Context, identifier string) error {
+	return r.db.WithContext(ctx).Unscoped().Where("identifier = ?", identifier).Delete(&domain.LoginAttempt{}).Error
+}
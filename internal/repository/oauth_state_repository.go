@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type oauthStateRepository struct {
+	db *gorm.DB
+}
+
+// NewOAuthStateRepository creates a new OAuth state repository
+func NewOAuthStateRepository(db *gorm.DB) OAuthStateRepository {
+	return &oauthStateRepository{db: db}
+}
+
+// Create stores a pending authorization request
+func (r *oauthStateRepository) Create(ctx context.Context, state *domain.OAuthState) error {
+	return r.db.WithContext(ctx).Create(state).Error
+}
+
+// Consume loads and deletes a state in one transaction so it can be used once
+func (r *oauthStateRepository) Consume(ctx context.Context, digest string) (*domain.OAuthState, error) {
+	var state domain.OAuthState
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("state_digest = ?", digest).
+			First(&state).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&state).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &state, nil
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"gorm.io/gorm"
+)
+
+type passwordHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewPasswordHistoryRepository creates a new password history repository
+func NewPasswordHistoryRepository(db *gorm.DB) PasswordHistoryRepository {
+	return &passwordHistoryRepository{db: db}
+}
+
+// Create records a previous password digest
+func (r *passwordHistoryRepository) Create(ctx context.Context, history *domain.PasswordHistory) error {
+	return r.db.WithContext(ctx).Create(history).Error
+}
+
+// FindRecentByUserID finds the newest password digests of a user
+func (r *passwordHistoryRepository) FindRecentByUserID(ctx context.Context, userID uint, limit int) ([]*domain.PasswordHistory, error) {
+	var histories []*domain.PasswordHistory
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&histories).Error
+	if err != nil {
+		return nil, err
+	}
+	return histories, nil
+}
+
+// Prune removes all but the newest keep digests of a user
+func (r *passwordHistoryRepository) Prune(ctx context.Context, userID uint, keep int) error {
+	recent, err := r.FindRecentByUserID(ctx, userID, keep)
+	if err != nil {
+		return err
+	}
+
+	query := r.db.WithContext(ctx).Unscoped().Where("user_id = ?", userID)
+	if len(recent) > 0 {
+		ids := make([]uint, len(recent))
+		for i, history := range recent {
+			ids[i] = history.ID
+		}
+		query = query.Where("id NOT IN ?", ids)
+	}
+	return query.Delete(&domain.PasswordHistory{}).Error
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"gorm.io/gorm"
+)
+
+type personalAccessTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewPersonalAccessTokenRepository creates a new personal access token repository
+func NewPersonalAccessTokenRepository(db *gorm.DB) PersonalAccessTokenRepository {
+	return &personalAccessTokenRepository{db: db}
+}
+
+// Create creates a new personal access token
+func (r *personalAccessTokenRepository) Create(ctx context.Context, token *domain.PersonalAccessToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+// FindByID finds a personal access token by ID
+func (r *personalAccessTokenRepository) FindByID(ctx context.Context, id uint) (*domain.PersonalAccessToken, error) {
+	var token domain.PersonalAccessToken
+	err := r.db.WithContext(ctx).First(&token, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Update updates a personal access token
+func (r *personalAccessTokenRepository) Update(ctx context.Context, token *domain.PersonalAccessToken) error {
+	return r.db.WithContext(ctx).Save(token).Error
+}
+
+// Delete deletes a personal access token
+func (r *personalAccessTokenRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&domain.PersonalAccessToken{}, id).Error
+}
+
+// FindByDigest finds a personal access token by its digest
+func (r *personalAccessTokenRepository) FindByDigest(ctx context.Context, digest string) (*domain.PersonalAccessToken, error) {
+	var token domain.PersonalAccessToken
+	err := r.db.WithContext(ctx).Where("token_digest = ?", digest).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// FindByUserID finds the non-revoked tokens of a user, newest first
+func (r *personalAccessTokenRepository) FindByUserID(ctx context.Context, userID uint) ([]*domain.PersonalAccessToken, error) {
+	var tokens []*domain.PersonalAccessToken
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// Revoke revokes a personal access token
+func (r *personalAccessTokenRepository) Revoke(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&domain.PersonalAccessToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeAllByUserID revokes all personal access tokens of a user
+func (r *personalAccessTokenRepository) RevokeAllByUserID(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Model(&domain.PersonalAccessToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// TouchLastUsed records the last use of a token unless it was recorded within interval
+func (r *personalAccessTokenRepository) TouchLastUsed(ctx context.Context, id uint, ip string, interval time.Duration) error {
+	now := time.Now()
+	return r.db.WithContext(ctx).Model(&domain.PersonalAccessToken{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, now.Add(-interval)).
+		Updates(map[string]interface{}{
+			"last_used_at": now,
+			"last_used_ip": ip,
+		}).Error
+}
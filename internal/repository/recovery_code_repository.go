@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"gorm.io/gorm"
+)
+
+type recoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewRecoveryCodeRepository creates a new recovery code repository
+func NewRecoveryCodeRepository(db *gorm.DB) RecoveryCodeRepository {
+	return &recoveryCodeRepository{db: db}
+}
+
+// Create creates a new recovery code
+func (r *recoveryCodeRepository) Create(ctx context.Context, code *domain.RecoveryCode) error {
+	return r.db.WithContext(ctx).Create(code).Error
+}
+
+// FindByID finds a recovery code by ID
+func (r *recoveryCodeRepository) FindByID(ctx context.Context, id uint) (*domain.RecoveryCode, error) {
+	var code domain.RecoveryCode
+	err := r.db.WithContext(ctx).First(&code, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &code, nil
+}
+
+// Update updates a recovery code
+func (r *recoveryCodeRepository) Update(ctx context.Context, code *domain.RecoveryCode) error {
+	return r.db.WithContext(ctx).Save(code).Error
+}
+
+// Delete deletes a recovery code
+func (r *recoveryCodeRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&domain.RecoveryCode{}, id).Error
+}
+
+// ReplaceForUser discards a user's codes and stores a new set
+func (r *recoveryCodeRepository) ReplaceForUser(ctx context.Context, userID uint, digests []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		codes := make([]domain.RecoveryCode, len(digests))
+		for i, digest := range digests {
+			codes[i] = domain.RecoveryCode{UserID: userID, CodeDigest: digest}
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// Use marks a matching unused code as used
+func (r *recoveryCodeRepository) Use(ctx context.Context, userID uint, digest string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&domain.RecoveryCode{}).
+		Where("user_id = ? AND code_digest = ? AND used_at IS NULL", userID, digest).
+		Limit(1).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// DeleteByUserID removes all codes of a user
+func (r *recoveryCodeRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Unscoped().Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error
+}
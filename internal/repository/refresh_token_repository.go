@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"gorm.io/gorm"
+)
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewRefreshTokenRepository creates a new refresh token repository
+func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
+	return &refreshTokenRepository{db: db}
+}
+
+// Create creates a new refresh token
+func (r *refreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+// FindByID finds a refresh token by ID
+func (r *refreshTokenRepository) FindByID(ctx context.Context, id uint) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	err := r.db.WithContext(ctx).Preload("Session").First(&token, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// FindByDigest finds a refresh token by its digest
+func (r *refreshTokenRepository) FindByDigest(ctx context.Context, digest string) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	err := r.db.WithContext(ctx).Preload("Session").Where("token_digest = ?", digest).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Update updates a refresh token
+func (r *refreshTokenRepository) Update(ctx context.Context, token *domain.RefreshToken) error {
+	return r.db.WithContext(ctx).Save(token).Error
+}
+
+// Delete deletes a refresh token
+func (r *refreshTokenRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&domain.RefreshToken{}, id).Error
+}
+
+// MarkUsed marks a refresh token as used; returns false if it was already used
+func (r *refreshTokenRepository) MarkUsed(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&domain.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
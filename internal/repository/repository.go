@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"time"
+
 	"github.com/House-lovers7/speadwear-go/internal/domain"
 )
 
@@ -17,7 +19,25 @@ type BaseRepository[T any] interface {
 type UserRepository interface {
 	BaseRepository[domain.User]
 	FindByEmail(ctx context.Context, email string) (*domain.User, error)
+	FindByActivationDigest(ctx context.Context, digest string) (*domain.User, error)
+	FindByResetDigest(ctx context.Context, digest string) (*domain.User, error)
+	FindByUnlockDigest(ctx context.Context, digest string) (*domain.User, error)
+	FindByMagicLinkDigest(ctx context.Context, digest string) (*domain.User, error)
+	FindByEmailChangeDigest(ctx context.Context, digest string) (*domain.User, error)
+	FindByEmailRevertDigest(ctx context.Context, digest string) (*domain.User, error)
+	// ConsumeMagicLink clears the magic link digest; returns false if it was
+	// already used or replaced
+	ConsumeMagicLink(ctx context.Context, userID uint, digest string) (bool, error)
+	// AdvanceTOTPStep records the last accepted TOTP step; returns false if the
+	// step is not newer than the stored one (code replay)
+	AdvanceTOTPStep(ctx context.Context, userID uint, step int64) (bool, error)
+	// FindDueForDeletion finds accounts whose deletion grace period ended at or before the given time
+	FindDueForDeletion(ctx context.Context, before time.Time) ([]*domain.User, error)
+	// FindDeclutterDigestDue finds active users with the forgotten items reminder
+	// enabled who were last reminded at or before the given time
+	FindDeclutterDigestDue(ctx context.Context, before time.Time) ([]*domain.User, error)
 	FindAll(ctx context.Context, limit, offset int) ([]*domain.User, error)
+	Search(ctx context.Context, query string, limit, offset int) ([]*domain.User, int64, error)
 	Count(ctx context.Context) (int64, error)
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
 }
@@ -26,8 +46,27 @@ type UserRepository interface {
 type ItemRepository interface {
 	BaseRepository[domain.Item]
 	FindByUserID(ctx context.Context, userID uint, limit, offset int) ([]*domain.Item, error)
+	// FindInWardrobeByUserID is FindByUserID without archived, donated or sold items
+	FindInWardrobeByUserID(ctx context.Context, userID uint, limit, offset int) ([]*domain.Item, error)
 	FindByFilters(ctx context.Context, filters ItemFilter) ([]*domain.Item, error)
 	CountByUserID(ctx context.Context, userID uint) (int64, error)
+	CountInWardrobeByUserID(ctx context.Context, userID uint) (int64, error)
+	// ChangeStatus saves the item's new status together with the transition record
+	ChangeStatus(ctx context.Context, item *domain.Item, change *domain.ItemStatusChange) error
+}
+
+// CategoryRepository defines methods for item category data access
+type CategoryRepository interface {
+	BaseRepository[domain.Category]
+	// FindByUserID finds all of a user's categories, ordered by position within their parent
+	FindByUserID(ctx context.Context, userID uint) ([]*domain.Category, error)
+	// CreateDefaults creates a user's first top-level categories and files the user's items under them
+	CreateDefaults(ctx context.Context, userID uint, names []string) ([]*domain.Category, error)
+	// Rename saves a category's name and sets the super item of the items filed under categoryIDs
+	Rename(ctx context.Context, category *domain.Category, categoryIDs []uint, superItem string) error
+	UpdatePositions(ctx context.Context, categories []*domain.Category) error
+	// Merge moves source's items and sub-categories into target and deletes source
+	Merge(ctx context.Context, source, target *domain.Category, categoryIDs []uint, superItem string) error
 }
 
 // CoordinateRepository defines methods for coordinate data access
@@ -39,10 +78,22 @@ type CoordinateRepository interface {
 	CountByUserID(ctx context.Context, userID uint) (int64, error)
 }
 
+// WearLogRepository defines methods for wear log data access
+type WearLogRepository interface {
+	BaseRepository[domain.WearLog]
+	// FindByFilters finds a user's wear logs, latest day first, with the total count
+	FindByFilters(ctx context.Context, filter WearLogFilter) ([]*domain.WearLog, int64, error)
+	// ItemWearStats aggregates how often and when the given items were worn, by item ID
+	ItemWearStats(ctx context.Context, itemIDs []uint) (map[uint]domain.WearStats, error)
+	// CoordinateWearStats aggregates how often and when the given coordinates were worn, by coordinate ID
+	CoordinateWearStats(ctx context.Context, coordinateIDs []uint) (map[uint]domain.WearStats, error)
+}
+
 // CommentRepository defines methods for comment data access
 type CommentRepository interface {
 	BaseRepository[domain.Comment]
 	FindByCoordinateID(ctx context.Context, coordinateID uint, limit, offset int) ([]*domain.Comment, error)
+	FindByUserID(ctx context.Context, userID uint) ([]*domain.Comment, error)
 	CountByCoordinateID(ctx context.Context, coordinateID uint) (int64, error)
 }
 
@@ -50,6 +101,7 @@ type CommentRepository interface {
 type LikeCoordinateRepository interface {
 	BaseRepository[domain.LikeCoordinate]
 	FindByCoordinateID(ctx context.Context, coordinateID uint) ([]*domain.LikeCoordinate, error)
+	FindByUserID(ctx context.Context, userID uint) ([]*domain.LikeCoordinate, error)
 	FindByUserAndCoordinate(ctx context.Context, userID, coordinateID uint) (*domain.LikeCoordinate, error)
 	ExistsByUserAndCoordinate(ctx context.Context, userID, coordinateID uint) (bool, error)
 	CountByCoordinateID(ctx context.Context, coordinateID uint) (int64, error)
@@ -84,6 +136,93 @@ type NotificationRepository interface {
 	CountUnreadByReceiverID(ctx context.Context, receiverID uint) (int64, error)
 }
 
+// SessionRepository defines methods for login session data access
+type SessionRepository interface {
+	BaseRepository[domain.Session]
+	FindActiveByUserID(ctx context.Context, userID uint) ([]*domain.Session, error)
+	Revoke(ctx context.Context, id uint) error
+	RevokeAllByUserID(ctx context.Context, userID uint, exceptID uint) error
+}
+
+// RefreshTokenRepository defines methods for refresh token data access
+type RefreshTokenRepository interface {
+	BaseRepository[domain.RefreshToken]
+	FindByDigest(ctx context.Context, digest string) (*domain.RefreshToken, error)
+	MarkUsed(ctx context.Context, id uint) (bool, error)
+}
+
+// AuditEventRepository defines methods for audit trail data access. The trail
+// is append-only, so events can't be updated or deleted.
+type AuditEventRepository interface {
+	Create(ctx context.Context, event *domain.AuditEvent) error
+	Search(ctx context.Context, filter AuditEventFilter) ([]*domain.AuditEvent, int64, error)
+}
+
+// RecoveryCodeRepository defines methods for two-factor recovery code data access
+type RecoveryCodeRepository interface {
+	BaseRepository[domain.RecoveryCode]
+	ReplaceForUser(ctx context.Context, userID uint, digests []string) error
+	// Use consumes an unused code; returns false if none matched
+	Use(ctx context.Context, userID uint, digest string) (bool, error)
+	DeleteByUserID(ctx context.Context, userID uint) error
+}
+
+// PasswordHistoryRepository defines methods for previous password data access
+type PasswordHistoryRepository interface {
+	Create(ctx context.Context, history *domain.PasswordHistory) error
+	FindRecentByUserID(ctx context.Context, userID uint, limit int) ([]*domain.PasswordHistory, error)
+	// Prune keeps only the newest keep entries of a user
+	Prune(ctx context.Context, userID uint, keep int) error
+}
+
+// UserIdentityRepository defines methods for linked OIDC identity data access
+type UserIdentityRepository interface {
+	BaseRepository[domain.UserIdentity]
+	FindByProviderSubject(ctx context.Context, provider, subject string) (*domain.UserIdentity, error)
+	FindByLinkDigest(ctx context.Context, digest string) (*domain.UserIdentity, error)
+	FindByUserID(ctx context.Context, userID uint) ([]*domain.UserIdentity, error)
+}
+
+// OAuthStateRepository defines methods for pending OIDC authorization data access
+type OAuthStateRepository interface {
+	Create(ctx context.Context, state *domain.OAuthState) error
+	// Consume deletes and returns the state with the digest; nil if unknown
+	Consume(ctx context.Context, digest string) (*domain.OAuthState, error)
+}
+
+// PersonalAccessTokenRepository defines methods for personal access token data access
+type PersonalAccessTokenRepository interface {
+	BaseRepository[domain.PersonalAccessToken]
+	FindByDigest(ctx context.Context, digest string) (*domain.PersonalAccessToken, error)
+	FindByUserID(ctx context.Context, userID uint) ([]*domain.PersonalAccessToken, error)
+	Revoke(ctx context.Context, id uint) error
+	RevokeAllByUserID(ctx context.Context, userID uint) error
+	// TouchLastUsed records usage, writing at most once per interval
+	TouchLastUsed(ctx context.Context, id uint, ip string, interval time.Duration) error
+}
+
+// DataExportRepository defines methods for data export data access
+type DataExportRepository interface {
+	BaseRepository[domain.DataExport]
+	FindByUserID(ctx context.Context, userID uint) ([]*domain.DataExport, error)
+	FindLatestByUserID(ctx context.Context, userID uint) (*domain.DataExport, error)
+	FindByDownloadDigest(ctx context.Context, digest string) (*domain.DataExport, error)
+	// CreateIfIdle creates the export unless one updated since staleBefore is
+	// still in progress, and reports whether it did
+	CreateIfIdle(ctx context.Context, export *domain.DataExport, staleBefore time.Time) (bool, error)
+	MarkFailed(ctx context.Context, id uint, reason string) error
+}
+
+// LoginAttemptStore tracks failed login attempts. Implementations must be safe
+// for concurrent use; the DB-backed one is shared between server instances.
+type LoginAttemptStore interface {
+	Get(ctx context.Context, identifier string) (*domain.LoginAttempt, error)
+	// RecordFailure counts a failure, restarting the count when the previous
+	// failures are older than window, and returns the updated attempt
+	RecordFailure(ctx context.Context, identifier string, now time.Time, window time.Duration) (*domain.LoginAttempt, error)
+	Reset(ctx context.Context, identifier string) error
+}
+
 // Filter structures
 type ItemFilter struct {
 	UserID   *uint
@@ -93,6 +232,8 @@ type ItemFilter struct {
 	SuperItem *string
 	MinRating *float32
 	MaxRating *float32
+	Statuses  []string // any status when empty
+	CategoryIDs []uint // any category when empty
 	Limit    int
 	Offset   int
 }
@@ -105,4 +246,24 @@ type CoordinateFilter struct {
 	MaxRating *float32
 	Limit     int
 	Offset    int
+}
+
+type AuditEventFilter struct {
+	ActorID    *uint
+	Action     string
+	TargetType string
+	TargetID   *uint
+	IPAddress  string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+type WearLogFilter struct {
+	UserID uint
+	From   *time.Time // first day, inclusive
+	To     *time.Time // last day, inclusive
+	Limit  int
+	Offset int
 }
\ No newline at end of file
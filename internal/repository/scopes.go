@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"gorm.io/gorm"
+)
+
+// hiddenCondition matches users whose suspension is in effect or whose
+// account is awaiting deletion
+const hiddenCondition = "(suspended_at IS NOT NULL AND (suspended_until IS NULL OR suspended_until > ?)) OR deletion_scheduled_at IS NOT NULL"
+
+// notHidden excludes suspended users and accounts awaiting deletion from a users query
+func notHidden(db *gorm.DB) *gorm.DB {
+	return db.Where("NOT ("+hiddenCondition+")", time.Now())
+}
+
+// ownerNotHidden excludes records owned (user_id) by hidden users
+func ownerNotHidden(db *gorm.DB) *gorm.DB {
+	hidden := db.Session(&gorm.Session{NewDB: true}).
+		Model(&domain.User{}).
+		Select("id").
+		Where(hiddenCondition, time.Now())
+	return db.Where("user_id NOT IN (?)", hidden)
+}
+
+// withCoordinateItems preloads a coordinate's items in outfit order
+func withCoordinateItems(db *gorm.DB) *gorm.DB {
+	return db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position, id")
+	}).Preload("Items.Item")
+}
+
+// inWardrobe excludes items that left the wardrobe (archived, donated or sold)
+func inWardrobe(db *gorm.DB) *gorm.DB {
+	return db.Where("status NOT IN ?", domain.RetiredItemStatuses)
+}
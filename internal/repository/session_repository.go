@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"gorm.io/gorm"
+)
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+// NewSessionRepository creates a new session repository
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+// Create creates a new session
+func (r *sessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	return r.db.WithContext(ctx).Create(session).Error
+}
+
+// FindByID finds a session by ID
+func (r *sessionRepository) FindByID(ctx context.Context, id uint) (*domain.Session, error) {
+	var session domain.Session
+	err := r.db.WithContext(ctx).First(&session, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Update updates a session
+func (r *sessionRepository) Update(ctx context.Context, session *domain.Session) error {
+	return r.db.WithContext(ctx).Save(session).Error
+}
+
+// Delete deletes a session
+func (r *sessionRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&domain.Session{}, id).Error
+}
+
+// FindActiveByUserID finds non-revoked, non-expired sessions of a user
+func (r *sessionRepository) FindActiveByUserID(ctx context.Context, userID uint) ([]*domain.Session, error) {
+	var sessions []*domain.Session
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// Revoke revokes a session
+func (r *sessionRepository) Revoke(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&domain.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeAllByUserID revokes all sessions of a user except exceptID (0 revokes all)
+func (r *sessionRepository) RevokeAllByUserID(ctx context.Context, userID uint, exceptID uint) error {
+	query := r.db.WithContext(ctx).Model(&domain.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID)
+	if exceptID != 0 {
+		query = query.Where("id <> ?", exceptID)
+	}
+	return query.Update("revoked_at", time.Now()).Error
+}
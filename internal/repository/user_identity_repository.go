@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"gorm.io/gorm"
+)
+
+type userIdentityRepository struct {
+	db *gorm.DB
+}
+
+// NewUserIdentityRepository creates a new user identity repository
+func NewUserIdentityRepository(db *gorm.DB) UserIdentityRepository {
+	return &userIdentityRepository{db: db}
+}
+
+// Create creates a new identity
+func (r *userIdentityRepository) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	return r.db.WithContext(ctx).Create(identity).Error
+}
+
+// FindByID finds an identity by ID
+func (r *userIdentityRepository) FindByID(ctx context.Context, id uint) (*domain.UserIdentity, error) {
+	var identity domain.UserIdentity
+	err := r.db.WithContext(ctx).First(&identity, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// Update updates an identity
+func (r *userIdentityRepository) Update(ctx context.Context, identity *domain.UserIdentity) error {
+	return r.db.WithContext(ctx).Save(identity).Error
+}
+
+// Delete removes an identity so the provider account can be linked again
+func (r *userIdentityRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Unscoped().Delete(&domain.UserIdentity{}, id).Error
+}
+
+// FindByProviderSubject finds the identity of a provider account
+func (r *userIdentityRepository) FindByProviderSubject(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	var identity domain.UserIdentity
+	err := r.db.WithContext(ctx).Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// FindByLinkDigest finds a pending identity by link confirmation token digest
+func (r *userIdentityRepository) FindByLinkDigest(ctx context.Context, digest string) (*domain.UserIdentity, error) {
+	var identity domain.UserIdentity
+	err := r.db.WithContext(ctx).Where("link_digest = ?", digest).First(&identity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// FindByUserID finds the identities of a user
+func (r *userIdentityRepository) FindByUserID(ctx context.Context, userID uint) ([]*domain.UserIdentity, error) {
+	var identities []*domain.UserIdentity
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at").Find(&identities).Error
+	if err != nil {
+		return nil, err
+	}
+	return identities, nil
+}
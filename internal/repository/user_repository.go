@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 	
 	"github.com/House-lovers7/speadwear-go/internal/domain"
 	"gorm.io/gorm"
@@ -48,6 +50,137 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*domain
 	return &user, nil
 }
 
+// FindByActivationDigest finds a user by activation token digest
+func (r *userRepository) FindByActivationDigest(ctx context.Context, digest string) (*domain.User, error) {
+	var user domain.User
+	err := r.db.WithContext(ctx).Where("activation_digest = ?", digest).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindByResetDigest finds a user by password reset token digest
+func (r *userRepository) FindByResetDigest(ctx context.Context, digest string) (*domain.User, error) {
+	var user domain.User
+	err := r.db.WithContext(ctx).Where("reset_digest = ?", digest).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindByUnlockDigest finds a user by account unlock token digest
+func (r *userRepository) FindByUnlockDigest(ctx context.Context, digest string) (*domain.User, error) {
+	var user domain.User
+	err := r.db.WithContext(ctx).Where("unlock_digest = ?", digest).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindByMagicLinkDigest finds a user by magic login link token digest
+func (r *userRepository) FindByMagicLinkDigest(ctx context.Context, digest string) (*domain.User, error) {
+	var user domain.User
+	err := r.db.WithContext(ctx).Where("magic_link_digest = ?", digest).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindByEmailChangeDigest finds a user by pending email confirmation token digest
+func (r *userRepository) FindByEmailChangeDigest(ctx context.Context, digest string) (*domain.User, error) {
+	var user domain.User
+	err := r.db.WithContext(ctx).Where("email_change_digest = ?", digest).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindByEmailRevertDigest finds a user by email change cancellation token digest
+func (r *userRepository) FindByEmailRevertDigest(ctx context.Context, digest string) (*domain.User, error) {
+	var user domain.User
+	err := r.db.WithContext(ctx).Where("email_revert_digest = ?", digest).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// ConsumeMagicLink atomically invalidates a magic login link
+func (r *userRepository) ConsumeMagicLink(ctx context.Context, userID uint, digest string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&domain.User{}).
+		Where("id = ? AND magic_link_digest = ?", userID, digest).
+		Updates(map[string]interface{}{
+			"magic_link_digest":  "",
+			"magic_link_sent_at": nil,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// AdvanceTOTPStep atomically moves the last accepted TOTP step forward
+func (r *userRepository) AdvanceTOTPStep(ctx context.Context, userID uint, step int64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&domain.User{}).
+		Where("id = ? AND totp_last_step < ?", userID, step).
+		Update("totp_last_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// FindDueForDeletion finds users whose scheduled deletion time has passed
+func (r *userRepository) FindDueForDeletion(ctx context.Context, before time.Time) ([]*domain.User, error) {
+	var users []*domain.User
+	err := r.db.WithContext(ctx).
+		Where("deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= ?", before).
+		Order("deletion_scheduled_at").
+		Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// FindDeclutterDigestDue finds users due a forgotten items reminder
+func (r *userRepository) FindDeclutterDigestDue(ctx context.Context, before time.Time) ([]*domain.User, error) {
+	var users []*domain.User
+	err := r.db.WithContext(ctx).
+		Where("declutter_digest_days > 0").
+		Where("declutter_notified_at IS NULL OR declutter_notified_at <= ?", before).
+		Scopes(notHidden).
+		Order("id").
+		Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 // Update updates a user
 func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
 	return r.db.WithContext(ctx).Save(user).Error
@@ -58,10 +191,10 @@ func (r *userRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&domain.User{}, id).Error
 }
 
-// FindAll finds all users with pagination
+// FindAll finds all visible users (not suspended or awaiting deletion) with pagination
 func (r *userRepository) FindAll(ctx context.Context, limit, offset int) ([]*domain.User, error) {
 	var users []*domain.User
-	query := r.db.WithContext(ctx)
+	query := r.db.WithContext(ctx).Scopes(notHidden)
 	
 	if limit > 0 {
 		query = query.Limit(limit)
@@ -77,10 +210,46 @@ func (r *userRepository) FindAll(ctx context.Context, limit, offset int) ([]*dom
 	return users, nil
 }
 
-// Count counts all users
+// Search finds users whose email or name contains the query
+func (r *userRepository) Search(ctx context.Context, query string, limit, offset int) ([]*domain.User, int64, error) {
+	var users []*domain.User
+	var total int64
+	
+	db := r.db.WithContext(ctx).Model(&domain.User{})
+	if query != "" {
+		pattern := "%" + escapeLike(query) + "%"
+		db = db.Where("email LIKE ? OR name LIKE ?", pattern, pattern)
+	}
+	
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	
+	if limit > 0 {
+		db = db.Limit(limit)
+	}
+	if offset > 0 {
+		db = db.Offset(offset)
+	}
+	
+	if err := db.Order("id ASC").Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// likeEscaper escapes the LIKE wildcards and MySQL's default escape character
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// escapeLike makes a search term match literally inside a LIKE pattern
+func escapeLike(term string) string {
+	return likeEscaper.Replace(term)
+}
+
+// Count counts all visible users
 func (r *userRepository) Count(ctx context.Context) (int64, error) {
 	var count int64
-	err := r.db.WithContext(ctx).Model(&domain.User{}).Count(&count).Error
+	err := r.db.WithContext(ctx).Model(&domain.User{}).Scopes(notHidden).Count(&count).Error
 	return count, err
 }
 
@@ -272,6 +272,41 @@ func TestUserRepository_FindAll(t *testing.T) {
 	}
 }
 
+func TestUserRepository_Search(t *testing.T) {
+	db := testutil.TestDB(t)
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+	fixtures := testutil.NewFixtures(t, db)
+
+	fixtures.CreateUser(func(u *domain.User) { u.Name = "Alice" })
+	fixtures.CreateUser(func(u *domain.User) { u.Name = "Bob" })
+	fixtures.CreateUser(func(u *domain.User) { u.Name = "100% cotton_fan" })
+
+	tests := []struct {
+		name  string
+		query string
+		want  int64
+	}{
+		{"empty query", "", 3},
+		{"name match", "ali", 1},
+		{"percent sign", "%", 1},
+		{"underscore", "_", 1},
+		{"wildcards only match literally", "0%_c", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, total, err := repo.Search(ctx, tt.query, 10, 0)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if total != tt.want || int64(len(users)) != tt.want {
+				t.Errorf("Search(%q) returned %d users (total %d), want %d", tt.query, len(users), total, tt.want)
+			}
+		})
+	}
+}
+
 func TestUserRepository_Count(t *testing.T) {
 	db := testutil.TestDB(t)
 	repo := NewUserRepository(db)
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"gorm.io/gorm"
+)
+
+type wearLogRepository struct {
+	db *gorm.DB
+}
+
+// NewWearLogRepository creates a new wear log repository
+func NewWearLogRepository(db *gorm.DB) WearLogRepository {
+	return &wearLogRepository{db: db}
+}
+
+// Create creates a wear log together with its items
+func (r *wearLogRepository) Create(ctx context.Context, wearLog *domain.WearLog) error {
+	return r.db.WithContext(ctx).Create(wearLog).Error
+}
+
+// FindByID finds a wear log by ID with its coordinate and items
+func (r *wearLogRepository) FindByID(ctx context.Context, id uint) (*domain.WearLog, error) {
+	var wearLog domain.WearLog
+	err := r.db.WithContext(ctx).
+		Preload("Coordinate").
+		Preload("Items.Item").
+		First(&wearLog, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &wearLog, nil
+}
+
+// Update saves a wear log and replaces its items with wearLog.Items
+func (r *wearLogRepository) Update(ctx context.Context, wearLog *domain.WearLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Items", "Coordinate", "User").Save(wearLog).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("wear_log_id = ?", wearLog.ID).Delete(&domain.WearLogItem{}).Error; err != nil {
+			return err
+		}
+		for i := range wearLog.Items {
+			wearLog.Items[i].ID = 0
+			wearLog.Items[i].WearLogID = wearLog.ID
+		}
+		if len(wearLog.Items) == 0 {
+			return nil
+		}
+		return tx.Omit("Item").Create(&wearLog.Items).Error
+	})
+}
+
+// Delete deletes a wear log and its items
+func (r *wearLogRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("wear_log_id = ?", id).Delete(&domain.WearLogItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.WearLog{}, id).Error
+	})
+}
+
+// FindByFilters finds a user's wear logs, latest day first, with the total count
+func (r *wearLogRepository) FindByFilters(ctx context.Context, filter WearLogFilter) ([]*domain.WearLog, int64, error) {
+	var logs []*domain.WearLog
+	var total int64
+
+	db := r.db.WithContext(ctx).Model(&domain.WearLog{}).Where("user_id = ?", filter.UserID)
+	if filter.From != nil {
+		db = db.Where("worn_on >= ?", filter.From.Format(domain.DateLayout))
+	}
+	if filter.To != nil {
+		db = db.Where("worn_on <= ?", filter.To.Format(domain.DateLayout))
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filter.Limit > 0 {
+		db = db.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		db = db.Offset(filter.Offset)
+	}
+
+	err := db.Preload("Coordinate").
+		Preload("Items.Item").
+		Order("worn_on DESC, id DESC").
+		Find(&logs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return logs, total, nil
+}
+
+// wearStatsRow is one aggregated row of wear statistics
+type wearStatsRow struct {
+	ID         uint
+	WearCount  int64
+	LastWornOn time.Time
+}
+
+// ItemWearStats aggregates how often and when the given items were worn, by item ID
+func (r *wearLogRepository) ItemWearStats(ctx context.Context, itemIDs []uint) (map[uint]domain.WearStats, error) {
+	if len(itemIDs) == 0 {
+		return map[uint]domain.WearStats{}, nil
+	}
+	var rows []wearStatsRow
+	err := r.db.WithContext(ctx).
+		Table("wear_log_items").
+		Select("wear_log_items.item_id AS id, COUNT(*) AS wear_count, MAX(wear_logs.worn_on) AS last_worn_on").
+		Joins("JOIN wear_logs ON wear_logs.id = wear_log_items.wear_log_id AND wear_logs.deleted_at IS NULL").
+		Where("wear_log_items.item_id IN ?", itemIDs).
+		Group("wear_log_items.item_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return wearStatsByID(rows), nil
+}
+
+// CoordinateWearStats aggregates how often and when the given coordinates were worn, by coordinate ID
+func (r *wearLogRepository) CoordinateWearStats(ctx context.Context, coordinateIDs []uint) (map[uint]domain.WearStats, error) {
+	if len(coordinateIDs) == 0 {
+		return map[uint]domain.WearStats{}, nil
+	}
+	var rows []wearStatsRow
+	err := r.db.WithContext(ctx).
+		Model(&domain.WearLog{}).
+		Select("coordinate_id AS id, COUNT(*) AS wear_count, MAX(worn_on) AS last_worn_on").
+		Where("coordinate_id IN ?", coordinateIDs).
+		Group("coordinate_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return wearStatsByID(rows), nil
+}
+
+// wearStatsByID indexes aggregated rows by the item or coordinate ID
+func wearStatsByID(rows []wearStatsRow) map[uint]domain.WearStats {
+	stats := make(map[uint]domain.WearStats, len(rows))
+	for _, row := range rows {
+		lastWorn := row.LastWornOn
+		stats[row.ID] = domain.WearStats{WearCount: row.WearCount, LastWornAt: &lastWorn}
+	}
+	return stats
+}
@@ -2,15 +2,17 @@ package router
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/House-lovers7/speadwear-go/internal/domain"
 	"github.com/House-lovers7/speadwear-go/internal/handler"
 	"github.com/House-lovers7/speadwear-go/internal/middleware"
 	"github.com/House-lovers7/speadwear-go/internal/repository"
 	"github.com/House-lovers7/speadwear-go/internal/usecase"
 	"github.com/House-lovers7/speadwear-go/pkg/config"
+	"github.com/House-lovers7/speadwear-go/pkg/utils"
 )
 
 // SetupRouter configures and returns the main router
-func SetupRouter(usecases *usecase.Container, repos *repository.Container, cfg *config.Config) *gin.Engine {
+func SetupRouter(usecases *usecase.Container, repos *repository.Container, keys *utils.KeySet, cfg *config.Config) *gin.Engine {
 	// Set Gin mode based on environment
 	if cfg.App.Env == "production" {
 		gin.SetMode(gin.ReleaseMode)
@@ -21,6 +23,7 @@ func SetupRouter(usecases *usecase.Container, repos *repository.Container, cfg *
 	// Global middleware
 	r.Use(gin.Recovery())
 	r.Use(gin.Logger())
+	r.Use(middleware.ClientInfo())
 
 	// CORS middleware (add this in production)
 	// r.Use(middleware.CORS())
@@ -29,12 +32,19 @@ func SetupRouter(usecases *usecase.Container, repos *repository.Container, cfg *
 	authHandler := handler.NewAuthHandler(cfg, usecases.User)
 	userHandler := handler.NewUserHandler(usecases.User)
 	itemHandler := handler.NewItemHandler(usecases.Item)
+	categoryHandler := handler.NewCategoryHandler(usecases.Category)
 	coordinateHandler := handler.NewCoordinateHandler(
 		usecases.Coordinate,
 		repos.Comment,
 		repos.LikeCoordinate,
 	)
+	wearLogHandler := handler.NewWearLogHandler(usecases.WearLog)
+	declutterHandler := handler.NewDeclutterHandler(usecases.Declutter)
 	socialHandler := handler.NewSocialHandler(usecases.Social)
+	adminHandler := handler.NewAdminHandler(usecases.Admin)
+	accessTokenHandler := handler.NewAccessTokenHandler(usecases.AccessToken)
+	exportHandler := handler.NewExportHandler(usecases.Export)
+	keysHandler := handler.NewKeysHandler(keys)
 
 	// Static files for uploaded images
 	r.Static("/uploads", "./uploads")
@@ -47,6 +57,9 @@ func SetupRouter(usecases *usecase.Container, repos *repository.Container, cfg *
 		})
 	})
 
+	// Public keys for verifying access tokens in other services
+	r.GET("/.well-known/jwks.json", keysHandler.JWKS)
+
 	// API v1 routes
 	v1 := r.Group("/api/v1")
 	{
@@ -55,7 +68,16 @@ func SetupRouter(usecases *usecase.Container, repos *repository.Container, cfg *
 		{
 			// Authentication
 			public.POST("/auth/login", authHandler.Login)
+			public.POST("/auth/login/2fa", authHandler.LoginTwoFactor)
 			public.POST("/auth/signup", authHandler.Signup)
+			public.POST("/auth/refresh", authHandler.RefreshToken)
+			public.POST("/auth/magic-link", authHandler.RequestMagicLink)
+			public.POST("/auth/magic-link/verify", authHandler.MagicLinkLogin)
+			
+			// OpenID Connect login
+			public.GET("/auth/oidc/:provider", authHandler.BeginOIDCLogin)
+			public.POST("/auth/oidc/:provider/callback", authHandler.OIDCCallback)
+			public.POST("/auth/oidc/link/confirm", authHandler.ConfirmIdentityLink)
 			
 			// Password reset
 			public.POST("/users/password/reset", userHandler.ResetPasswordRequest)
@@ -65,6 +87,16 @@ func SetupRouter(usecases *usecase.Container, repos *repository.Container, cfg *
 			public.POST("/users/activate", userHandler.ActivateAccount)
 			public.POST("/users/activate/resend", userHandler.ResendActivationEmail)
 			
+			// Email change confirmation (new address) and cancellation (old address)
+			public.POST("/users/email/confirm", userHandler.ConfirmEmailChange)
+			public.POST("/users/email/cancel", userHandler.CancelEmailChange)
+			
+			// Login lockout
+			public.POST("/users/unlock", userHandler.UnlockAccount)
+			
+			// Account data export download (the link token authorizes it)
+			public.GET("/exports/download", exportHandler.DownloadExport)
+			
 			// Public user profiles
 			public.GET("/users/:id", userHandler.GetUser)
 			public.GET("/users/:user_id/items", itemHandler.GetUserItems)
@@ -80,79 +112,160 @@ func SetupRouter(usecases *usecase.Container, repos *repository.Container, cfg *
 			public.GET("/coordinates/search", coordinateHandler.SearchCoordinates)
 		}
 
-		// Protected routes (authentication required)
+		// Protected routes (authentication required). Personal access tokens
+		// are accepted where a scope is declared; account management needs a
+		// login session.
 		protected := v1.Group("")
-		protected.Use(middleware.AuthRequired(cfg))
+		protected.Use(middleware.AuthRequired(keys, repos))
+
+		// Account management (login sessions only)
+		account := protected.Group("", middleware.SessionRequired())
 		{
 			// Authentication
-			protected.POST("/auth/logout", authHandler.Logout)
-			protected.POST("/auth/refresh", authHandler.RefreshToken)
-			protected.GET("/auth/me", authHandler.Me)
+			account.POST("/auth/logout", authHandler.Logout)
+
+			// Sessions, two-factor and linked identities
+			account.GET("/users/me/sessions", userHandler.ListSessions)
+			account.DELETE("/users/me/sessions", userHandler.RevokeOtherSessions)
+			account.DELETE("/users/me/sessions/:id", userHandler.RevokeSession)
+			account.POST("/users/me/2fa", userHandler.EnrollTwoFactor)
+			account.POST("/users/me/2fa/verify", userHandler.VerifyTwoFactor)
+			account.DELETE("/users/me/2fa", userHandler.DisableTwoFactor)
+			account.GET("/users/me/identities", userHandler.ListIdentities)
+			account.POST("/users/me/identities/:provider", userHandler.BeginIdentityLink)
+			account.POST("/users/me/identities/:provider/callback", userHandler.LinkIdentity)
+			account.DELETE("/users/me/identities/:id", userHandler.UnlinkIdentity)
+
+			// Personal access tokens
+			account.GET("/users/me/tokens", accessTokenHandler.ListAccessTokens)
+			account.POST("/users/me/tokens", accessTokenHandler.CreateAccessToken)
+			account.DELETE("/users/me/tokens/:id", accessTokenHandler.RevokeAccessToken)
+
+			// Account data export
+			account.POST("/users/me/export", exportHandler.RequestExport)
+			account.GET("/users/me/export", exportHandler.GetExport)
 
 			// User management
-			protected.GET("/users", userHandler.ListUsers)
-			protected.GET("/users/me", userHandler.GetMe)
-			protected.PUT("/users/profile", userHandler.UpdateProfile)
-			protected.PUT("/users/password", userHandler.ChangePassword)
-			protected.PUT("/users/:id", userHandler.UpdateUser)
-			protected.DELETE("/users/:id", userHandler.DeleteUser)
-
-			// Item management
-			protected.POST("/items", itemHandler.CreateItem)
-			protected.GET("/items", itemHandler.GetMyItems)
-			protected.PUT("/items/:id", itemHandler.UpdateItem)
-			protected.DELETE("/items/:id", itemHandler.DeleteItem)
-			protected.DELETE("/items", itemHandler.DeleteItems) // Batch delete
-			protected.GET("/items/statistics", itemHandler.GetItemStatistics)
-
-			// Coordinate management
-			protected.POST("/coordinates", coordinateHandler.CreateCoordinate)
-			protected.GET("/coordinates", coordinateHandler.GetMyCoordinates)
-			protected.GET("/coordinates/timeline", coordinateHandler.GetTimeline)
-			protected.PUT("/coordinates/:id", coordinateHandler.UpdateCoordinate)
-			protected.DELETE("/coordinates/:id", coordinateHandler.DeleteCoordinate)
-			protected.GET("/coordinates/statistics", coordinateHandler.GetCoordinateStatistics)
-
-			// Like functionality
-			protected.POST("/coordinates/:id/like", coordinateHandler.LikeCoordinate)
-			protected.DELETE("/coordinates/:id/like", coordinateHandler.UnlikeCoordinate)
-
-			// Comment functionality
-			protected.POST("/comments", socialHandler.CreateComment)
-			protected.PUT("/comments/:id", socialHandler.UpdateComment)
-			protected.DELETE("/comments/:id", socialHandler.DeleteComment)
-
-			// Follow functionality
-			protected.POST("/follow/:user_id", socialHandler.FollowUser)
-			protected.DELETE("/follow/:user_id", socialHandler.UnfollowUser)
-			protected.GET("/follow/followers", socialHandler.GetFollowers)
-			protected.GET("/follow/following", socialHandler.GetFollowing)
-			protected.GET("/follow/status/:user_id", socialHandler.CheckFollowStatus)
-
-			// Block functionality
-			protected.POST("/blocks/:user_id", socialHandler.BlockUser)
-			protected.DELETE("/blocks/:user_id", socialHandler.UnblockUser)
-			protected.GET("/blocks", socialHandler.GetBlockedUsers)
-			protected.GET("/blocks/status/:user_id", socialHandler.CheckBlockStatus)
-
-			// Notification functionality
-			protected.GET("/notifications", socialHandler.GetNotifications)
-			protected.GET("/notifications/unread", socialHandler.GetUnreadNotifications)
-			protected.GET("/notifications/unread/count", socialHandler.GetUnreadCount)
-			protected.PUT("/notifications/:id/read", socialHandler.MarkAsRead)
-			protected.PUT("/notifications/read_all", socialHandler.MarkAllAsRead)
+			account.PUT("/users/profile", userHandler.UpdateProfile)
+			account.PUT("/users/password", userHandler.ChangePassword)
+			account.PUT("/users/:id", userHandler.UpdateUser)
+			account.DELETE("/users/:id", userHandler.DeleteUser)
+		}
+
+		// Profile
+		profileRead := protected.Group("", middleware.RequireScope(domain.ScopeProfileRead))
+		{
+			profileRead.GET("/auth/me", authHandler.Me)
+			profileRead.GET("/users", userHandler.ListUsers)
+			profileRead.GET("/users/me", userHandler.GetMe)
+		}
+
+		// Item management
+		itemsRead := protected.Group("", middleware.RequireScope(domain.ScopeItemsRead))
+		{
+			itemsRead.GET("/items", itemHandler.GetMyItems)
+			itemsRead.GET("/items/statistics", itemHandler.GetItemStatistics)
+			itemsRead.GET("/items/analytics", itemHandler.GetWardrobeAnalytics)
+			itemsRead.GET("/items/forgotten", declutterHandler.GetForgottenItems)
+			itemsRead.GET("/categories", categoryHandler.GetCategories)
+		}
+		itemsWrite := protected.Group("", middleware.RequireScope(domain.ScopeItemsWrite))
+		{
+			itemsWrite.POST("/items", itemHandler.CreateItem)
+			itemsWrite.PUT("/items/:id", itemHandler.UpdateItem)
+			itemsWrite.PUT("/items/:id/status", itemHandler.ChangeItemStatus)
+			itemsWrite.DELETE("/items/:id", itemHandler.DeleteItem)
+			itemsWrite.DELETE("/items", itemHandler.DeleteItems) // Batch delete
+			itemsWrite.PUT("/items/forgotten/digest", declutterHandler.UpdateDigest)
+			itemsWrite.POST("/categories", categoryHandler.CreateCategory)
+			itemsWrite.PUT("/categories/order", categoryHandler.ReorderCategories)
+			itemsWrite.PUT("/categories/:id", categoryHandler.RenameCategory)
+			itemsWrite.POST("/categories/:id/merge", categoryHandler.MergeCategory)
+		}
+
+		// Coordinate management
+		coordinatesRead := protected.Group("", middleware.RequireScope(domain.ScopeCoordinatesRead))
+		{
+			coordinatesRead.GET("/coordinates", coordinateHandler.GetMyCoordinates)
+			coordinatesRead.GET("/coordinates/timeline", coordinateHandler.GetTimeline)
+			coordinatesRead.GET("/coordinates/statistics", coordinateHandler.GetCoordinateStatistics)
+		}
+		coordinatesWrite := protected.Group("", middleware.RequireScope(domain.ScopeCoordinatesWrite))
+		{
+			coordinatesWrite.POST("/coordinates", coordinateHandler.CreateCoordinate)
+			coordinatesWrite.PUT("/coordinates/:id", coordinateHandler.UpdateCoordinate)
+			coordinatesWrite.DELETE("/coordinates/:id", coordinateHandler.DeleteCoordinate)
+		}
+
+		// Wear diary
+		wearLogsRead := protected.Group("", middleware.RequireScope(domain.ScopeWearLogsRead))
+		{
+			wearLogsRead.GET("/wear-logs", wearLogHandler.ListWearLogs)
+			wearLogsRead.GET("/wear-logs/:id", wearLogHandler.GetWearLog)
+		}
+		wearLogsWrite := protected.Group("", middleware.RequireScope(domain.ScopeWearLogsWrite))
+		{
+			wearLogsWrite.POST("/wear-logs", wearLogHandler.CreateWearLog)
+			wearLogsWrite.PUT("/wear-logs/:id", wearLogHandler.UpdateWearLog)
+			wearLogsWrite.DELETE("/wear-logs/:id", wearLogHandler.DeleteWearLog)
+		}
+
+		// Likes, comments, follows and blocks
+		socialRead := protected.Group("", middleware.RequireScope(domain.ScopeSocialRead))
+		{
+			socialRead.GET("/follow/followers", socialHandler.GetFollowers)
+			socialRead.GET("/follow/following", socialHandler.GetFollowing)
+			socialRead.GET("/follow/status/:user_id", socialHandler.CheckFollowStatus)
+			socialRead.GET("/blocks", socialHandler.GetBlockedUsers)
+			socialRead.GET("/blocks/status/:user_id", socialHandler.CheckBlockStatus)
+		}
+		socialWrite := protected.Group("", middleware.RequireScope(domain.ScopeSocialWrite))
+		{
+			socialWrite.POST("/coordinates/:id/like", coordinateHandler.LikeCoordinate)
+			socialWrite.DELETE("/coordinates/:id/like", coordinateHandler.UnlikeCoordinate)
+			socialWrite.POST("/comments", socialHandler.CreateComment)
+			socialWrite.PUT("/comments/:id", socialHandler.UpdateComment)
+			socialWrite.DELETE("/comments/:id", socialHandler.DeleteComment)
+			socialWrite.POST("/follow/:user_id", socialHandler.FollowUser)
+			socialWrite.DELETE("/follow/:user_id", socialHandler.UnfollowUser)
+			socialWrite.POST("/blocks/:user_id", socialHandler.BlockUser)
+			socialWrite.DELETE("/blocks/:user_id", socialHandler.UnblockUser)
+		}
+
+		// Notifications
+		notificationsRead := protected.Group("", middleware.RequireScope(domain.ScopeNotificationsRead))
+		{
+			notificationsRead.GET("/notifications", socialHandler.GetNotifications)
+			notificationsRead.GET("/notifications/unread", socialHandler.GetUnreadNotifications)
+			notificationsRead.GET("/notifications/unread/count", socialHandler.GetUnreadCount)
+		}
+		notificationsWrite := protected.Group("", middleware.RequireScope(domain.ScopeNotificationsWrite))
+		{
+			notificationsWrite.PUT("/notifications/:id/read", socialHandler.MarkAsRead)
+			notificationsWrite.PUT("/notifications/read_all", socialHandler.MarkAllAsRead)
 		}
 
 		// Admin routes
 		admin := v1.Group("/admin")
-		admin.Use(middleware.AuthRequired(cfg))
-		admin.Use(middleware.AdminRequired())
+		admin.Use(middleware.AuthRequired(keys, repos))
+		admin.Use(middleware.SessionRequired())
+		admin.Use(middleware.RequirePermission(domain.PermissionAdminAccess))
 		{
-			// Add admin-specific routes here
-			// admin.GET("/users", adminHandler.ListAllUsers)
-			// admin.DELETE("/users/:id", adminHandler.DeleteUser)
-			// admin.DELETE("/items/:id", adminHandler.DeleteItem)
-			// admin.DELETE("/coordinates/:id", adminHandler.DeleteCoordinate)
+			// User management
+			admin.GET("/users", middleware.RequirePermission(domain.PermissionUsersRead), adminHandler.ListAllUsers)
+			admin.GET("/users/:id/content", middleware.RequirePermission(domain.PermissionUsersRead), adminHandler.GetUserContent)
+			admin.POST("/users/:id/suspend", middleware.RequirePermission(domain.PermissionUsersManage), adminHandler.SuspendUser)
+			admin.DELETE("/users/:id/suspend", middleware.RequirePermission(domain.PermissionUsersManage), adminHandler.UnsuspendUser)
+			admin.PUT("/users/:id/role", middleware.RequirePermission(domain.PermissionRolesManage), adminHandler.SetUserRole)
+			admin.POST("/users/:id/impersonate", middleware.RequirePermission(domain.PermissionUsersImpersonate), adminHandler.ImpersonateUser)
+			admin.DELETE("/users/:id", middleware.RequirePermission(domain.PermissionUsersManage), adminHandler.DeleteUser)
+
+			// Content moderation
+			admin.DELETE("/items/:id", middleware.RequirePermission(domain.PermissionContentModerate), adminHandler.DeleteItem)
+			admin.DELETE("/coordinates/:id", middleware.RequirePermission(domain.PermissionContentModerate), adminHandler.DeleteCoordinate)
+
+			// Audit trail
+			admin.GET("/audit-events", middleware.RequirePermission(domain.PermissionAuditRead), adminHandler.ListAuditEvents)
 		}
 	}
 
@@ -165,8 +278,8 @@ func SetupRouter(usecases *usecase.Container, repos *repository.Container, cfg *
 }
 
 // SetupDevRouter sets up router with additional development routes
-func SetupDevRouter(usecases *usecase.Container, repos *repository.Container, cfg *config.Config) *gin.Engine {
-	r := SetupRouter(usecases, repos, cfg)
+func SetupDevRouter(usecases *usecase.Container, repos *repository.Container, keys *utils.KeySet, cfg *config.Config) *gin.Engine {
+	r := SetupRouter(usecases, repos, keys, cfg)
 
 	// Development-only routes
 	dev := r.Group("/dev")
@@ -33,13 +33,28 @@ func TestDB(t *testing.T) *gorm.DB {
 	// Migrate test database
 	err = db.AutoMigrate(
 		&domain.User{},
+		&domain.Category{},
 		&domain.Item{},
+		&domain.ItemStatusChange{},
 		&domain.Coordinate{},
+		&domain.CoordinateItem{},
+		&domain.WearLog{},
+		&domain.WearLogItem{},
 		&domain.Comment{},
 		&domain.LikeCoordinate{},
 		&domain.Relationship{},
 		&domain.Block{},
 		&domain.Notification{},
+		&domain.Session{},
+		&domain.RefreshToken{},
+		&domain.AuditEvent{},
+		&domain.LoginAttempt{},
+		&domain.RecoveryCode{},
+		&domain.UserIdentity{},
+		&domain.OAuthState{},
+		&domain.PersonalAccessToken{},
+		&domain.DataExport{},
+		&domain.PasswordHistory{},
 	)
 	if err != nil {
 		t.Fatalf("failed to migrate test database: %v", err)
@@ -59,13 +74,28 @@ func CleanupDB(t *testing.T, db *gorm.DB) {
 
 	// Delete all data in reverse order of dependencies
 	tables := []interface{}{
+		&domain.PasswordHistory{},
+		&domain.DataExport{},
+		&domain.PersonalAccessToken{},
+		&domain.OAuthState{},
+		&domain.UserIdentity{},
+		&domain.RecoveryCode{},
+		&domain.LoginAttempt{},
+		&domain.AuditEvent{},
+		&domain.RefreshToken{},
+		&domain.Session{},
 		&domain.Notification{},
 		&domain.Block{},
 		&domain.Relationship{},
 		&domain.LikeCoordinate{},
 		&domain.Comment{},
+		&domain.WearLogItem{},
+		&domain.WearLog{},
+		&domain.CoordinateItem{},
 		&domain.Coordinate{},
+		&domain.ItemStatusChange{},
 		&domain.Item{},
+		&domain.Category{},
 		&domain.User{},
 	}
 
@@ -81,13 +111,28 @@ func TruncateTables(t *testing.T, db *gorm.DB) {
 	t.Helper()
 
 	tables := []string{
+		"password_histories",
+		"data_exports",
+		"personal_access_tokens",
+		"oauth_states",
+		"user_identities",
+		"recovery_codes",
+		"login_attempts",
+		"audit_events",
+		"refresh_tokens",
+		"sessions",
 		"notifications",
 		"blocks",
 		"relationships",
 		"like_coordinates",
 		"comments",
+		"wear_log_items",
+		"wear_logs",
+		"coordinate_items",
 		"coordinates",
+		"item_status_changes",
 		"items",
+		"categories",
 		"users",
 	}
 
@@ -107,29 +107,45 @@ func (f *Fixtures) CreateCoordinate(userID uint, opts ...func(*domain.Coordinate
 	// Create required items and associate with coordinate
 	shoes := f.CreateItem(userID, func(i *domain.Item) {
 		i.SuperItem = "シューズ"
-		i.CoordinateID = &coordinate.ID
 	})
 	bottoms := f.CreateItem(userID, func(i *domain.Item) {
 		i.SuperItem = "ボトムス"
-		i.CoordinateID = &coordinate.ID
 	})
 	tops := f.CreateItem(userID, func(i *domain.Item) {
 		i.SuperItem = "トップス"
-		i.CoordinateID = &coordinate.ID
 	})
-
-	// Preload items for return value
-	coordinate.Items = []domain.Item{*shoes, *bottoms, *tops}
+	f.LinkItems(coordinate, []*domain.Item{shoes, bottoms, tops}, domain.CoordinateItemRoleShoes, domain.CoordinateItemRoleBottom, domain.CoordinateItemRoleTop)
 
 	return coordinate
 }
 
+// LinkItems appends items to a coordinate, with optional roles in the same order
+func (f *Fixtures) LinkItems(coordinate *domain.Coordinate, items []*domain.Item, roles ...string) {
+	f.t.Helper()
+
+	for i, item := range items {
+		link := domain.CoordinateItem{
+			CoordinateID: coordinate.ID,
+			ItemID:       item.ID,
+			Position:     len(coordinate.Items),
+			Item:         *item,
+		}
+		if i < len(roles) {
+			link.Role = roles[i]
+		}
+		if err := f.db.Omit("Item").Create(&link).Error; err != nil {
+			f.t.Fatalf("failed to link item to coordinate: %v", err)
+		}
+		coordinate.Items = append(coordinate.Items, link)
+	}
+}
+
 // CreateComment creates a test comment
 func (f *Fixtures) CreateComment(userID, coordinateID uint, opts ...func(*domain.Comment)) *domain.Comment {
 	f.t.Helper()
 
 	comment := &domain.Comment{
-		UserID:       userID,
+		UserID:       &userID,
 		CoordinateID: coordinateID,
 		Comment:      "Test comment",
 	}
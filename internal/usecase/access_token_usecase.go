@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+)
+
+// AccessTokenUsecase defines personal access token business logic
+type AccessTokenUsecase interface {
+	// CreateAccessToken issues a token; the plaintext is only returned here
+	CreateAccessToken(ctx context.Context, userID uint, name string, scopes []string, expiresAt *time.Time) (string, *domain.PersonalAccessToken, error)
+	ListAccessTokens(ctx context.Context, userID uint) ([]*domain.PersonalAccessToken, error)
+	RevokeAccessToken(ctx context.Context, userID, tokenID uint) error
+}
@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"context"
+	"time"
+)
+
+// AccountPurgeUsecase removes accounts whose deletion grace period has ended
+type AccountPurgeUsecase interface {
+	// PurgeDeletedAccounts permanently deletes every account due at now and
+	// returns how many were purged
+	PurgeDeletedAccounts(ctx context.Context, now time.Time) (int, error)
+}
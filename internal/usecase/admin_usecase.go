@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/dto"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+)
+
+// UserContent holds everything a user has posted
+type UserContent struct {
+	User        *domain.User
+	Items       []*domain.Item
+	Coordinates []*domain.Coordinate
+}
+
+// AdminUsecase defines administrative business logic
+type AdminUsecase interface {
+	// User management
+	SearchUsers(ctx context.Context, query string, limit, offset int) ([]*domain.User, int64, error)
+	SuspendUser(ctx context.Context, actorID, userID uint, until *time.Time, reason string) error
+	UnsuspendUser(ctx context.Context, actorID, userID uint) error
+	DeleteUser(ctx context.Context, actorID, userID uint) error
+	SetUserRole(ctx context.Context, actorID, userID uint, role string) error
+	// ImpersonateUser issues a read-only token for acting as the user; sessionID is the actor's
+	ImpersonateUser(ctx context.Context, actorID, sessionID, userID uint, reason string) (*dto.ImpersonationResponse, error)
+	
+	// Content moderation
+	GetUserContent(ctx context.Context, actorID, userID uint, limit, offset int) (*UserContent, error)
+	DeleteItem(ctx context.Context, actorID, itemID uint) error
+	DeleteCoordinate(ctx context.Context, actorID, coordinateID uint) error
+
+	// Audit trail
+	ListAuditEvents(ctx context.Context, filter repository.AuditEventFilter) ([]*domain.AuditEvent, int64, error)
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+)
+
+// FieldChange is the value of a field before and after an update
+type FieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// Changes maps field names to how an update changed them
+type Changes map[string]FieldChange
+
+// Track records a field if its value changed
+func (c Changes) Track(field string, from, to interface{}) {
+	if from != to {
+		c[field] = FieldChange{From: from, To: to}
+	}
+}
+
+// AuditLogger records actions to the audit trail
+type AuditLogger interface {
+	Record(ctx context.Context, actorID uint, action, targetType string, targetID uint, metadata map[string]interface{}) error
+	// RecordChanges records an update together with the fields it changed;
+	// nothing is recorded if no field changed
+	RecordChanges(ctx context.Context, actorID uint, action, targetType string, targetID uint, changes Changes) error
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+)
+
+// CategoryNode is a category with its sub-categories in order
+type CategoryNode struct {
+	Category *domain.Category
+	Children []*CategoryNode
+}
+
+// CategoryUsecase defines the business logic of a user's item category tree.
+// A user starts with domain.SuperItemCategories as top-level categories,
+// created the first time their categories are used.
+type CategoryUsecase interface {
+	// GetCategoryTree returns the user's top-level categories with their sub-categories
+	GetCategoryTree(ctx context.Context, userID uint) ([]*CategoryNode, error)
+	// CreateCategory adds a category after its siblings; a nil ParentID makes it top-level
+	CreateCategory(ctx context.Context, userID uint, category *domain.Category) error
+	RenameCategory(ctx context.Context, userID uint, categoryID uint, name string) error
+	// ReorderCategories orders the children of parentID (top-level when nil) as categoryIDs
+	ReorderCategories(ctx context.Context, userID uint, parentID *uint, categoryIDs []uint) error
+	// MergeCategories moves the items and sub-categories of sourceID into targetID and
+	// deletes sourceID; same-named sub-categories are merged too
+	MergeCategories(ctx context.Context, userID uint, sourceID, targetID uint) error
+}
@@ -2,8 +2,14 @@ package usecase
 
 // Container holds all usecases
 type Container struct {
-	User       UserUsecase
-	Item       ItemUsecase
-	Coordinate CoordinateUsecase
-	Social     SocialUsecase
+	User        UserUsecase
+	Item        ItemUsecase
+	Category    CategoryUsecase
+	Coordinate  CoordinateUsecase
+	WearLog     WearLogUsecase
+	Declutter   DeclutterUsecase
+	Social      SocialUsecase
+	Admin       AdminUsecase
+	AccessToken AccessTokenUsecase
+	Export      ExportUsecase
 }
\ No newline at end of file
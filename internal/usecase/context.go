@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"context"
+	
+	"github.com/House-lovers7/speadwear-go/internal/dto"
+)
+
+type clientInfoKey struct{}
+
+// WithClientInfo returns a context carrying the client information of the current request
+func WithClientInfo(ctx context.Context, client dto.ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, client)
+}
+
+// ClientInfoFromContext returns the client information stored by WithClientInfo
+func ClientInfoFromContext(ctx context.Context) dto.ClientInfo {
+	client, _ := ctx.Value(clientInfoKey{}).(dto.ClientInfo)
+	return client
+}
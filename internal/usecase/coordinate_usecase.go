@@ -10,11 +10,11 @@ import (
 
 // CoordinateUsecase defines coordinate-related business logic
 type CoordinateUsecase interface {
-	// CRUD operations
-	CreateCoordinate(ctx context.Context, userID uint, coordinate *domain.Coordinate, itemIDs []uint, image *multipart.FileHeader) error
+	// CRUD operations; items are linked in the given order
+	CreateCoordinate(ctx context.Context, userID uint, coordinate *domain.Coordinate, items []domain.CoordinateItem, image *multipart.FileHeader) error
 	GetCoordinate(ctx context.Context, coordinateID uint) (*domain.Coordinate, error)
 	GetCoordinateWithDetails(ctx context.Context, coordinateID uint) (*domain.Coordinate, error)
-	UpdateCoordinate(ctx context.Context, userID uint, coordinateID uint, updates map[string]interface{}, itemIDs []uint, image *multipart.FileHeader) error
+	UpdateCoordinate(ctx context.Context, userID uint, coordinateID uint, updates map[string]interface{}, items []domain.CoordinateItem, image *multipart.FileHeader) error
 	DeleteCoordinate(ctx context.Context, userID uint, coordinateID uint) error
 	
 	// Listing and searching
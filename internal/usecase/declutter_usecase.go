@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+)
+
+// ForgottenItem is an item left unused, scored as a declutter candidate
+type ForgottenItem struct {
+	Item           *domain.Item
+	LastUsedAt     time.Time // last wear or coordinate, or when the item was added
+	UnusedDays     int
+	DeclutterScore int // 0-100, higher is a stronger candidate
+}
+
+// ForgottenItemGroup holds the forgotten items of one SuperItem
+type ForgottenItemGroup struct {
+	SuperItem string
+	Items     []ForgottenItem
+}
+
+// DeclutterUsecase finds items a user no longer uses
+type DeclutterUsecase interface {
+	// GetForgottenItems lists available items neither worn nor added to a
+	// coordinate in the last days days, grouped by SuperItem with the strongest
+	// candidates first
+	GetForgottenItems(ctx context.Context, userID uint, days int) ([]ForgottenItemGroup, error)
+	// SetDigest enables the weekly forgotten items notification for the given
+	// number of days; 0 disables it
+	SetDigest(ctx context.Context, userID uint, days int) error
+	// SendDigests notifies every user due a weekly reminder who has forgotten
+	// items and returns how many were notified
+	SendDigests(ctx context.Context, now time.Time) (int, error)
+}
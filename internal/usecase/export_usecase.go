@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+)
+
+// ExportUsecase defines account data export business logic
+type ExportUsecase interface {
+	// RequestExport queues a new archive of the user's data, built in the background
+	RequestExport(ctx context.Context, userID uint) (*domain.DataExport, error)
+	GetLatestExport(ctx context.Context, userID uint) (*domain.DataExport, error)
+	// OpenExport resolves a download link token to a finished archive
+	OpenExport(ctx context.Context, token string) (*domain.DataExport, error)
+}
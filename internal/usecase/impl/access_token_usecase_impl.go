@@ -0,0 +1,112 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+	"github.com/House-lovers7/speadwear-go/pkg/utils"
+)
+
+// Number of leading token characters kept in clear so users can recognise a token
+const accessTokenPrefixLength = 12
+
+type accessTokenUsecase struct {
+	tokenRepo repository.PersonalAccessTokenRepository
+}
+
+// NewAccessTokenUsecase creates a new personal access token usecase
+func NewAccessTokenUsecase(tokenRepo repository.PersonalAccessTokenRepository) usecase.AccessTokenUsecase {
+	return &accessTokenUsecase{
+		tokenRepo: tokenRepo,
+	}
+}
+
+// CreateAccessToken issues a new personal access token
+func (u *accessTokenUsecase) CreateAccessToken(ctx context.Context, userID uint, name string, scopes []string, expiresAt *time.Time) (string, *domain.PersonalAccessToken, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", nil, errors.New("name is required")
+	}
+
+	granted, err := normalizeScopes(scopes)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return "", nil, errors.New("expiry must be in the future")
+	}
+
+	secret, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return "", nil, err
+	}
+	plaintext := domain.AccessTokenPrefix + secret
+
+	token := &domain.PersonalAccessToken{
+		UserID:      userID,
+		Name:        name,
+		TokenDigest: utils.HashToken(plaintext),
+		TokenPrefix: plaintext[:accessTokenPrefixLength],
+		Scopes:      strings.Join(granted, " "),
+		ExpiresAt:   expiresAt,
+	}
+	if err := u.tokenRepo.Create(ctx, token); err != nil {
+		return "", nil, err
+	}
+
+	return plaintext, token, nil
+}
+
+// ListAccessTokens lists the user's tokens that have not been revoked
+func (u *accessTokenUsecase) ListAccessTokens(ctx context.Context, userID uint) ([]*domain.PersonalAccessToken, error) {
+	return u.tokenRepo.FindByUserID(ctx, userID)
+}
+
+// RevokeAccessToken revokes one of the user's tokens
+func (u *accessTokenUsecase) RevokeAccessToken(ctx context.Context, userID, tokenID uint) error {
+	token, err := u.tokenRepo.FindByID(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+	if token == nil || token.UserID != userID || token.RevokedAt != nil {
+		return errors.New("token not found")
+	}
+
+	return u.tokenRepo.Revoke(ctx, tokenID)
+}
+
+// normalizeScopes validates requested scopes and removes duplicates
+func normalizeScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, errors.New("invalid scope")
+	}
+
+	seen := make(map[string]bool, len(scopes))
+	granted := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !isAccessTokenScope(scope) {
+			return nil, errors.New("invalid scope")
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			granted = append(granted, scope)
+		}
+	}
+	return granted, nil
+}
+
+// isAccessTokenScope reports whether scope is a known token scope
+func isAccessTokenScope(scope string) bool {
+	for _, s := range domain.AccessTokenScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+	"github.com/House-lovers7/speadwear-go/pkg/storage"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type accountPurgeUsecase struct {
+	userRepo repository.UserRepository
+	storage  storage.Storage
+	db       *gorm.DB
+}
+
+// NewAccountPurgeUsecase creates a new account purge usecase
+func NewAccountPurgeUsecase(userRepo repository.UserRepository, storage storage.Storage, db *gorm.DB) usecase.AccountPurgeUsecase {
+	return &accountPurgeUsecase{
+		userRepo: userRepo,
+		storage:  storage,
+		db:       db,
+	}
+}
+
+// accountPurge summarises what was removed with an account
+type accountPurge struct {
+	Items       int
+	Coordinates int
+}
+
+// PurgeDeletedAccounts purges the accounts whose grace period has ended
+func (u *accountPurgeUsecase) PurgeDeletedAccounts(ctx context.Context, now time.Time) (int, error) {
+	users, err := u.userRepo.FindDueForDeletion(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, user := range users {
+		result, err := purgeAccount(ctx, u.db, u.storage, user.ID, &now)
+		if err != nil {
+			return purged, err
+		}
+		if result != nil {
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// purgeAccount permanently deletes a user and everything they own in one
+// transaction. Comments on other people's coordinates are kept without an
+// author. When dueBy is set the account is only purged if its
+// deletion is still scheduled at or before dueBy, so a login that cancelled
+// the deletion wins; a nil result means the account was skipped.
+func purgeAccount(ctx context.Context, db *gorm.DB, storage storage.Storage, userID uint, dueBy *time.Time) (*accountPurge, error) {
+	var result *accountPurge
+	var pictures, exportFiles []string
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the user row so a login can't cancel the deletion halfway through
+		userQuery := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", userID)
+		if dueBy != nil {
+			userQuery = userQuery.Where("deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= ?", *dueBy)
+		}
+		var user domain.User
+		if err := userQuery.First(&user).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		pictures = append(pictures, user.Picture)
+
+		var items []*domain.Item
+		if err := tx.Unscoped().Where("user_id = ?", userID).Find(&items).Error; err != nil {
+			return err
+		}
+		var coordinates []*domain.Coordinate
+		if err := tx.Unscoped().Where("user_id = ?", userID).Find(&coordinates).Error; err != nil {
+			return err
+		}
+		// The zero ID keeps NOT IN from matching nothing when there are no coordinates
+		coordinateIDs := []uint{0}
+		itemIDs := []uint{0}
+		for _, item := range items {
+			pictures = append(pictures, item.Picture)
+			itemIDs = append(itemIDs, item.ID)
+		}
+		for _, coordinate := range coordinates {
+			pictures = append(pictures, coordinate.Picture)
+			coordinateIDs = append(coordinateIDs, coordinate.ID)
+		}
+
+		var sessionIDs []uint
+		if err := tx.Unscoped().Model(&domain.Session{}).Where("user_id = ?", userID).Pluck("id", &sessionIDs).Error; err != nil {
+			return err
+		}
+
+		var wearLogIDs []uint
+		if err := tx.Unscoped().Model(&domain.WearLog{}).Where("user_id = ?", userID).Pluck("id", &wearLogIDs).Error; err != nil {
+			return err
+		}
+
+		var exports []*domain.DataExport
+		if err := tx.Where("user_id = ?", userID).Find(&exports).Error; err != nil {
+			return err
+		}
+		for _, export := range exports {
+			exportFiles = append(exportFiles, export.FilePath)
+		}
+
+		// Comments on other people's coordinates stay, without their author
+		if err := tx.Unscoped().Model(&domain.Comment{}).
+			Where("user_id = ? AND coordinate_id NOT IN ?", userID, coordinateIDs).
+			Update("user_id", nil).Error; err != nil {
+			return err
+		}
+
+		deletes := []struct {
+			model interface{}
+			query string
+			args  []interface{}
+		}{
+			{&domain.Notification{}, "sender_id = ? OR receiver_id = ? OR coordinate_id IN ?", []interface{}{userID, userID, coordinateIDs}},
+			{&domain.Comment{}, "coordinate_id IN ?", []interface{}{coordinateIDs}},
+			{&domain.LikeCoordinate{}, "user_id = ? OR coordinate_id IN ?", []interface{}{userID, coordinateIDs}},
+			{&domain.Relationship{}, "follower_id = ? OR followed_id = ?", []interface{}{userID, userID}},
+			{&domain.Block{}, "blocker_id = ? OR blocked_id = ?", []interface{}{userID, userID}},
+			{&domain.CoordinateItem{}, "coordinate_id IN ?", []interface{}{coordinateIDs}},
+			{&domain.WearLogItem{}, "wear_log_id IN ?", []interface{}{wearLogIDs}},
+			{&domain.WearLog{}, "user_id = ?", []interface{}{userID}},
+			{&domain.ItemStatusChange{}, "item_id IN ?", []interface{}{itemIDs}},
+			{&domain.Item{}, "user_id = ?", []interface{}{userID}},
+			{&domain.Category{}, "user_id = ?", []interface{}{userID}},
+			{&domain.Coordinate{}, "user_id = ?", []interface{}{userID}},
+			{&domain.RefreshToken{}, "session_id IN ?", []interface{}{sessionIDs}},
+			{&domain.Session{}, "user_id = ?", []interface{}{userID}},
+			{&domain.RecoveryCode{}, "user_id = ?", []interface{}{userID}},
+			{&domain.PasswordHistory{}, "user_id = ?", []interface{}{userID}},
+			{&domain.UserIdentity{}, "user_id = ?", []interface{}{userID}},
+			{&domain.OAuthState{}, "user_id = ?", []interface{}{userID}},
+			{&domain.PersonalAccessToken{}, "user_id = ?", []interface{}{userID}},
+			{&domain.DataExport{}, "user_id = ?", []interface{}{userID}},
+			// Last, as the rows above reference it
+			{&domain.User{}, "id = ?", []interface{}{userID}},
+		}
+		for _, d := range deletes {
+			if err := tx.Unscoped().Where(d.query, d.args...).Delete(d.model).Error; err != nil {
+				return err
+			}
+		}
+
+		result = &accountPurge{Items: len(items), Coordinates: len(coordinates)}
+		return nil
+	})
+	if err != nil || result == nil {
+		return nil, err
+	}
+
+	// Remove files once the records are gone
+	for _, picture := range pictures {
+		storage.Delete(picture)
+	}
+	for _, file := range exportFiles {
+		if file != "" {
+			os.Remove(file)
+		}
+	}
+
+	return result, nil
+}
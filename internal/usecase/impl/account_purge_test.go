@@ -0,0 +1,84 @@
+package impl
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/internal/testutil"
+	"github.com/House-lovers7/speadwear-go/pkg/storage"
+)
+
+func TestAccountPurgeUsecase_PurgeDeletedAccounts(t *testing.T) {
+	db := testutil.TestDB(t)
+	fixtures := testutil.NewFixtures(t, db)
+	ctx := context.Background()
+
+	uploads := t.TempDir()
+	purge := NewAccountPurgeUsecase(repository.NewUserRepository(db), storage.NewLocalStorage(uploads, 0), db)
+
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	leaving := fixtures.CreateUser(func(u *domain.User) { u.DeletionScheduledAt = &past })
+	waiting := fixtures.CreateUser(func(u *domain.User) { u.DeletionScheduledAt = &future })
+	other := fixtures.CreateUser()
+
+	item := fixtures.CreateItem(leaving.ID, func(i *domain.Item) { i.Picture = "items/leaving.jpg" })
+	os.MkdirAll(filepath.Join(uploads, "items"), 0755)
+	os.WriteFile(filepath.Join(uploads, item.Picture), []byte("image"), 0644)
+
+	ownCoordinate := fixtures.CreateCoordinate(leaving.ID)
+	otherCoordinate := fixtures.CreateCoordinate(other.ID)
+	commentOnOwn := fixtures.CreateComment(other.ID, ownCoordinate.ID)
+	commentOnOther := fixtures.CreateComment(leaving.ID, otherCoordinate.ID)
+	fixtures.CreateLike(leaving.ID, otherCoordinate.ID)
+	fixtures.CreateLike(other.ID, ownCoordinate.ID)
+	fixtures.CreateRelationship(leaving.ID, other.ID)
+	fixtures.CreateRelationship(other.ID, leaving.ID)
+	fixtures.CreateBlock(other.ID, leaving.ID)
+	fixtures.CreateNotification(leaving.ID, other.ID, domain.NotificationActionFollow)
+
+	purged, err := purge.PurgeDeletedAccounts(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("PurgeDeletedAccounts() error = %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("PurgeDeletedAccounts() = %d, want 1", purged)
+	}
+
+	count := func(model interface{}, query string, args ...interface{}) int64 {
+		var n int64
+		db.Unscoped().Model(model).Where(query, args...).Count(&n)
+		return n
+	}
+	if count(&domain.User{}, "id = ?", leaving.ID) != 0 {
+		t.Error("purged user row still exists")
+	}
+	if count(&domain.User{}, "id = ?", waiting.ID) != 1 {
+		t.Error("user still in the grace period was purged")
+	}
+	if count(&domain.Item{}, "user_id = ?", leaving.ID) != 0 || count(&domain.Coordinate{}, "user_id = ?", leaving.ID) != 0 {
+		t.Error("owned items and coordinates were not deleted")
+	}
+	if count(&domain.Comment{}, "id = ?", commentOnOwn.ID) != 0 {
+		t.Error("comments on the user's coordinates were not deleted")
+	}
+	if count(&domain.Comment{}, "id = ? AND user_id IS NULL", commentOnOther.ID) != 1 {
+		t.Error("comment on another user's coordinate was not anonymised")
+	}
+	if count(&domain.LikeCoordinate{}, "user_id = ? OR coordinate_id = ?", leaving.ID, ownCoordinate.ID) != 0 {
+		t.Error("likes were not deleted")
+	}
+	if count(&domain.Relationship{}, "follower_id = ? OR followed_id = ?", leaving.ID, leaving.ID) != 0 ||
+		count(&domain.Block{}, "blocked_id = ?", leaving.ID) != 0 ||
+		count(&domain.Notification{}, "sender_id = ?", leaving.ID) != 0 {
+		t.Error("follow, block or notification rows were not deleted")
+	}
+	if _, err := os.Stat(filepath.Join(uploads, item.Picture)); !os.IsNotExist(err) {
+		t.Error("item picture was not removed from disk")
+	}
+}
@@ -0,0 +1,265 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+	"github.com/House-lovers7/speadwear-go/pkg/config"
+	"github.com/House-lovers7/speadwear-go/pkg/storage"
+	"github.com/House-lovers7/speadwear-go/pkg/utils"
+	"gorm.io/gorm"
+)
+
+type adminUsecase struct {
+	userRepo       repository.UserRepository
+	itemRepo       repository.ItemRepository
+	coordinateRepo repository.CoordinateRepository
+	sessionRepo    repository.SessionRepository
+	auditEventRepo repository.AuditEventRepository
+	storage        storage.Storage
+	audit          usecase.AuditLogger
+	keys           *utils.KeySet
+	db             *gorm.DB
+	config         *config.Config
+}
+
+// NewAdminUsecase creates a new admin usecase
+func NewAdminUsecase(
+	userRepo repository.UserRepository,
+	itemRepo repository.ItemRepository,
+	coordinateRepo repository.CoordinateRepository,
+	sessionRepo repository.SessionRepository,
+	auditEventRepo repository.AuditEventRepository,
+	storage storage.Storage,
+	audit usecase.AuditLogger,
+	keys *utils.KeySet,
+	db *gorm.DB,
+	config *config.Config,
+) usecase.AdminUsecase {
+	return &adminUsecase{
+		userRepo:       userRepo,
+		itemRepo:       itemRepo,
+		coordinateRepo: coordinateRepo,
+		sessionRepo:    sessionRepo,
+		auditEventRepo: auditEventRepo,
+		storage:        storage,
+		audit:          audit,
+		keys:           keys,
+		db:             db,
+		config:         config,
+	}
+}
+
+// SearchUsers searches users by email or name
+func (u *adminUsecase) SearchUsers(ctx context.Context, query string, limit, offset int) ([]*domain.User, int64, error) {
+	return u.userRepo.Search(ctx, query, limit, offset)
+}
+
+// SuspendUser suspends an account until the given time (nil means indefinitely)
+func (u *adminUsecase) SuspendUser(ctx context.Context, actorID, userID uint, until *time.Time, reason string) error {
+	if actorID == userID {
+		return errors.New("cannot modify own account")
+	}
+
+	user, err := u.findUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if until != nil && !until.After(now) {
+		return errors.New("suspension end must be in the future")
+	}
+
+	user.SuspendedAt = &now
+	user.SuspendedUntil = until
+	user.SuspensionReason = reason
+	user.SuspendedBy = &actorID
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	// Kick the user out of all sessions
+	if err := u.sessionRepo.RevokeAllByUserID(ctx, userID, 0); err != nil {
+		return err
+	}
+
+	return u.audit.Record(ctx, actorID, domain.AuditActionUserSuspend, domain.AuditTargetUser, userID, map[string]interface{}{
+		"until":  until,
+		"reason": reason,
+	})
+}
+
+// UnsuspendUser lifts a suspension
+func (u *adminUsecase) UnsuspendUser(ctx context.Context, actorID, userID uint) error {
+	user, err := u.findUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.SuspendedAt == nil {
+		return errors.New("user is not suspended")
+	}
+
+	user.SuspendedAt = nil
+	user.SuspendedUntil = nil
+	user.SuspensionReason = ""
+	user.SuspendedBy = nil
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	return u.audit.Record(ctx, actorID, domain.AuditActionUserUnsuspend, domain.AuditTargetUser, userID, nil)
+}
+
+// DeleteUser force-deletes an account at once, skipping the grace period
+func (u *adminUsecase) DeleteUser(ctx context.Context, actorID, userID uint) error {
+	if actorID == userID {
+		return errors.New("cannot modify own account")
+	}
+
+	user, err := u.findUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	result, err := purgeAccount(ctx, u.db, u.storage, userID, nil)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return errors.New("user not found")
+	}
+
+	return u.audit.Record(ctx, actorID, domain.AuditActionUserDelete, domain.AuditTargetUser, userID, map[string]interface{}{
+		"email":       user.Email,
+		"items":       result.Items,
+		"coordinates": result.Coordinates,
+	})
+}
+
+// SetUserRole assigns a role to a user
+func (u *adminUsecase) SetUserRole(ctx context.Context, actorID, userID uint, role string) error {
+	if actorID == userID {
+		return errors.New("cannot modify own account")
+	}
+	if role != domain.RoleUser && role != domain.RoleSupport && role != domain.RoleModerator && role != domain.RoleAdmin {
+		return errors.New("invalid role")
+	}
+
+	user, err := u.findUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	previous := user.Role
+	user.Role = role
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	return u.audit.Record(ctx, actorID, domain.AuditActionUserRoleChange, domain.AuditTargetUser, userID, map[string]interface{}{
+		"from": previous,
+		"to":   role,
+	})
+}
+
+// GetUserContent returns a user's items and coordinates regardless of block state
+func (u *adminUsecase) GetUserContent(ctx context.Context, actorID, userID uint, limit, offset int) (*usecase.UserContent, error) {
+	user, err := u.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := u.itemRepo.FindByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	coordinates, err := u.coordinateRepo.FindByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.audit.Record(ctx, actorID, domain.AuditActionUserContentView, domain.AuditTargetUser, userID, nil); err != nil {
+		return nil, err
+	}
+
+	return &usecase.UserContent{
+		User:        user,
+		Items:       items,
+		Coordinates: coordinates,
+	}, nil
+}
+
+// DeleteItem takes down any item
+func (u *adminUsecase) DeleteItem(ctx context.Context, actorID, itemID uint) error {
+	item, err := u.itemRepo.FindByID(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		return errors.New("item not found")
+	}
+
+	if err := u.itemRepo.Delete(ctx, itemID); err != nil {
+		return err
+	}
+	u.storage.Delete(item.Picture)
+
+	return u.audit.Record(ctx, actorID, domain.AuditActionItemTakedown, domain.AuditTargetItem, itemID, map[string]interface{}{
+		"owner_id": item.UserID,
+	})
+}
+
+// DeleteCoordinate takes down any coordinate
+func (u *adminUsecase) DeleteCoordinate(ctx context.Context, actorID, coordinateID uint) error {
+	coordinate, err := u.coordinateRepo.FindByID(ctx, coordinateID)
+	if err != nil {
+		return err
+	}
+	if coordinate == nil {
+		return errors.New("coordinate not found")
+	}
+
+	err = u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Unlink items; they stay with their owner
+		if err := tx.Where("coordinate_id = ?", coordinateID).Delete(&domain.CoordinateItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&domain.WearLog{}).Where("coordinate_id = ?", coordinateID).Update("coordinate_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Coordinate{}, coordinateID).Error
+	})
+	if err != nil {
+		return err
+	}
+	u.storage.Delete(coordinate.Picture)
+
+	return u.audit.Record(ctx, actorID, domain.AuditActionCoordinateTakedown, domain.AuditTargetCoordinate, coordinateID, map[string]interface{}{
+		"owner_id": coordinate.UserID,
+	})
+}
+
+// ListAuditEvents searches the audit trail
+func (u *adminUsecase) ListAuditEvents(ctx context.Context, filter repository.AuditEventFilter) ([]*domain.AuditEvent, int64, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, 0, errors.New("invalid time range")
+	}
+	return u.auditEventRepo.Search(ctx, filter)
+}
+
+// findUser loads a user or returns "user not found"
+func (u *adminUsecase) findUser(ctx context.Context, userID uint) (*domain.User, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
@@ -0,0 +1,66 @@
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+)
+
+type auditLogger struct {
+	auditEventRepo repository.AuditEventRepository
+}
+
+// NewAuditLogger creates an audit logger backed by the audit_events table
+func NewAuditLogger(auditEventRepo repository.AuditEventRepository) usecase.AuditLogger {
+	return &auditLogger{
+		auditEventRepo: auditEventRepo,
+	}
+}
+
+// Record writes an audit event, attaching the client information of the request
+func (l *auditLogger) Record(ctx context.Context, actorID uint, action, targetType string, targetID uint, metadata map[string]interface{}) error {
+	return l.write(ctx, actorID, action, targetType, targetID, metadata, nil)
+}
+
+// RecordChanges writes an audit event with the diff of the changed fields.
+// Updates that changed nothing are not recorded.
+func (l *auditLogger) RecordChanges(ctx context.Context, actorID uint, action, targetType string, targetID uint, changes usecase.Changes) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	return l.write(ctx, actorID, action, targetType, targetID, nil, changes)
+}
+
+// write stores an event with the client information of the request
+func (l *auditLogger) write(ctx context.Context, actorID uint, action, targetType string, targetID uint, metadata map[string]interface{}, changes usecase.Changes) error {
+	client := usecase.ClientInfoFromContext(ctx)
+	
+	event := &domain.AuditEvent{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		IPAddress:  client.IPAddress,
+		UserAgent:  client.UserAgent,
+	}
+	
+	if len(metadata) > 0 {
+		data, err := json.Marshal(metadata)
+		if err != nil {
+			return err
+		}
+		event.Metadata = string(data)
+	}
+	if len(changes) > 0 {
+		data, err := json.Marshal(changes)
+		if err != nil {
+			return err
+		}
+		event.Changes = string(data)
+	}
+	
+	return l.auditEventRepo.Create(ctx, event)
+}
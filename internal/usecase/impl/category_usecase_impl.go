@@ -0,0 +1,308 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+)
+
+type categoryUsecase struct {
+	categoryRepo repository.CategoryRepository
+	audit        usecase.AuditLogger
+}
+
+// NewCategoryUsecase creates a new category usecase
+func NewCategoryUsecase(categoryRepo repository.CategoryRepository, audit usecase.AuditLogger) usecase.CategoryUsecase {
+	return &categoryUsecase{
+		categoryRepo: categoryRepo,
+		audit:        audit,
+	}
+}
+
+// GetCategoryTree returns the user's category tree
+func (u *categoryUsecase) GetCategoryTree(ctx context.Context, userID uint) ([]*usecase.CategoryNode, error) {
+	tree, err := loadCategoryTree(ctx, u.categoryRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	return tree.nodes(0), nil
+}
+
+// CreateCategory adds a category to the user's tree
+func (u *categoryUsecase) CreateCategory(ctx context.Context, userID uint, category *domain.Category) error {
+	name := strings.TrimSpace(category.Name)
+	if name == "" {
+		return errors.New("category name required")
+	}
+
+	tree, err := loadCategoryTree(ctx, u.categoryRepo, userID)
+	if err != nil {
+		return err
+	}
+	parentID := categoryParentKey(category.ParentID)
+	if category.ParentID != nil {
+		parent := tree.byID[parentID]
+		if parent == nil {
+			return errors.New("category not found")
+		}
+		if tree.depth(parent) >= domain.MaxCategoryDepth {
+			return errors.New("category tree too deep")
+		}
+	}
+	if tree.childNamed(parentID, name) != nil {
+		return errors.New("category already exists")
+	}
+
+	category.UserID = userID
+	category.Name = name
+	category.Position = len(tree.children[parentID])
+	if err := u.categoryRepo.Create(ctx, category); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, userID, domain.AuditActionCategoryCreate, domain.AuditTargetCategory, category.ID, map[string]interface{}{
+		"name": name,
+	})
+}
+
+// RenameCategory renames one of the user's categories. Renaming a top-level
+// category renames the super item of every item under it.
+func (u *categoryUsecase) RenameCategory(ctx context.Context, userID uint, categoryID uint, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("category name required")
+	}
+
+	tree, err := loadCategoryTree(ctx, u.categoryRepo, userID)
+	if err != nil {
+		return err
+	}
+	category := tree.byID[categoryID]
+	if category == nil {
+		return errors.New("category not found")
+	}
+	if category.Name == name {
+		return nil
+	}
+	if tree.childNamed(categoryParentKey(category.ParentID), name) != nil {
+		return errors.New("category already exists")
+	}
+
+	changes := usecase.Changes{}
+	changes.Track("name", category.Name, name)
+	category.Name = name
+
+	var itemCategoryIDs []uint
+	if category.ParentID == nil {
+		itemCategoryIDs = tree.subtreeIDs(category)
+	}
+	if err := u.categoryRepo.Rename(ctx, category, itemCategoryIDs, name); err != nil {
+		return err
+	}
+	return u.audit.RecordChanges(ctx, userID, domain.AuditActionCategoryRename, domain.AuditTargetCategory, categoryID, changes)
+}
+
+// ReorderCategories sets the order of a category's children. categoryIDs must
+// list every child exactly once.
+func (u *categoryUsecase) ReorderCategories(ctx context.Context, userID uint, parentID *uint, categoryIDs []uint) error {
+	tree, err := loadCategoryTree(ctx, u.categoryRepo, userID)
+	if err != nil {
+		return err
+	}
+	key := categoryParentKey(parentID)
+	if parentID != nil && tree.byID[key] == nil {
+		return errors.New("category not found")
+	}
+
+	siblings := tree.children[key]
+	if len(categoryIDs) != len(siblings) {
+		return errors.New("categories do not match")
+	}
+	isSibling := make(map[uint]bool, len(siblings))
+	for _, sibling := range siblings {
+		isSibling[sibling.ID] = true
+	}
+	ordered := make([]*domain.Category, len(categoryIDs))
+	for i, id := range categoryIDs {
+		if !isSibling[id] {
+			return errors.New("categories do not match")
+		}
+		delete(isSibling, id) // a repeated ID fails on its second occurrence
+		ordered[i] = tree.byID[id]
+		ordered[i].Position = i
+	}
+	return u.categoryRepo.UpdatePositions(ctx, ordered)
+}
+
+// MergeCategories merges one of the user's categories into another
+func (u *categoryUsecase) MergeCategories(ctx context.Context, userID uint, sourceID, targetID uint) error {
+	tree, err := loadCategoryTree(ctx, u.categoryRepo, userID)
+	if err != nil {
+		return err
+	}
+	source, target := tree.byID[sourceID], tree.byID[targetID]
+	if source == nil || target == nil {
+		return errors.New("category not found")
+	}
+	if sourceID == targetID {
+		return errors.New("cannot merge a category into itself")
+	}
+	for _, id := range tree.subtreeIDs(source) {
+		if id == targetID {
+			return errors.New("cannot merge a category into its own sub-category")
+		}
+	}
+	if tree.depth(target)+tree.height(source) > domain.MaxCategoryDepth {
+		return errors.New("category tree too deep")
+	}
+
+	if err := u.merge(ctx, tree, source, target); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, userID, domain.AuditActionCategoryMerge, domain.AuditTargetCategory, sourceID, map[string]interface{}{
+		"name":    source.Name,
+		"into_id": targetID,
+	})
+}
+
+// merge merges source into target, first merging each sub-category of source
+// into target's sub-category of the same name so siblings keep unique names
+func (u *categoryUsecase) merge(ctx context.Context, tree *categoryTree, source, target *domain.Category) error {
+	for _, child := range tree.children[source.ID] {
+		if twin := tree.childNamed(target.ID, child.Name); twin != nil {
+			if err := u.merge(ctx, tree, child, twin); err != nil {
+				return err
+			}
+		}
+	}
+	return u.categoryRepo.Merge(ctx, source, target, tree.subtreeIDs(source), tree.root(target).Name)
+}
+
+// categoryTree indexes a user's categories
+type categoryTree struct {
+	byID     map[uint]*domain.Category
+	children map[uint][]*domain.Category // by parent ID, 0 for top level, in position order
+}
+
+// loadCategoryTree loads the user's categories, creating the default ones the
+// first time
+func loadCategoryTree(ctx context.Context, categoryRepo repository.CategoryRepository, userID uint) (*categoryTree, error) {
+	categories, err := categoryRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(categories) == 0 {
+		categories, err = categoryRepo.CreateDefaults(ctx, userID, domain.SuperItemCategories)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newCategoryTree(categories), nil
+}
+
+// newCategoryTree indexes categories already in position order
+func newCategoryTree(categories []*domain.Category) *categoryTree {
+	tree := &categoryTree{
+		byID:     make(map[uint]*domain.Category, len(categories)),
+		children: make(map[uint][]*domain.Category),
+	}
+	for _, category := range categories {
+		tree.byID[category.ID] = category
+		key := categoryParentKey(category.ParentID)
+		tree.children[key] = append(tree.children[key], category)
+	}
+	return tree
+}
+
+// categoryParentKey is the children key of a parent ID
+func categoryParentKey(parentID *uint) uint {
+	if parentID == nil {
+		return 0
+	}
+	return *parentID
+}
+
+// childNamed finds the child of parentID with the given name
+func (t *categoryTree) childNamed(parentID uint, name string) *domain.Category {
+	for _, child := range t.children[parentID] {
+		if child.Name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// root returns the top-level category above category
+func (t *categoryTree) root(category *domain.Category) *domain.Category {
+	for category.ParentID != nil && t.byID[*category.ParentID] != nil {
+		category = t.byID[*category.ParentID]
+	}
+	return category
+}
+
+// depth is 1 for a top-level category, 2 for its children and so on
+func (t *categoryTree) depth(category *domain.Category) int {
+	depth := 1
+	for category.ParentID != nil && t.byID[*category.ParentID] != nil {
+		category = t.byID[*category.ParentID]
+		depth++
+	}
+	return depth
+}
+
+// height is how many levels of sub-categories category has
+func (t *categoryTree) height(category *domain.Category) int {
+	height := 0
+	for _, child := range t.children[category.ID] {
+		if h := t.height(child) + 1; h > height {
+			height = h
+		}
+	}
+	return height
+}
+
+// subtreeIDs lists category and all its sub-categories
+func (t *categoryTree) subtreeIDs(category *domain.Category) []uint {
+	ids := []uint{category.ID}
+	for _, child := range t.children[category.ID] {
+		ids = append(ids, t.subtreeIDs(child)...)
+	}
+	return ids
+}
+
+// nodes builds the tree below parentID
+func (t *categoryTree) nodes(parentID uint) []*usecase.CategoryNode {
+	nodes := make([]*usecase.CategoryNode, len(t.children[parentID]))
+	for i, category := range t.children[parentID] {
+		nodes[i] = &usecase.CategoryNode{
+			Category: category,
+			Children: t.nodes(category.ID),
+		}
+	}
+	return nodes
+}
+
+// file puts item in a category, given by its CategoryID or else by its
+// SuperItem naming a top-level category, and sets SuperItem to the name of
+// that category's top-level category
+func (t *categoryTree) file(item *domain.Item) error {
+	var category *domain.Category
+	switch {
+	case item.CategoryID != nil:
+		category = t.byID[*item.CategoryID]
+	case item.SuperItem != "":
+		category = t.childNamed(0, item.SuperItem)
+	default:
+		return errors.New("category required")
+	}
+	if category == nil {
+		return errors.New("category not found")
+	}
+	categoryID := category.ID
+	item.CategoryID = &categoryID
+	item.SuperItem = t.root(category).Name
+	return nil
+}
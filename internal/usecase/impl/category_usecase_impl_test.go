@@ -0,0 +1,288 @@
+package impl
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/internal/testutil"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+)
+
+func setupCategoryUsecase(t *testing.T) (*categoryUsecase, *repository.Container, *testutil.Fixtures) {
+	db := testutil.TestDB(t)
+	repos := repository.NewContainer(db)
+
+	usecase := NewCategoryUsecase(repos.Category, NewAuditLogger(repos.AuditEvent)).(*categoryUsecase)
+
+	return usecase, repos, testutil.NewFixtures(t, db)
+}
+
+// createCategory adds a category under parent, top-level when parent is nil
+func createCategory(t *testing.T, u *categoryUsecase, userID uint, parent *domain.Category, name string) *domain.Category {
+	t.Helper()
+	category := &domain.Category{Name: name}
+	if parent != nil {
+		category.ParentID = &parent.ID
+	}
+	if err := u.CreateCategory(context.Background(), userID, category); err != nil {
+		t.Fatalf("CreateCategory(%s) error = %v", name, err)
+	}
+	return category
+}
+
+// categoryNames lists the names of nodes in order
+func categoryNames(nodes []*usecase.CategoryNode) []string {
+	names := make([]string, len(nodes))
+	for i, node := range nodes {
+		names[i] = node.Category.Name
+	}
+	return names
+}
+
+func TestCategoryUsecase_DefaultTree(t *testing.T) {
+	usecase, repos, fixtures := setupCategoryUsecase(t)
+	ctx := context.Background()
+
+	user := fixtures.CreateUser()
+	shirt := fixtures.CreateItem(user.ID, func(i *domain.Item) { i.SuperItem = "トップス" })
+	vintage := fixtures.CreateItem(user.ID, func(i *domain.Item) { i.SuperItem = "古着" })
+
+	nodes, err := usecase.GetCategoryTree(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetCategoryTree() error = %v", err)
+	}
+
+	// The defaults come first, then the super items only the user's items use
+	names := categoryNames(nodes)
+	if len(names) != len(domain.SuperItemCategories)+1 || names[0] != domain.SuperItemCategories[0] || names[len(names)-1] != "古着" {
+		t.Fatalf("unexpected top-level categories %v", names)
+	}
+
+	for _, item := range []*domain.Item{shirt, vintage} {
+		filed, _ := repos.Item.FindByID(ctx, item.ID)
+		if filed.CategoryID == nil {
+			t.Fatalf("item %q was not filed under a category", item.SuperItem)
+		}
+		category, _ := repos.Category.FindByID(ctx, *filed.CategoryID)
+		if category.Name != item.SuperItem {
+			t.Errorf("item %q filed under %q", item.SuperItem, category.Name)
+		}
+	}
+
+	// The defaults are only created once
+	again, err := usecase.GetCategoryTree(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetCategoryTree() error = %v", err)
+	}
+	if len(again) != len(nodes) {
+		t.Errorf("GetCategoryTree() returned %d categories the second time, want %d", len(again), len(nodes))
+	}
+}
+
+func TestCategoryUsecase_DefaultTreeConcurrent(t *testing.T) {
+	usecase, repos, fixtures := setupCategoryUsecase(t)
+	ctx := context.Background()
+
+	user := fixtures.CreateUser()
+
+	// Pages load the item list and the category tree at the same time
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := usecase.GetCategoryTree(ctx, user.ID)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("GetCategoryTree() error = %v", err)
+		}
+	}
+
+	categories, _ := repos.Category.FindByUserID(ctx, user.ID)
+	if len(categories) != len(domain.SuperItemCategories) {
+		t.Errorf("created %d categories, want %d", len(categories), len(domain.SuperItemCategories))
+	}
+}
+
+func TestCategoryUsecase_CreateAndRename(t *testing.T) {
+	usecase, repos, fixtures := setupCategoryUsecase(t)
+	ctx := context.Background()
+
+	user := fixtures.CreateUser()
+	other := fixtures.CreateUser()
+	tree, err := loadCategoryTree(ctx, repos.Category, user.ID)
+	if err != nil {
+		t.Fatalf("loadCategoryTree() error = %v", err)
+	}
+	tops := tree.childNamed(0, "トップス")
+
+	shirts := createCategory(t, usecase, user.ID, tops, "シャツ")
+	oxford := createCategory(t, usecase, user.ID, shirts, "オックスフォード")
+	if shirts.Position != 0 || oxford.ParentID == nil || *oxford.ParentID != shirts.ID {
+		t.Errorf("unexpected categories %+v, %+v", shirts, oxford)
+	}
+
+	if err := usecase.CreateCategory(ctx, user.ID, &domain.Category{Name: " シャツ ", ParentID: &tops.ID}); err == nil || err.Error() != "category already exists" {
+		t.Errorf("CreateCategory() with a sibling's name error = %v", err)
+	}
+	if err := usecase.CreateCategory(ctx, other.ID, &domain.Category{Name: "ブラウス", ParentID: &tops.ID}); err == nil || err.Error() != "category not found" {
+		t.Errorf("CreateCategory() under another user's category error = %v", err)
+	}
+
+	parent := oxford
+	for depth := 4; depth <= domain.MaxCategoryDepth; depth++ {
+		parent = createCategory(t, usecase, user.ID, parent, "サブカテゴリー")
+	}
+	if err := usecase.CreateCategory(ctx, user.ID, &domain.Category{Name: "深すぎる", ParentID: &parent.ID}); err == nil || err.Error() != "category tree too deep" {
+		t.Errorf("CreateCategory() below the depth limit error = %v", err)
+	}
+
+	item := fixtures.CreateItem(user.ID, func(i *domain.Item) {
+		i.CategoryID = &oxford.ID
+		i.SuperItem = "トップス"
+	})
+
+	// Renaming a sub-category leaves super items alone
+	if err := usecase.RenameCategory(ctx, user.ID, shirts.ID, "シャツ・ブラウス"); err != nil {
+		t.Fatalf("RenameCategory() error = %v", err)
+	}
+	// Renaming a top-level category renames the super item of everything below it
+	if err := usecase.RenameCategory(ctx, user.ID, tops.ID, "Tops"); err != nil {
+		t.Fatalf("RenameCategory() error = %v", err)
+	}
+	renamed, _ := repos.Item.FindByID(ctx, item.ID)
+	if renamed.SuperItem != "Tops" {
+		t.Errorf("SuperItem = %q after renaming its top-level category", renamed.SuperItem)
+	}
+
+	if err := usecase.RenameCategory(ctx, user.ID, tops.ID, "ボトムス"); err == nil || err.Error() != "category already exists" {
+		t.Errorf("RenameCategory() to a sibling's name error = %v", err)
+	}
+	if err := usecase.RenameCategory(ctx, other.ID, tops.ID, "Mine"); err == nil || err.Error() != "category not found" {
+		t.Errorf("RenameCategory() of another user's category error = %v", err)
+	}
+}
+
+func TestCategoryUsecase_ReorderCategories(t *testing.T) {
+	usecase, repos, fixtures := setupCategoryUsecase(t)
+	ctx := context.Background()
+
+	user := fixtures.CreateUser()
+	nodes, err := usecase.GetCategoryTree(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetCategoryTree() error = %v", err)
+	}
+
+	reversed := make([]uint, len(nodes))
+	for i, node := range nodes {
+		reversed[len(nodes)-1-i] = node.Category.ID
+	}
+	if err := usecase.ReorderCategories(ctx, user.ID, nil, reversed); err != nil {
+		t.Fatalf("ReorderCategories() error = %v", err)
+	}
+	reordered, _ := usecase.GetCategoryTree(ctx, user.ID)
+	if reordered[0].Category.ID != reversed[0] || reordered[len(reordered)-1].Category.ID != reversed[len(reversed)-1] {
+		t.Errorf("unexpected order %v", categoryNames(reordered))
+	}
+
+	tree, _ := loadCategoryTree(ctx, repos.Category, user.ID)
+	tops := tree.childNamed(0, "トップス")
+	shirts := createCategory(t, usecase, user.ID, tops, "シャツ")
+	knits := createCategory(t, usecase, user.ID, tops, "ニット")
+	if err := usecase.ReorderCategories(ctx, user.ID, &tops.ID, []uint{knits.ID, shirts.ID}); err != nil {
+		t.Fatalf("ReorderCategories() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		parentID    *uint
+		categoryIDs []uint
+		errMsg      string
+	}{
+		{"missing sibling", &tops.ID, []uint{knits.ID}, "categories do not match"},
+		{"repeated sibling", &tops.ID, []uint{knits.ID, knits.ID}, "categories do not match"},
+		{"category from another parent", nil, append(reversed[1:], shirts.ID), "categories do not match"},
+		{"unknown parent", &[]uint{99999}[0], []uint{shirts.ID}, "category not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := usecase.ReorderCategories(ctx, user.ID, tt.parentID, tt.categoryIDs)
+			if err == nil || err.Error() != tt.errMsg {
+				t.Errorf("ReorderCategories() error = %v, want %v", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestCategoryUsecase_MergeCategories(t *testing.T) {
+	usecase, repos, fixtures := setupCategoryUsecase(t)
+	ctx := context.Background()
+
+	user := fixtures.CreateUser()
+	tree, err := loadCategoryTree(ctx, repos.Category, user.ID)
+	if err != nil {
+		t.Fatalf("loadCategoryTree() error = %v", err)
+	}
+	tops, outer := tree.childNamed(0, "トップス"), tree.childNamed(0, "アウター")
+
+	// トップス → シャツ → オックスフォード and アウター → シャツ → フランネル, アウター → コート
+	topsShirts := createCategory(t, usecase, user.ID, tops, "シャツ")
+	oxford := createCategory(t, usecase, user.ID, topsShirts, "オックスフォード")
+	outerShirts := createCategory(t, usecase, user.ID, outer, "シャツ")
+	flannel := createCategory(t, usecase, user.ID, outerShirts, "フランネル")
+	coats := createCategory(t, usecase, user.ID, outer, "コート")
+
+	filed := func(category *domain.Category) func(*domain.Item) {
+		return func(i *domain.Item) {
+			i.CategoryID = &category.ID
+			i.SuperItem = "アウター"
+		}
+	}
+	flannelShirt := fixtures.CreateItem(user.ID, filed(flannel))
+	jacket := fixtures.CreateItem(user.ID, filed(outer))
+
+	if err := usecase.MergeCategories(ctx, user.ID, tops.ID, oxford.ID); err == nil || err.Error() != "cannot merge a category into its own sub-category" {
+		t.Errorf("MergeCategories() into a sub-category error = %v", err)
+	}
+
+	if err := usecase.MergeCategories(ctx, user.ID, outer.ID, tops.ID); err != nil {
+		t.Fatalf("MergeCategories() error = %v", err)
+	}
+
+	nodes, _ := usecase.GetCategoryTree(ctx, user.ID)
+	for _, name := range categoryNames(nodes) {
+		if name == "アウター" {
+			t.Fatal("merged category still in the tree")
+		}
+	}
+	merged, _ := loadCategoryTree(ctx, repos.Category, user.ID)
+	if names := categoryNames(merged.nodes(tops.ID)); len(names) != 2 || names[0] != "シャツ" || names[1] != "コート" {
+		t.Errorf("unexpected sub-categories %v", names)
+	}
+	if names := categoryNames(merged.nodes(topsShirts.ID)); len(names) != 2 || names[0] != "オックスフォード" || names[1] != "フランネル" {
+		t.Errorf("same-named sub-categories were not merged: %v", names)
+	}
+	if merged.byID[coats.ID].Position != 1 {
+		t.Errorf("moved sub-category position = %d, want 1", merged.byID[coats.ID].Position)
+	}
+
+	for _, item := range []*domain.Item{flannelShirt, jacket} {
+		moved, _ := repos.Item.FindByID(ctx, item.ID)
+		if moved.SuperItem != "トップス" {
+			t.Errorf("item %d SuperItem = %q, want トップス", item.ID, moved.SuperItem)
+		}
+	}
+	moved, _ := repos.Item.FindByID(ctx, jacket.ID)
+	if moved.CategoryID == nil || *moved.CategoryID != tops.ID {
+		t.Errorf("item filed under the merged category was not moved")
+	}
+}
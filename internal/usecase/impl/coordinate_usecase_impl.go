@@ -4,95 +4,100 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
 	"mime/multipart"
-	"os"
-	"path/filepath"
 	"time"
 	
 	"github.com/House-lovers7/speadwear-go/internal/domain"
 	"github.com/House-lovers7/speadwear-go/internal/repository"
 	"github.com/House-lovers7/speadwear-go/internal/usecase"
 	"github.com/House-lovers7/speadwear-go/pkg/config"
+	"github.com/House-lovers7/speadwear-go/pkg/storage"
 	"gorm.io/gorm"
 )
 
 type coordinateUsecase struct {
-	coordinateRepo       repository.CoordinateRepository
-	itemRepo            repository.ItemRepository
-	likeCoordinateRepo  repository.LikeCoordinateRepository
-	relationshipRepo    repository.RelationshipRepository
-	blockRepo           repository.BlockRepository
-	notificationRepo    repository.NotificationRepository
-	config              *config.Config
-	db                  *gorm.DB
+	coordinateRepo     repository.CoordinateRepository
+	itemRepo           repository.ItemRepository
+	userRepo           repository.UserRepository
+	likeCoordinateRepo repository.LikeCoordinateRepository
+	relationshipRepo   repository.RelationshipRepository
+	blockRepo          repository.BlockRepository
+	notificationRepo   repository.NotificationRepository
+	wearLogRepo        repository.WearLogRepository
+	storage            storage.Storage
+	audit              usecase.AuditLogger
+	config             *config.Config
+	db                 *gorm.DB
 }
 
 // NewCoordinateUsecase creates a new coordinate usecase
 func NewCoordinateUsecase(
 	coordinateRepo repository.CoordinateRepository,
 	itemRepo repository.ItemRepository,
+	userRepo repository.UserRepository,
 	likeCoordinateRepo repository.LikeCoordinateRepository,
 	relationshipRepo repository.RelationshipRepository,
 	blockRepo repository.BlockRepository,
 	notificationRepo repository.NotificationRepository,
+	wearLogRepo repository.WearLogRepository,
+	storage storage.Storage,
+	audit usecase.AuditLogger,
 	config *config.Config,
 	db *gorm.DB,
 ) usecase.CoordinateUsecase {
 	return &coordinateUsecase{
 		coordinateRepo:      coordinateRepo,
 		itemRepo:           itemRepo,
+		userRepo:           userRepo,
 		likeCoordinateRepo: likeCoordinateRepo,
 		relationshipRepo:   relationshipRepo,
 		blockRepo:          blockRepo,
 		notificationRepo:   notificationRepo,
+		wearLogRepo:        wearLogRepo,
+		storage:            storage,
+		audit:              audit,
 		config:             config,
 		db:                 db,
 	}
 }
 
 // CreateCoordinate creates a new coordinate
-func (u *coordinateUsecase) CreateCoordinate(ctx context.Context, userID uint, coordinate *domain.Coordinate, itemIDs []uint, image *multipart.FileHeader) error {
+func (u *coordinateUsecase) CreateCoordinate(ctx context.Context, userID uint, coordinate *domain.Coordinate, items []domain.CoordinateItem, image *multipart.FileHeader) error {
 	coordinate.UserID = userID
+	if len(items) == 0 {
+		return errors.New("coordinate needs at least one item")
+	}
 	
 	// Verify all items belong to the user
-	for _, itemID := range itemIDs {
-		item, err := u.itemRepo.FindByID(ctx, itemID)
-		if err != nil {
-			return err
-		}
-		if item == nil {
-			return errors.New("item not found")
-		}
-		if item.UserID != userID {
-			return errors.New("unauthorized: item does not belong to user")
-		}
+	itemIDs, err := u.checkCoordinateItems(ctx, userID, items, nil)
+	if err != nil {
+		return err
 	}
 	
 	// Upload image if provided
 	if image != nil {
-		filename, err := u.uploadImage(image, "coordinates")
+		filename, err := u.storage.Save(image, "coordinates")
 		if err != nil {
 			return err
 		}
 		coordinate.Picture = filename
 	}
 	
-	// Use transaction to create coordinate and update items
-	return u.db.Transaction(func(tx *gorm.DB) error {
+	// Use transaction to create coordinate and link items
+	err = u.db.Transaction(func(tx *gorm.DB) error {
 		// Create coordinate
 		if err := tx.Create(coordinate).Error; err != nil {
 			return err
 		}
 		
-		// Update items to link to coordinate
-		for _, itemID := range itemIDs {
-			if err := tx.Model(&domain.Item{}).Where("id = ?", itemID).Update("coordinate_id", coordinate.ID).Error; err != nil {
-				return err
-			}
-		}
-		
-		return nil
+		return replaceCoordinateItems(tx, coordinate.ID, items)
+	})
+	if err != nil {
+		return err
+	}
+
+	return u.audit.Record(ctx, userID, domain.AuditActionCoordinateCreate, domain.AuditTargetCoordinate, coordinate.ID, map[string]interface{}{
+		"item_ids": itemIDs,
 	})
 }
 
@@ -105,6 +110,9 @@ func (u *coordinateUsecase) GetCoordinate(ctx context.Context, coordinateID uint
 	if coordinate == nil {
 		return nil, errors.New("coordinate not found")
 	}
+	if err := checkOwnerVisible(ctx, u.userRepo, coordinate.UserID, "coordinate not found"); err != nil {
+		return nil, err
+	}
 	return coordinate, nil
 }
 
@@ -117,12 +125,19 @@ func (u *coordinateUsecase) GetCoordinateWithDetails(ctx context.Context, coordi
 	if coordinate == nil {
 		return nil, errors.New("coordinate not found")
 	}
+	if err := checkOwnerVisible(ctx, u.userRepo, coordinate.UserID, "coordinate not found"); err != nil {
+		return nil, err
+	}
+	if err := attachCoordinateWearStats(ctx, u.wearLogRepo, []*domain.Coordinate{coordinate}); err != nil {
+		return nil, err
+	}
 	return coordinate, nil
 }
 
 // UpdateCoordinate updates a coordinate
-func (u *coordinateUsecase) UpdateCoordinate(ctx context.Context, userID uint, coordinateID uint, updates map[string]interface{}, itemIDs []uint, image *multipart.FileHeader) error {
-	coordinate, err := u.coordinateRepo.FindByID(ctx, coordinateID)
+func (u *coordinateUsecase) UpdateCoordinate(ctx context.Context, userID uint, coordinateID uint, updates map[string]interface{}, items []domain.CoordinateItem, image *multipart.FileHeader) error {
+	// The current items are needed to let unavailable ones stay in the outfit
+	coordinate, err := u.coordinateRepo.FindWithItems(ctx, coordinateID)
 	if err != nil {
 		return err
 	}
@@ -135,46 +150,66 @@ func (u *coordinateUsecase) UpdateCoordinate(ctx context.Context, userID uint, c
 		return errors.New("unauthorized")
 	}
 	
+	// Verify all new items belong to the user
+	itemIDs, err := u.checkCoordinateItems(ctx, userID, items, coordinate.Items)
+	if err != nil {
+		return err
+	}
+
 	// Apply updates
+	changes := usecase.Changes{}
 	if season, ok := updates["season"].(int); ok {
+		changes.Track("season", coordinate.Season, season)
 		coordinate.Season = season
 	}
 	if tpo, ok := updates["tpo"].(int); ok {
+		changes.Track("tpo", coordinate.TPO, tpo)
 		coordinate.TPO = tpo
 	}
 	if memo, ok := updates["memo"].(string); ok {
+		changes.Track("memo", coordinate.Memo, memo)
 		coordinate.Memo = memo
 	}
 	if rating, ok := updates["rating"].(float32); ok {
+		changes.Track("rating", coordinate.Rating, rating)
 		coordinate.Rating = rating
 	}
 	
 	// Update silhouette information
 	if siTopLength, ok := updates["si_top_length"].(int); ok {
+		changes.Track("si_top_length", coordinate.SiTopLength, siTopLength)
 		coordinate.SiTopLength = siTopLength
 	}
 	if siTopSleeve, ok := updates["si_top_sleeve"].(int); ok {
+		changes.Track("si_top_sleeve", coordinate.SiTopSleeve, siTopSleeve)
 		coordinate.SiTopSleeve = siTopSleeve
 	}
 	if siBottomLength, ok := updates["si_bottom_length"].(int); ok {
+		changes.Track("si_bottom_length", coordinate.SiBottomLength, siBottomLength)
 		coordinate.SiBottomLength = siBottomLength
 	}
 	if siBottomType, ok := updates["si_bottom_type"].(int); ok {
+		changes.Track("si_bottom_type", coordinate.SiBottomType, siBottomType)
 		coordinate.SiBottomType = siBottomType
 	}
 	if siDressLength, ok := updates["si_dress_length"].(int); ok {
+		changes.Track("si_dress_length", coordinate.SiDressLength, siDressLength)
 		coordinate.SiDressLength = siDressLength
 	}
 	if siDressSleeve, ok := updates["si_dress_sleeve"].(int); ok {
+		changes.Track("si_dress_sleeve", coordinate.SiDressSleeve, siDressSleeve)
 		coordinate.SiDressSleeve = siDressSleeve
 	}
 	if siOuterLength, ok := updates["si_outer_length"].(int); ok {
+		changes.Track("si_outer_length", coordinate.SiOuterLength, siOuterLength)
 		coordinate.SiOuterLength = siOuterLength
 	}
 	if siOuterSleeve, ok := updates["si_outer_sleeve"].(int); ok {
+		changes.Track("si_outer_sleeve", coordinate.SiOuterSleeve, siOuterSleeve)
 		coordinate.SiOuterSleeve = siOuterSleeve
 	}
 	if siShoeSize, ok := updates["si_shoe_size"].(int); ok {
+		changes.Track("si_shoe_size", coordinate.SiShoeSize, siShoeSize)
 		coordinate.SiShoeSize = siShoeSize
 	}
 	
@@ -182,51 +217,39 @@ func (u *coordinateUsecase) UpdateCoordinate(ctx context.Context, userID uint, c
 	if image != nil {
 		// Delete old image if exists
 		if coordinate.Picture != "" {
-			u.deleteImage(coordinate.Picture)
+			u.storage.Delete(coordinate.Picture)
 		}
 		
-		filename, err := u.uploadImage(image, "coordinates")
+		filename, err := u.storage.Save(image, "coordinates")
 		if err != nil {
 			return err
 		}
+		changes.Track("picture", coordinate.Picture, filename)
 		coordinate.Picture = filename
 	}
+	if len(itemIDs) > 0 {
+		changes["item_ids"] = usecase.FieldChange{To: itemIDs}
+	}
 	
 	// Use transaction to update coordinate and items
-	return u.db.Transaction(func(tx *gorm.DB) error {
+	err = u.db.Transaction(func(tx *gorm.DB) error {
 		// Update coordinate
 		if err := u.coordinateRepo.Update(ctx, coordinate); err != nil {
 			return err
 		}
 		
-		// Update items if provided
-		if len(itemIDs) > 0 {
-			// Remove all items from this coordinate
-			if err := tx.Model(&domain.Item{}).Where("coordinate_id = ?", coordinateID).Update("coordinate_id", nil).Error; err != nil {
-				return err
-			}
-			
-			// Add new items
-			for _, itemID := range itemIDs {
-				item, err := u.itemRepo.FindByID(ctx, itemID)
-				if err != nil {
-					return err
-				}
-				if item == nil {
-					return errors.New("item not found")
-				}
-				if item.UserID != userID {
-					return errors.New("unauthorized: item does not belong to user")
-				}
-				
-				if err := tx.Model(&domain.Item{}).Where("id = ?", itemID).Update("coordinate_id", coordinateID).Error; err != nil {
-					return err
-				}
-			}
+		// Replace the item list if provided
+		if len(items) > 0 {
+			return replaceCoordinateItems(tx, coordinateID, items)
 		}
 		
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	return u.audit.RecordChanges(ctx, userID, domain.AuditActionCoordinateUpdate, domain.AuditTargetCoordinate, coordinateID, changes)
 }
 
 // DeleteCoordinate deletes a coordinate
@@ -246,23 +269,23 @@ func (u *coordinateUsecase) DeleteCoordinate(ctx context.Context, userID uint, c
 	
 	// Delete image if exists
 	if coordinate.Picture != "" {
-		u.deleteImage(coordinate.Picture)
+		u.storage.Delete(coordinate.Picture)
 	}
 	
-	// Use transaction to delete coordinate and update items
-	return u.db.Transaction(func(tx *gorm.DB) error {
-		// Remove items from coordinate
-		if err := tx.Model(&domain.Item{}).Where("coordinate_id = ?", coordinateID).Update("coordinate_id", nil).Error; err != nil {
-			return err
-		}
-		
-		// Delete coordinate
-		return u.coordinateRepo.Delete(ctx, coordinateID)
-	})
+	// Delete coordinate; the items themselves stay in the wardrobe
+	if err := u.coordinateRepo.Delete(ctx, coordinateID); err != nil {
+		return err
+	}
+
+	return u.audit.Record(ctx, userID, domain.AuditActionCoordinateDelete, domain.AuditTargetCoordinate, coordinateID, nil)
 }
 
 // GetUserCoordinates gets coordinates for a user
 func (u *coordinateUsecase) GetUserCoordinates(ctx context.Context, userID uint, limit, offset int) ([]*domain.Coordinate, int64, error) {
+	if _, err := findVisibleUser(ctx, u.userRepo, userID); err != nil {
+		return nil, 0, err
+	}
+	
 	coordinates, err := u.coordinateRepo.FindByUserID(ctx, userID, limit, offset)
 	if err != nil {
 		return nil, 0, err
@@ -273,12 +296,22 @@ func (u *coordinateUsecase) GetUserCoordinates(ctx context.Context, userID uint,
 		return nil, 0, err
 	}
 	
+	if err := attachCoordinateWearStats(ctx, u.wearLogRepo, coordinates); err != nil {
+		return nil, 0, err
+	}
 	return coordinates, count, nil
 }
 
 // SearchCoordinates searches coordinates with filters
 func (u *coordinateUsecase) SearchCoordinates(ctx context.Context, filters repository.CoordinateFilter) ([]*domain.Coordinate, error) {
-	return u.coordinateRepo.FindByFilters(ctx, filters)
+	coordinates, err := u.coordinateRepo.FindByFilters(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachCoordinateWearStats(ctx, u.wearLogRepo, coordinates); err != nil {
+		return nil, err
+	}
+	return coordinates, nil
 }
 
 // GetTimelineCoordinates gets timeline coordinates for a user (from followed users)
@@ -291,7 +324,13 @@ func (u *coordinateUsecase) GetTimelineCoordinates(ctx context.Context, userID u
 	
 	// Get coordinates from followed users
 	var coordinates []*domain.Coordinate
+	now := time.Now()
 	for _, user := range followedUsers {
+		// Skip suspended users and accounts awaiting deletion
+		if user.IsSuspended(now) || user.DeletionPending() {
+			continue
+		}
+		
 		// Check if user is blocked
 		isBlocked, err := u.blockRepo.ExistsByBlockerAndBlocked(ctx, userID, user.ID)
 		if err != nil {
@@ -311,6 +350,9 @@ func (u *coordinateUsecase) GetTimelineCoordinates(ctx context.Context, userID u
 	// Sort by created_at desc and apply pagination
 	// TODO: Implement proper sorting and pagination
 	
+	if err := attachCoordinateWearStats(ctx, u.wearLogRepo, coordinates); err != nil {
+		return nil, err
+	}
 	return coordinates, nil
 }
 
@@ -432,52 +474,57 @@ func (u *coordinateUsecase) GetUserCoordinateStatistics(ctx context.Context, use
 	return stats, nil
 }
 
-// uploadImage uploads an image file
-func (u *coordinateUsecase) uploadImage(file *multipart.FileHeader, folder string) (string, error) {
-	// Check file size
-	if file.Size > u.config.Upload.MaxFileSize {
-		return "", errors.New("file size exceeds limit")
-	}
-	
-	// Open file
-	src, err := file.Open()
-	if err != nil {
-		return "", err
-	}
-	defer src.Close()
-	
-	// Generate unique filename
-	ext := filepath.Ext(file.Filename)
-	filename := fmt.Sprintf("%s/%d_%d%s", folder, time.Now().Unix(), time.Now().Nanosecond(), ext)
-	
-	// Create upload directory if not exists
-	uploadPath := filepath.Join(u.config.Upload.Path, folder)
-	if err := os.MkdirAll(uploadPath, 0755); err != nil {
-		return "", err
-	}
-	
-	// Create destination file
-	dstPath := filepath.Join(u.config.Upload.Path, filename)
-	dst, err := os.Create(dstPath)
-	if err != nil {
-		return "", err
-	}
-	defer dst.Close()
-	
-	// Copy file
-	if _, err = io.Copy(dst, src); err != nil {
-		return "", err
+// checkCoordinateItems verifies the items exist, belong to the user and are
+// listed once, returning their IDs in order. Items not already in current
+// must also be available (not in the laundry, lent out or retired).
+func (u *coordinateUsecase) checkCoordinateItems(ctx context.Context, userID uint, items, current []domain.CoordinateItem) ([]uint, error) {
+	kept := make(map[uint]bool, len(current))
+	for _, link := range current {
+		kept[link.ItemID] = true
+	}
+	itemIDs := make([]uint, len(items))
+	seen := make(map[uint]bool, len(items))
+	for i, link := range items {
+		if seen[link.ItemID] {
+			return nil, errors.New("duplicate item in coordinate")
+		}
+		seen[link.ItemID] = true
+
+		item, err := u.itemRepo.FindByID(ctx, link.ItemID)
+		if err != nil {
+			return nil, err
+		}
+		if item == nil {
+			return nil, errors.New("item not found")
+		}
+		if item.UserID != userID {
+			return nil, errors.New("unauthorized: item does not belong to user")
+		}
+		if !item.IsAvailable() && !kept[link.ItemID] {
+			return nil, errors.New("item not available")
+		}
+		itemIDs[i] = link.ItemID
 	}
-	
-	return filename, nil
+	return itemIDs, nil
 }
 
-// deleteImage deletes an image file
-func (u *coordinateUsecase) deleteImage(filename string) error {
-	if filename == "" {
+// replaceCoordinateItems sets the coordinate's item list, numbering the
+// positions in the given order
+func replaceCoordinateItems(tx *gorm.DB, coordinateID uint, items []domain.CoordinateItem) error {
+	if err := tx.Where("coordinate_id = ?", coordinateID).Delete(&domain.CoordinateItem{}).Error; err != nil {
+		return err
+	}
+	links := make([]domain.CoordinateItem, len(items))
+	for i, item := range items {
+		links[i] = domain.CoordinateItem{
+			CoordinateID: coordinateID,
+			ItemID:       item.ItemID,
+			Position:     i,
+			Role:         item.Role,
+		}
+	}
+	if len(links) == 0 {
 		return nil
 	}
-	
-	path := filepath.Join(u.config.Upload.Path, filename)
-	return os.Remove(path)
-}
\ No newline at end of file
+	return tx.Create(&links).Error
+}
@@ -0,0 +1,159 @@
+package impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/internal/testutil"
+	"github.com/House-lovers7/speadwear-go/pkg/config"
+	"github.com/House-lovers7/speadwear-go/pkg/storage"
+)
+
+func setupCoordinateUsecase(t *testing.T) (*coordinateUsecase, *repository.Container, *testutil.Fixtures) {
+	db := testutil.TestDB(t)
+
+	repos := repository.NewContainer(db)
+	cfg := &config.Config{
+		Upload: config.UploadConfig{
+			Path:        "./test-uploads",
+			MaxFileSize: 5 * 1024 * 1024,
+		},
+	}
+
+	usecase := NewCoordinateUsecase(
+		repos.Coordinate,
+		repos.Item,
+		repos.User,
+		repos.LikeCoordinate,
+		repos.Relationship,
+		repos.Block,
+		repos.Notification,
+		repos.WearLog,
+		storage.NewLocalStorage(cfg.Upload.Path, cfg.Upload.MaxFileSize),
+		NewAuditLogger(repos.AuditEvent),
+		cfg,
+		db,
+	).(*coordinateUsecase)
+
+	return usecase, repos, testutil.NewFixtures(t, db)
+}
+
+func TestCoordinateUsecase_GetCoordinate(t *testing.T) {
+	usecase, _, fixtures := setupCoordinateUsecase(t)
+	ctx := context.Background()
+
+	user := fixtures.CreateUser()
+	coordinate := fixtures.CreateCoordinate(user.ID)
+
+	// Coordinates of suspended users and accounts awaiting deletion are hidden
+	now := time.Now()
+	suspended := fixtures.CreateUser(func(u *domain.User) { u.SuspendedAt = &now })
+	leaving := fixtures.CreateUser(func(u *domain.User) { u.DeletionScheduledAt = &now })
+	suspendedCoordinate := fixtures.CreateCoordinate(suspended.ID)
+	leavingCoordinate := fixtures.CreateCoordinate(leaving.ID)
+
+	tests := []struct {
+		name         string
+		coordinateID uint
+		wantErr      bool
+	}{
+		{"existing coordinate", coordinate.ID, false},
+		{"coordinate of a suspended user", suspendedCoordinate.ID, true},
+		{"coordinate of an account awaiting deletion", leavingCoordinate.ID, true},
+		{"non-existent coordinate", 99999, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := usecase.GetCoordinate(ctx, tt.coordinateID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetCoordinate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && err.Error() != "coordinate not found" {
+				t.Errorf("GetCoordinate() error = %v, want coordinate not found", err)
+			}
+			if !tt.wantErr && found.ID != tt.coordinateID {
+				t.Errorf("GetCoordinate() ID = %v, want %v", found.ID, tt.coordinateID)
+			}
+
+			detailed, err := usecase.GetCoordinateWithDetails(ctx, tt.coordinateID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetCoordinateWithDetails() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(detailed.Items) != 3 {
+				t.Errorf("GetCoordinateWithDetails() returned %d items, want 3", len(detailed.Items))
+			}
+		})
+	}
+}
+
+func TestCoordinateUsecase_UpdateCoordinateKeepsUnavailableItems(t *testing.T) {
+	usecase, repos, fixtures := setupCoordinateUsecase(t)
+	ctx := context.Background()
+
+	user := fixtures.CreateUser()
+	coordinate := fixtures.CreateCoordinate(user.ID)
+	outfit, _ := repos.Coordinate.FindWithItems(ctx, coordinate.ID)
+	shoes, bottoms, tops := outfit.Items[0].ItemID, outfit.Items[1].ItemID, outfit.Items[2].ItemID
+
+	// The top goes to the laundry and a new jacket is lent out
+	top, _ := repos.Item.FindByID(ctx, tops)
+	top.Status = domain.ItemStatusLaundry
+	if err := repos.Item.Update(ctx, top); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	jacket := fixtures.CreateItem(user.ID, func(i *domain.Item) {
+		i.SuperItem = "アウター"
+		i.Status = domain.ItemStatusLent
+	})
+
+	tests := []struct {
+		name    string
+		updates map[string]interface{}
+		items   []domain.CoordinateItem
+		errMsg  string
+	}{
+		{
+			name:    "details only",
+			updates: map[string]interface{}{"memo": "Laundry day"},
+		},
+		{
+			name:  "reordered with the unavailable item kept",
+			items: []domain.CoordinateItem{{ItemID: tops}, {ItemID: bottoms}, {ItemID: shoes}},
+		},
+		{
+			name:   "unavailable item added",
+			items:  []domain.CoordinateItem{{ItemID: tops}, {ItemID: bottoms}, {ItemID: shoes}, {ItemID: jacket.ID}},
+			errMsg: "item not available",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := usecase.UpdateCoordinate(ctx, user.ID, coordinate.ID, tt.updates, tt.items, nil)
+			if tt.errMsg != "" {
+				if err == nil || err.Error() != tt.errMsg {
+					t.Errorf("UpdateCoordinate() error = %v, want %v", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateCoordinate() error = %v", err)
+			}
+		})
+	}
+
+	updated, err := repos.Coordinate.FindWithItems(ctx, coordinate.ID)
+	if err != nil {
+		t.Fatalf("FindWithItems() error = %v", err)
+	}
+	if updated.Memo != "Laundry day" {
+		t.Errorf("Memo = %q, want Laundry day", updated.Memo)
+	}
+	if len(updated.Items) != 3 || updated.Items[0].ItemID != tops || updated.Items[2].ItemID != shoes {
+		t.Errorf("unexpected items %+v", updated.Items)
+	}
+}
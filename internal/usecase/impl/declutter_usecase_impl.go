@@ -0,0 +1,238 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+	"github.com/House-lovers7/speadwear-go/pkg/config"
+)
+
+// declutterDigestInterval is how often a user gets the forgotten items reminder
+const declutterDigestInterval = 7 * 24 * time.Hour
+
+// Declutter score weights, summing to 1
+const (
+	declutterRatingWeight = 0.25 // low rating
+	declutterAgeWeight    = 0.25 // long owned
+	declutterWearWeight   = 0.3  // rarely worn
+	declutterSeasonWeight = 0.2  // its season came round and it still went unused
+)
+
+const (
+	// declutterFullAge is the age at which an item gets the full age score
+	declutterFullAge = 3 * 365 * 24 * time.Hour
+	// declutterFrequentWears is the wears per month at which an item gets no wear score
+	declutterFrequentWears = 4.0
+)
+
+type declutterUsecase struct {
+	itemRepo         repository.ItemRepository
+	userRepo         repository.UserRepository
+	wearLogRepo      repository.WearLogRepository
+	notificationRepo repository.NotificationRepository
+	config           *config.Config
+}
+
+// NewDeclutterUsecase creates a new declutter usecase
+func NewDeclutterUsecase(
+	itemRepo repository.ItemRepository,
+	userRepo repository.UserRepository,
+	wearLogRepo repository.WearLogRepository,
+	notificationRepo repository.NotificationRepository,
+	config *config.Config,
+) usecase.DeclutterUsecase {
+	return &declutterUsecase{
+		itemRepo:         itemRepo,
+		userRepo:         userRepo,
+		wearLogRepo:      wearLogRepo,
+		notificationRepo: notificationRepo,
+		config:           config,
+	}
+}
+
+// GetForgottenItems lists the user's items unused for the given number of days
+func (u *declutterUsecase) GetForgottenItems(ctx context.Context, userID uint, days int) ([]usecase.ForgottenItemGroup, error) {
+	return u.forgottenItems(ctx, userID, days, time.Now())
+}
+
+// SetDigest sets the unused days threshold of the weekly reminder
+func (u *declutterUsecase) SetDigest(ctx context.Context, userID uint, days int) error {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("user not found")
+	}
+
+	user.DeclutterDigestDays = days
+	return u.userRepo.Update(ctx, user)
+}
+
+// SendDigests sends the weekly forgotten items notifications due at now
+func (u *declutterUsecase) SendDigests(ctx context.Context, now time.Time) (int, error) {
+	users, err := u.userRepo.FindDeclutterDigestDue(ctx, now.Add(-declutterDigestInterval))
+	if err != nil {
+		return 0, err
+	}
+
+	notified := 0
+	for _, user := range users {
+		groups, err := u.forgottenItems(ctx, user.ID, user.DeclutterDigestDays, now)
+		if err != nil {
+			return notified, err
+		}
+		if len(groups) > 0 {
+			notification := &domain.Notification{
+				SenderID:   user.ID,
+				ReceiverID: user.ID,
+				Action:     domain.NotificationActionForgottenItems,
+			}
+			if err := u.notificationRepo.Create(ctx, notification); err != nil {
+				return notified, err
+			}
+			notified++
+		}
+
+		// Users with nothing forgotten are checked again next week too
+		user.DeclutterNotifiedAt = &now
+		if err := u.userRepo.Update(ctx, user); err != nil {
+			return notified, err
+		}
+	}
+	return notified, nil
+}
+
+// forgottenItems finds the available items not used since days before now
+// and scores them; items in the laundry, lent out or retired are left out
+func (u *declutterUsecase) forgottenItems(ctx context.Context, userID uint, days int, now time.Time) ([]usecase.ForgottenItemGroup, error) {
+	items, err := u.itemRepo.FindByUserID(ctx, userID, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachItemWearStats(ctx, u.wearLogRepo, items); err != nil {
+		return nil, err
+	}
+
+	cutoff := now.AddDate(0, 0, -days)
+	bySuperItem := make(map[string]*usecase.ForgottenItemGroup)
+	for _, item := range items {
+		if !item.IsAvailable() {
+			continue
+		}
+		lastUsed := lastItemUse(item)
+		if lastUsed.After(cutoff) {
+			continue
+		}
+
+		group, ok := bySuperItem[item.SuperItem]
+		if !ok {
+			group = &usecase.ForgottenItemGroup{SuperItem: item.SuperItem}
+			bySuperItem[item.SuperItem] = group
+		}
+		group.Items = append(group.Items, usecase.ForgottenItem{
+			Item:           item,
+			LastUsedAt:     lastUsed,
+			UnusedDays:     int(now.Sub(lastUsed).Hours() / 24),
+			DeclutterScore: declutterScore(item, lastUsed, now),
+		})
+	}
+
+	groups := make([]usecase.ForgottenItemGroup, 0, len(bySuperItem))
+	for _, group := range bySuperItem {
+		sort.Slice(group.Items, func(i, j int) bool {
+			a, b := group.Items[i], group.Items[j]
+			if a.DeclutterScore != b.DeclutterScore {
+				return a.DeclutterScore > b.DeclutterScore
+			}
+			return a.UnusedDays > b.UnusedDays
+		})
+		groups = append(groups, *group)
+	}
+	// Groups holding the strongest candidates come first
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i].Items[0].DeclutterScore, groups[j].Items[0].DeclutterScore
+		if a != b {
+			return a > b
+		}
+		return groups[i].SuperItem < groups[j].SuperItem
+	})
+	return groups, nil
+}
+
+// lastItemUse is when the item was last worn or added to a coordinate, or
+// when it was added if neither happened since
+func lastItemUse(item *domain.Item) time.Time {
+	lastUsed := item.CreatedAt
+	if item.LastWornAt != nil && item.LastWornAt.After(lastUsed) {
+		lastUsed = *item.LastWornAt
+	}
+	for _, link := range item.CoordinateItems {
+		if link.CreatedAt.After(lastUsed) {
+			lastUsed = link.CreatedAt
+		}
+	}
+	return lastUsed
+}
+
+// declutterScore rates from 0 to 100 how good a candidate for letting go an
+// unused item is, from its rating, age, wear frequency and season
+func declutterScore(item *domain.Item, lastUsed, now time.Time) int {
+	rating := 0.5 // unrated items are neutral
+	if item.Rating > 0 {
+		rating = math.Min(float64(5-item.Rating)/4, 1)
+	}
+
+	age := now.Sub(item.CreatedAt)
+	ageScore := math.Min(float64(age)/float64(declutterFullAge), 1)
+
+	months := math.Max(age.Hours()/24/30, 1)
+	wearScore := 1 - math.Min(float64(item.WearCount)/months/declutterFrequentWears, 1)
+
+	seasonScore := 0.0
+	if seasonCameRound(item.Season, lastUsed, now) {
+		seasonScore = 1
+	}
+
+	score := declutterRatingWeight*rating +
+		declutterAgeWeight*ageScore +
+		declutterWearWeight*wearScore +
+		declutterSeasonWeight*seasonScore
+	return int(math.Round(score * 100))
+}
+
+// seasonCameRound reports whether any month between since and now falls in
+// the season, so the item could have been worn but wasn't
+func seasonCameRound(season int, since, now time.Time) bool {
+	if season == domain.SeasonAllSeason {
+		return true
+	}
+	month := time.Date(since.Year(), since.Month(), 1, 0, 0, 0, 0, since.Location())
+	for i := 0; i < 12 && !month.After(now); i++ {
+		if seasonOfMonth(month.Month()) == season {
+			return true
+		}
+		month = month.AddDate(0, 1, 0)
+	}
+	return false
+}
+
+// seasonOfMonth maps a month to its season
+func seasonOfMonth(month time.Month) int {
+	switch month {
+	case time.March, time.April, time.May:
+		return domain.SeasonSpring
+	case time.June, time.July, time.August:
+		return domain.SeasonSummer
+	case time.September, time.October, time.November:
+		return domain.SeasonAutumn
+	default:
+		return domain.SeasonWinter
+	}
+}
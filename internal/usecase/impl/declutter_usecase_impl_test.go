@@ -0,0 +1,145 @@
+package impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/internal/testutil"
+	"github.com/House-lovers7/speadwear-go/pkg/config"
+)
+
+func setupDeclutterUsecase(t *testing.T) (*declutterUsecase, *repository.Container, *testutil.Fixtures) {
+	db := testutil.TestDB(t)
+	repos := repository.NewContainer(db)
+
+	usecase := NewDeclutterUsecase(
+		repos.Item,
+		repos.User,
+		repos.WearLog,
+		repos.Notification,
+		&config.Config{},
+	).(*declutterUsecase)
+
+	return usecase, repos, testutil.NewFixtures(t, db)
+}
+
+func TestDeclutterUsecase_GetForgottenItems(t *testing.T) {
+	usecase, repos, fixtures := setupDeclutterUsecase(t)
+	ctx := context.Background()
+
+	user := fixtures.CreateUser()
+	twoYearsAgo := time.Now().AddDate(-2, 0, 0)
+	owned := func(superItem string, rating float32) func(*domain.Item) {
+		return func(i *domain.Item) {
+			i.SuperItem = superItem
+			i.Rating = rating
+			i.CreatedAt = twoYearsAgo
+		}
+	}
+	neverWorn := fixtures.CreateItem(user.ID, owned("アウター", 2))
+	wornLongAgo := fixtures.CreateItem(user.ID, owned("トップス", 5))
+	wornRecently := fixtures.CreateItem(user.ID, owned("トップス", 3))
+	coordinated := fixtures.CreateItem(user.ID, owned("ボトムス", 3))
+	fixtures.CreateItem(user.ID) // just added
+
+	for _, wearLog := range []*domain.WearLog{
+		{UserID: user.ID, WornOn: time.Now().AddDate(0, 0, -400), Items: []domain.WearLogItem{{ItemID: wornLongAgo.ID}}},
+		{UserID: user.ID, WornOn: time.Now().AddDate(0, 0, -10), Items: []domain.WearLogItem{{ItemID: wornRecently.ID}}},
+	} {
+		if err := repos.WearLog.Create(ctx, wearLog); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	coordinate := fixtures.CreateCoordinate(user.ID)
+	fixtures.LinkItems(coordinate, []*domain.Item{coordinated})
+
+	groups, err := usecase.GetForgottenItems(ctx, user.ID, 365)
+	if err != nil {
+		t.Fatalf("GetForgottenItems() error = %v", err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %+v", groups)
+	}
+
+	// The low rated, never worn item is the strongest candidate
+	first := groups[0]
+	if first.SuperItem != "アウター" || len(first.Items) != 1 || first.Items[0].Item.ID != neverWorn.ID {
+		t.Errorf("unexpected first group %+v", first)
+	}
+	second := groups[1]
+	if second.SuperItem != "トップス" || len(second.Items) != 1 || second.Items[0].Item.ID != wornLongAgo.ID {
+		t.Errorf("unexpected second group %+v", second)
+	}
+	if first.Items[0].DeclutterScore <= second.Items[0].DeclutterScore {
+		t.Errorf("expected never worn score %d above %d", first.Items[0].DeclutterScore, second.Items[0].DeclutterScore)
+	}
+	if unused := second.Items[0].UnusedDays; unused < 399 || unused > 400 {
+		t.Errorf("UnusedDays = %d, want 400", unused)
+	}
+
+	// A shorter window also includes the item worn ten days ago
+	groups, err = usecase.GetForgottenItems(ctx, user.ID, 5)
+	if err != nil {
+		t.Fatalf("GetForgottenItems() error = %v", err)
+	}
+	total := 0
+	for _, group := range groups {
+		total += len(group.Items)
+	}
+	if total != 3 {
+		t.Errorf("expected 3 items unused for 5 days, got %d", total)
+	}
+}
+
+func TestDeclutterUsecase_SendDigests(t *testing.T) {
+	usecase, repos, fixtures := setupDeclutterUsecase(t)
+	ctx := context.Background()
+
+	subscribed := fixtures.CreateUser()
+	unsubscribed := fixtures.CreateUser()
+	for _, user := range []*domain.User{subscribed, unsubscribed} {
+		fixtures.CreateItem(user.ID, func(i *domain.Item) {
+			i.CreatedAt = time.Now().AddDate(-1, -1, 0)
+		})
+	}
+	if err := usecase.SetDigest(ctx, subscribed.ID, 365); err != nil {
+		t.Fatalf("SetDigest() error = %v", err)
+	}
+
+	now := time.Now()
+	notified, err := usecase.SendDigests(ctx, now)
+	if err != nil {
+		t.Fatalf("SendDigests() error = %v", err)
+	}
+	if notified != 1 {
+		t.Errorf("SendDigests() = %d, want 1", notified)
+	}
+
+	notifications, err := repos.Notification.FindByReceiverID(ctx, subscribed.ID, 10, 0)
+	if err != nil {
+		t.Fatalf("FindByReceiverID() error = %v", err)
+	}
+	if len(notifications) != 1 || notifications[0].Action != domain.NotificationActionForgottenItems {
+		t.Errorf("unexpected notifications %+v", notifications)
+	}
+
+	// Nobody is reminded twice in a week
+	notified, err = usecase.SendDigests(ctx, now.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("SendDigests() error = %v", err)
+	}
+	if notified != 0 {
+		t.Errorf("SendDigests() a day later = %d, want 0", notified)
+	}
+
+	notified, err = usecase.SendDigests(ctx, now.Add(declutterDigestInterval))
+	if err != nil {
+		t.Fatalf("SendDigests() error = %v", err)
+	}
+	if notified != 1 {
+		t.Errorf("SendDigests() a week later = %d, want 1", notified)
+	}
+}
@@ -0,0 +1,200 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+	"github.com/House-lovers7/speadwear-go/pkg/mailer"
+	"github.com/House-lovers7/speadwear-go/pkg/utils"
+)
+
+// requestEmailChange records a pending email change, mails a confirmation link
+// to the new address and a cancel link to the current one
+func (u *userUsecase) requestEmailChange(ctx context.Context, user *domain.User, email string) error {
+	// Keep the cancel link of a completed change usable until it expires, so a
+	// second change can't be used to invalidate it
+	revertible, err := u.emailChangeRevertible(user)
+	if err != nil {
+		return err
+	}
+	if revertible && user.UnconfirmedEmail == "" {
+		return errors.New("email recently changed")
+	}
+
+	exists, err := u.userRepo.ExistsByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.New("email already exists")
+	}
+
+	confirmToken, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return err
+	}
+	revertToken, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return err
+	}
+
+	// A new request replaces any pending one
+	now := time.Now()
+	user.UnconfirmedEmail = email
+	user.PreviousEmail = ""
+	user.EmailChangeDigest = utils.HashToken(confirmToken)
+	user.EmailRevertDigest = utils.HashToken(revertToken)
+	user.EmailChangeSentAt = &now
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	msg, err := mailer.Render("email_change", email, map[string]interface{}{
+		"Name":      user.Name,
+		"URL":       u.config.App.FrontendURL + "/email/confirm?token=" + url.QueryEscape(confirmToken),
+		"ExpiresIn": u.config.Auth.EmailChangeExpiration,
+	})
+	if err != nil {
+		return err
+	}
+	if err := u.mailer.Send(ctx, msg); err != nil {
+		return err
+	}
+
+	notice, err := mailer.Render("email_change_notice", user.Email, map[string]interface{}{
+		"Name":      user.Name,
+		"NewEmail":  email,
+		"URL":       u.config.App.FrontendURL + "/email/cancel?token=" + url.QueryEscape(revertToken),
+		"ExpiresIn": u.config.Auth.EmailRevertExpiration,
+	})
+	if err != nil {
+		return err
+	}
+	return u.mailer.Send(ctx, notice)
+}
+
+// ConfirmEmailChange completes a pending email change with the token sent to the new address
+func (u *userUsecase) ConfirmEmailChange(ctx context.Context, token string) error {
+	if token == "" {
+		return errors.New("invalid or expired token")
+	}
+
+	user, err := u.userRepo.FindByEmailChangeDigest(ctx, utils.HashToken(token))
+	if err != nil {
+		return err
+	}
+	if user == nil || user.UnconfirmedEmail == "" || !utils.CompareTokenHash(token, user.EmailChangeDigest) {
+		return errors.New("invalid or expired token")
+	}
+
+	// Check expiration
+	expiration, err := time.ParseDuration(u.config.Auth.EmailChangeExpiration)
+	if err != nil {
+		return err
+	}
+	if user.EmailChangeSentAt == nil || time.Since(*user.EmailChangeSentAt) > expiration {
+		return errors.New("invalid or expired token")
+	}
+
+	// The address may have been taken while the change was pending
+	exists, err := u.userRepo.ExistsByEmail(ctx, user.UnconfirmedEmail)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.New("email already exists")
+	}
+
+	// The revert digest stays so the old address can still undo the change
+	changes := usecase.Changes{}
+	changes.Track("email", user.Email, user.UnconfirmedEmail)
+	user.PreviousEmail = user.Email
+	user.Email = user.UnconfirmedEmail
+	user.UnconfirmedEmail = ""
+	user.EmailChangeDigest = ""
+
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	return u.audit.RecordChanges(ctx, user.ID, domain.AuditActionUserEmailChange, domain.AuditTargetUser, user.ID, changes)
+}
+
+// CancelEmailChange discards a pending change, or reverts a completed one,
+// with the token sent to the old address
+func (u *userUsecase) CancelEmailChange(ctx context.Context, token string) error {
+	if token == "" {
+		return errors.New("invalid or expired token")
+	}
+
+	user, err := u.userRepo.FindByEmailRevertDigest(ctx, utils.HashToken(token))
+	if err != nil {
+		return err
+	}
+	if user == nil || !utils.CompareTokenHash(token, user.EmailRevertDigest) {
+		return errors.New("invalid or expired token")
+	}
+
+	revertible, err := u.emailChangeRevertible(user)
+	if err != nil {
+		return err
+	}
+	if !revertible {
+		return errors.New("invalid or expired token")
+	}
+
+	// Still pending: simply forget it
+	if user.UnconfirmedEmail != "" {
+		clearEmailChange(user)
+		if err := u.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+		return u.audit.Record(ctx, user.ID, domain.AuditActionUserEmailChangeCancel, domain.AuditTargetUser, user.ID, nil)
+	}
+
+	// Already confirmed: restore the old address and end every session, since
+	// whoever made the change may still be signed in
+	exists, err := u.userRepo.ExistsByEmail(ctx, user.PreviousEmail)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.New("email already exists")
+	}
+
+	changes := usecase.Changes{}
+	changes.Track("email", user.Email, user.PreviousEmail)
+	user.Email = user.PreviousEmail
+	clearEmailChange(user)
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	if err := u.sessionRepo.RevokeAllByUserID(ctx, user.ID, 0); err != nil {
+		return err
+	}
+	return u.audit.RecordChanges(ctx, user.ID, domain.AuditActionUserEmailChangeCancel, domain.AuditTargetUser, user.ID, changes)
+}
+
+// emailChangeRevertible reports whether the old address can still cancel the last change
+func (u *userUsecase) emailChangeRevertible(user *domain.User) (bool, error) {
+	if user.EmailRevertDigest == "" || user.EmailChangeSentAt == nil {
+		return false, nil
+	}
+	expiration, err := time.ParseDuration(u.config.Auth.EmailRevertExpiration)
+	if err != nil {
+		return false, err
+	}
+	return time.Since(*user.EmailChangeSentAt) <= expiration, nil
+}
+
+// clearEmailChange forgets any pending or revertible email change
+func clearEmailChange(user *domain.User) {
+	user.UnconfirmedEmail = ""
+	user.PreviousEmail = ""
+	user.EmailChangeDigest = ""
+	user.EmailRevertDigest = ""
+	user.EmailChangeSentAt = nil
+}
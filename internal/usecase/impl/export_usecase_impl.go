@@ -0,0 +1,600 @@
+package impl
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/dto"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+	"github.com/House-lovers7/speadwear-go/pkg/config"
+	"github.com/House-lovers7/speadwear-go/pkg/mailer"
+	"github.com/House-lovers7/speadwear-go/pkg/utils"
+)
+
+type exportUsecase struct {
+	repos  *repository.Container
+	mailer mailer.Mailer
+	config *config.Config
+	// async runs export jobs; tests replace it to run them inline
+	async func(job func())
+}
+
+// NewExportUsecase creates a new account data export usecase
+func NewExportUsecase(repos *repository.Container, mailer mailer.Mailer, config *config.Config) usecase.ExportUsecase {
+	return &exportUsecase{
+		repos:  repos,
+		mailer: mailer,
+		config: config,
+		async:  func(job func()) { go job() },
+	}
+}
+
+// exportArchive is everything written to an export ZIP
+type exportArchive struct {
+	Profile       dto.UserResponse
+	Items         []dto.ItemResponse
+	Categories    []exportCategory
+	Coordinates   []exportCoordinate
+	WearLogs      []exportWearLog
+	Comments      []exportComment
+	Likes         []exportLike
+	Following     []exportUser
+	Followers     []exportUser
+	Blocks        []exportUser
+	Notifications []exportNotification
+	Pictures      []string // upload-relative paths of the user's images
+}
+
+type exportCategory struct {
+	ID       uint   `json:"id"`
+	ParentID *uint  `json:"parent_id,omitempty"`
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+}
+
+type exportCoordinate struct {
+	ID             uint      `json:"id"`
+	Season         int       `json:"season"`
+	TPO            int       `json:"tpo"`
+	Picture        string    `json:"picture"`
+	SiTopLength    int       `json:"si_top_length"`
+	SiTopSleeve    int       `json:"si_top_sleeve"`
+	SiBottomLength int       `json:"si_bottom_length"`
+	SiBottomType   int       `json:"si_bottom_type"`
+	SiDressLength  int       `json:"si_dress_length"`
+	SiDressSleeve  int       `json:"si_dress_sleeve"`
+	SiOuterLength  int       `json:"si_outer_length"`
+	SiOuterSleeve  int       `json:"si_outer_sleeve"`
+	SiShoeSize     int       `json:"si_shoe_size"`
+	Memo           string    `json:"memo"`
+	Rating         float32   `json:"rating"`
+	ItemIDs        []uint    `json:"item_ids"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type exportWearLog struct {
+	ID           uint      `json:"id"`
+	WornOn       string    `json:"worn_on"`
+	CoordinateID *uint     `json:"coordinate_id,omitempty"`
+	ItemIDs      []uint    `json:"item_ids"`
+	Weather      string    `json:"weather"`
+	Mood         int       `json:"mood"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type exportComment struct {
+	ID           uint      `json:"id"`
+	CoordinateID uint      `json:"coordinate_id"`
+	Comment      string    `json:"comment"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type exportLike struct {
+	CoordinateID uint      `json:"coordinate_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type exportUser struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+type exportNotification struct {
+	ID           uint      `json:"id"`
+	SenderID     uint      `json:"sender_id"`
+	Action       string    `json:"action"`
+	CoordinateID *uint     `json:"coordinate_id,omitempty"`
+	CommentID    *uint     `json:"comment_id,omitempty"`
+	Checked      bool      `json:"checked"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// RequestExport queues a new export, replacing the archives of earlier ones
+func (u *exportUsecase) RequestExport(ctx context.Context, userID uint) (*domain.DataExport, error) {
+	staleBefore, err := u.staleBefore()
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := u.repos.DataExport.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, export := range previous {
+		if err := u.failStalled(ctx, export, staleBefore); err != nil {
+			return nil, err
+		}
+		if export.InProgress() {
+			return nil, errors.New("export already in progress")
+		}
+	}
+
+	// Only the newest archive is kept; older download links stop working
+	for _, export := range previous {
+		if export.FilePath == "" {
+			continue
+		}
+		if err := os.Remove(export.FilePath); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		export.FilePath = ""
+		export.DownloadDigest = ""
+		if err := u.repos.DataExport.Update(ctx, export); err != nil {
+			return nil, err
+		}
+	}
+
+	export := &domain.DataExport{
+		UserID: userID,
+		Status: domain.ExportStatusPending,
+	}
+	created, err := u.repos.DataExport.CreateIfIdle(ctx, export, staleBefore)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, errors.New("export already in progress")
+	}
+
+	u.async(func() { u.runExport(export.ID) })
+
+	return export, nil
+}
+
+// GetLatestExport returns the user's most recent export
+func (u *exportUsecase) GetLatestExport(ctx context.Context, userID uint) (*domain.DataExport, error) {
+	export, err := u.repos.DataExport.FindLatestByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if export == nil {
+		return nil, errors.New("export not found")
+	}
+
+	staleBefore, err := u.staleBefore()
+	if err != nil {
+		return nil, err
+	}
+	if err := u.failStalled(ctx, export, staleBefore); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// staleBefore is the last update time before which an unfinished export is
+// assumed to have been cut off
+func (u *exportUsecase) staleBefore() (time.Time, error) {
+	timeout, err := time.ParseDuration(u.config.Export.JobTimeout)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Now().Add(-timeout), nil
+}
+
+// failStalled marks an export as failed if its job stopped without finishing
+func (u *exportUsecase) failStalled(ctx context.Context, export *domain.DataExport, staleBefore time.Time) error {
+	if !export.Stalled(staleBefore) {
+		return nil
+	}
+	if err := u.repos.DataExport.MarkFailed(ctx, export.ID, "Export timed out"); err != nil {
+		return err
+	}
+	export.Status = domain.ExportStatusFailed
+	export.Error = "Export timed out"
+	return nil
+}
+
+// OpenExport returns the finished export a download token belongs to
+func (u *exportUsecase) OpenExport(ctx context.Context, token string) (*domain.DataExport, error) {
+	if token == "" {
+		return nil, errors.New("invalid or expired token")
+	}
+
+	export, err := u.repos.DataExport.FindByDownloadDigest(ctx, utils.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if export == nil || !utils.CompareTokenHash(token, export.DownloadDigest) || !export.IsDownloadable(time.Now()) {
+		return nil, errors.New("invalid or expired token")
+	}
+	if _, err := os.Stat(export.FilePath); err != nil {
+		return nil, errors.New("invalid or expired token")
+	}
+
+	return export, nil
+}
+
+// runExport builds the archive for an export and mails its download link.
+// It runs outside the request, so failures are recorded on the export.
+func (u *exportUsecase) runExport(exportID uint) {
+	ctx := context.Background()
+
+	export, err := u.repos.DataExport.FindByID(ctx, exportID)
+	if err == nil && export == nil {
+		log.Printf("data export %d: not found", exportID)
+		return
+	}
+	if err == nil {
+		export.Status = domain.ExportStatusProcessing
+		err = u.repos.DataExport.Update(ctx, export)
+	}
+	if err == nil {
+		err = u.buildExport(ctx, export)
+	}
+	if err != nil {
+		log.Printf("data export %d failed: %v", exportID, err)
+		if err := u.repos.DataExport.MarkFailed(ctx, exportID, "Failed to build export"); err != nil {
+			log.Printf("data export %d: %v", exportID, err)
+		}
+	}
+}
+
+// buildExport writes the archive, completes the export and notifies the user
+func (u *exportUsecase) buildExport(ctx context.Context, export *domain.DataExport) error {
+	user, err := u.repos.User.FindByID(ctx, export.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("user not found")
+	}
+
+	archive, err := u.collectExport(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(u.config.Export.Path, 0700); err != nil {
+		return err
+	}
+	filename := filepath.Join(u.config.Export.Path, fmt.Sprintf("user_%d_export_%d.zip", user.ID, export.ID))
+
+	// Write to a temporary file so a half-written archive is never served
+	tmp, err := os.CreateTemp(u.config.Export.Path, "export-*.zip.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := writeExportArchive(tmp, archive, u.config.Upload.Path); err != nil {
+		tmp.Close()
+		return err
+	}
+	info, err := tmp.Stat()
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), filename); err != nil {
+		return err
+	}
+
+	expiration, err := time.ParseDuration(u.config.Export.LinkExpiration)
+	if err != nil {
+		return err
+	}
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(expiration)
+	export.Status = domain.ExportStatusCompleted
+	export.FilePath = filename
+	export.FileSize = info.Size()
+	export.DownloadDigest = utils.HashToken(token)
+	export.CompletedAt = &now
+	export.ExpiresAt = &expiresAt
+	export.Error = ""
+	if err := u.repos.DataExport.Update(ctx, export); err != nil {
+		return err
+	}
+
+	msg, err := mailer.Render("data_export", user.Email, map[string]interface{}{
+		"Name":      user.Name,
+		"URL":       u.config.App.FrontendURL + "/export/download?token=" + url.QueryEscape(token),
+		"ExpiresIn": u.config.Export.LinkExpiration,
+	})
+	if err != nil {
+		return err
+	}
+	// The archive is ready either way; the user can request a new link by exporting again
+	if err := u.mailer.Send(ctx, msg); err != nil {
+		log.Printf("data export %d: failed to send notification: %v", export.ID, err)
+	}
+	return nil
+}
+
+// collectExport gathers the user's data from the repositories
+func (u *exportUsecase) collectExport(ctx context.Context, user *domain.User) (*exportArchive, error) {
+	archive := &exportArchive{Profile: newUserResponse(user)}
+	pictures := []string{user.Picture}
+
+	items, err := u.repos.Item.FindByUserID(ctx, user.ID, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachItemWearStats(ctx, u.repos.WearLog, items); err != nil {
+		return nil, err
+	}
+	archive.Items = make([]dto.ItemResponse, len(items))
+	for i, item := range items {
+		archive.Items[i] = dto.ItemResponse{
+			ID:              item.ID,
+			UserID:          item.UserID,
+			CoordinateIDs:   item.CoordinateIDs(),
+			CategoryID:      item.CategoryID,
+			SuperItem:       item.SuperItem,
+			Season:          item.Season,
+			TPO:             item.TPO,
+			Color:           item.Color,
+			Content:         item.Content,
+			Memo:            item.Memo,
+			Picture:         item.Picture,
+			Rating:          item.Rating,
+			PurchasePrice:   item.PurchasePrice,
+			Currency:        item.Currency,
+			Status:          item.Status,
+			StatusChangedAt: item.StatusChangedAt,
+			WearCount:       item.WearCount,
+			LastWornAt:      item.LastWornAt,
+			CreatedAt:       item.CreatedAt,
+			UpdatedAt:       item.UpdatedAt,
+		}
+		if item.PurchasedOn != nil {
+			purchasedOn := item.PurchasedOn.Format(domain.DateLayout)
+			archive.Items[i].PurchasedOn = &purchasedOn
+		}
+		pictures = append(pictures, item.Picture)
+	}
+
+	categories, err := u.repos.Category.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	archive.Categories = make([]exportCategory, len(categories))
+	for i, category := range categories {
+		archive.Categories[i] = exportCategory{
+			ID:       category.ID,
+			ParentID: category.ParentID,
+			Name:     category.Name,
+			Position: category.Position,
+		}
+	}
+
+	coordinates, err := u.repos.Coordinate.FindByUserID(ctx, user.ID, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	archive.Coordinates = make([]exportCoordinate, len(coordinates))
+	for i, coordinate := range coordinates {
+		itemIDs := make([]uint, len(coordinate.Items))
+		for j, link := range coordinate.Items {
+			itemIDs[j] = link.ItemID
+		}
+		archive.Coordinates[i] = exportCoordinate{
+			ID:             coordinate.ID,
+			Season:         coordinate.Season,
+			TPO:            coordinate.TPO,
+			Picture:        coordinate.Picture,
+			SiTopLength:    coordinate.SiTopLength,
+			SiTopSleeve:    coordinate.SiTopSleeve,
+			SiBottomLength: coordinate.SiBottomLength,
+			SiBottomType:   coordinate.SiBottomType,
+			SiDressLength:  coordinate.SiDressLength,
+			SiDressSleeve:  coordinate.SiDressSleeve,
+			SiOuterLength:  coordinate.SiOuterLength,
+			SiOuterSleeve:  coordinate.SiOuterSleeve,
+			SiShoeSize:     coordinate.SiShoeSize,
+			Memo:           coordinate.Memo,
+			Rating:         coordinate.Rating,
+			ItemIDs:        itemIDs,
+			CreatedAt:      coordinate.CreatedAt,
+			UpdatedAt:      coordinate.UpdatedAt,
+		}
+		pictures = append(pictures, coordinate.Picture)
+	}
+
+	wearLogs, _, err := u.repos.WearLog.FindByFilters(ctx, repository.WearLogFilter{UserID: user.ID})
+	if err != nil {
+		return nil, err
+	}
+	archive.WearLogs = make([]exportWearLog, len(wearLogs))
+	for i, wearLog := range wearLogs {
+		archive.WearLogs[i] = exportWearLog{
+			ID:           wearLog.ID,
+			WornOn:       wearLog.WornOn.Format(domain.DateLayout),
+			CoordinateID: wearLog.CoordinateID,
+			ItemIDs:      wearLogItemIDs(wearLog.Items),
+			Weather:      wearLog.Weather,
+			Mood:         wearLog.Mood,
+			CreatedAt:    wearLog.CreatedAt,
+			UpdatedAt:    wearLog.UpdatedAt,
+		}
+	}
+
+	comments, err := u.repos.Comment.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	archive.Comments = make([]exportComment, len(comments))
+	for i, comment := range comments {
+		archive.Comments[i] = exportComment{
+			ID:           comment.ID,
+			CoordinateID: comment.CoordinateID,
+			Comment:      comment.Comment,
+			CreatedAt:    comment.CreatedAt,
+			UpdatedAt:    comment.UpdatedAt,
+		}
+	}
+
+	likes, err := u.repos.LikeCoordinate.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	archive.Likes = make([]exportLike, len(likes))
+	for i, like := range likes {
+		archive.Likes[i] = exportLike{CoordinateID: like.CoordinateID, CreatedAt: like.CreatedAt}
+	}
+
+	following, err := u.repos.Relationship.FindFollowing(ctx, user.ID, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	archive.Following = newExportUsers(following)
+
+	followers, err := u.repos.Relationship.FindFollowers(ctx, user.ID, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	archive.Followers = newExportUsers(followers)
+
+	blocked, err := u.repos.Block.FindBlockedUsers(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	archive.Blocks = newExportUsers(blocked)
+
+	notifications, err := u.repos.Notification.FindByReceiverID(ctx, user.ID, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	archive.Notifications = make([]exportNotification, len(notifications))
+	for i, notification := range notifications {
+		archive.Notifications[i] = exportNotification{
+			ID:           notification.ID,
+			SenderID:     notification.SenderID,
+			Action:       notification.Action,
+			CoordinateID: notification.CoordinateID,
+			CommentID:    notification.CommentID,
+			Checked:      notification.Checked,
+			CreatedAt:    notification.CreatedAt,
+		}
+	}
+
+	for _, picture := range pictures {
+		// Pictures linked from identity providers are not uploads
+		if picture != "" && !strings.Contains(picture, "://") {
+			archive.Pictures = append(archive.Pictures, picture)
+		}
+	}
+
+	return archive, nil
+}
+
+// newExportUsers keeps only the public identity of related users
+func newExportUsers(users []*domain.User) []exportUser {
+	result := make([]exportUser, len(users))
+	for i, user := range users {
+		result[i] = exportUser{ID: user.ID, Name: user.Name}
+	}
+	return result
+}
+
+// writeExportArchive writes one JSON file per entity and the user's pictures as a ZIP
+func writeExportArchive(w io.Writer, archive *exportArchive, uploadPath string) error {
+	zw := zip.NewWriter(w)
+
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", archive.Profile},
+		{"items.json", archive.Items},
+		{"categories.json", archive.Categories},
+		{"coordinates.json", archive.Coordinates},
+		{"wear_logs.json", archive.WearLogs},
+		{"comments.json", archive.Comments},
+		{"likes.json", archive.Likes},
+		{"following.json", archive.Following},
+		{"followers.json", archive.Followers},
+		{"blocks.json", archive.Blocks},
+		{"notifications.json", archive.Notifications},
+	}
+	for _, file := range files {
+		f, err := zw.Create(file.name)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(f)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.data); err != nil {
+			return err
+		}
+	}
+
+	seen := make(map[string]bool, len(archive.Pictures))
+	for _, picture := range archive.Pictures {
+		// Keep stored paths inside the upload directory
+		name := path.Clean("/" + filepath.ToSlash(picture))[1:]
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		if err := addExportPicture(zw, filepath.Join(uploadPath, filepath.FromSlash(name)), "pictures/"+name); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// addExportPicture copies an uploaded file into the archive; missing files are skipped
+func addExportPicture(zw *zip.Writer, source, name string) error {
+	src, err := os.Open(source)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer src.Close()
+
+	dst, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, src)
+	return err
+}
@@ -0,0 +1,219 @@
+package impl
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/dto"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/internal/testutil"
+	"github.com/House-lovers7/speadwear-go/pkg/config"
+	"github.com/House-lovers7/speadwear-go/pkg/mailer"
+)
+
+func setupExportUsecase(t *testing.T) (*exportUsecase, *testutil.Fixtures) {
+	db := testutil.TestDB(t)
+
+	cfg := &config.Config{
+		Upload: config.UploadConfig{
+			Path: t.TempDir(),
+		},
+		Export: config.ExportConfig{
+			Path:           t.TempDir(),
+			LinkExpiration: "72h",
+			JobTimeout:     "1h",
+		},
+	}
+
+	usecase := NewExportUsecase(repository.NewContainer(db), mailer.NewMemoryMailer(), cfg).(*exportUsecase)
+	// Run export jobs inline so results can be checked
+	usecase.async = func(job func()) { job() }
+
+	return usecase, testutil.NewFixtures(t, db)
+}
+
+func TestExportUsecase_RequestExport(t *testing.T) {
+	usecase, fixtures := setupExportUsecase(t)
+	ctx := context.Background()
+	outbox := usecase.mailer.(*mailer.MemoryMailer)
+
+	user := fixtures.CreateUser()
+	other := fixtures.CreateUser()
+	item := fixtures.CreateItem(user.ID, func(i *domain.Item) { i.Picture = "items/shirt.jpg" })
+	coordinate := fixtures.CreateCoordinate(other.ID)
+	fixtures.CreateComment(user.ID, coordinate.ID)
+	fixtures.CreateLike(user.ID, coordinate.ID)
+	fixtures.CreateRelationship(user.ID, other.ID)
+
+	os.MkdirAll(filepath.Join(usecase.config.Upload.Path, "items"), 0755)
+	os.WriteFile(filepath.Join(usecase.config.Upload.Path, item.Picture), []byte("shirt"), 0644)
+
+	export, err := usecase.RequestExport(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("RequestExport() error = %v", err)
+	}
+
+	latest, err := usecase.GetLatestExport(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetLatestExport() error = %v", err)
+	}
+	if latest.ID != export.ID || latest.Status != domain.ExportStatusCompleted {
+		t.Fatalf("GetLatestExport() = %+v, want completed export %d", latest, export.ID)
+	}
+
+	body := outbox.Last().Body
+	token := body[strings.Index(body, "token=")+len("token="):]
+	token, _ = url.QueryUnescape(strings.TrimSpace(token[:strings.Index(token, "\n")]))
+
+	opened, err := usecase.OpenExport(ctx, token)
+	if err != nil {
+		t.Fatalf("OpenExport() error = %v", err)
+	}
+
+	reader, err := zip.OpenReader(opened.FilePath)
+	if err != nil {
+		t.Fatalf("zip.OpenReader() error = %v", err)
+	}
+	files := make(map[string]*zip.File)
+	for _, f := range reader.File {
+		files[f.Name] = f
+	}
+	reader.Close()
+	for _, name := range []string{"profile.json", "items.json", "comments.json", "likes.json", "following.json", "pictures/items/shirt.jpg"} {
+		if files[name] == nil {
+			t.Errorf("archive is missing %s", name)
+		}
+	}
+
+	// A new export replaces the old archive and its link
+	if _, err := usecase.RequestExport(ctx, user.ID); err != nil {
+		t.Fatalf("RequestExport() error = %v", err)
+	}
+	if _, err := usecase.OpenExport(ctx, token); err == nil || err.Error() != "invalid or expired token" {
+		t.Errorf("OpenExport() error = %v, want invalid or expired token", err)
+	}
+	if _, err := os.Stat(opened.FilePath); !os.IsNotExist(err) {
+		t.Error("RequestExport() did not remove the previous archive")
+	}
+
+	// Expired links are refused
+	body = outbox.Last().Body
+	token = body[strings.Index(body, "token=")+len("token="):]
+	token, _ = url.QueryUnescape(strings.TrimSpace(token[:strings.Index(token, "\n")]))
+
+	latest, _ = usecase.GetLatestExport(ctx, user.ID)
+	expiredAt := time.Now().Add(-time.Minute)
+	latest.ExpiresAt = &expiredAt
+	usecase.repos.DataExport.Update(ctx, latest)
+
+	if _, err := usecase.OpenExport(ctx, token); err == nil || err.Error() != "invalid or expired token" {
+		t.Errorf("OpenExport() error = %v, want invalid or expired token", err)
+	}
+
+	// Only one export may run at a time
+	usecase.async = func(job func()) {}
+	if _, err := usecase.RequestExport(ctx, other.ID); err != nil {
+		t.Fatalf("RequestExport() error = %v", err)
+	}
+	if _, err := usecase.RequestExport(ctx, other.ID); err == nil || err.Error() != "export already in progress" {
+		t.Errorf("RequestExport() error = %v, want export already in progress", err)
+	}
+}
+
+func TestExportUsecase_StalledExport(t *testing.T) {
+	usecase, fixtures := setupExportUsecase(t)
+	ctx := context.Background()
+
+	// A job cut off by a restart two hours ago
+	user := fixtures.CreateUser()
+	cutOff := time.Now().Add(-2 * time.Hour)
+	stalled := &domain.DataExport{UserID: user.ID, Status: domain.ExportStatusProcessing}
+	stalled.CreatedAt, stalled.UpdatedAt = cutOff, cutOff
+	if err := usecase.repos.DataExport.Create(ctx, stalled); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	latest, err := usecase.GetLatestExport(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetLatestExport() error = %v", err)
+	}
+	if latest.Status != domain.ExportStatusFailed {
+		t.Errorf("GetLatestExport() status = %s, want failed", latest.Status)
+	}
+
+	export, err := usecase.RequestExport(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("RequestExport() error = %v", err)
+	}
+	if export.ID == stalled.ID {
+		t.Error("RequestExport() should queue a new export")
+	}
+
+	// A job that can't start is marked failed instead of blocking new ones
+	usecase.repos.User.Delete(ctx, user.ID)
+	failing := &domain.DataExport{UserID: user.ID, Status: domain.ExportStatusPending}
+	usecase.repos.DataExport.Create(ctx, failing)
+	usecase.runExport(failing.ID)
+	failed, _ := usecase.repos.DataExport.FindByID(ctx, failing.ID)
+	if failed.Status != domain.ExportStatusFailed {
+		t.Errorf("runExport() left status %s, want failed", failed.Status)
+	}
+}
+
+func TestWriteExportArchive(t *testing.T) {
+	base := t.TempDir()
+	uploads := filepath.Join(base, "uploads")
+	os.MkdirAll(filepath.Join(uploads, "items"), 0755)
+	os.WriteFile(filepath.Join(uploads, "items", "a.jpg"), []byte("image-a"), 0644)
+	// Stored paths must not reach outside the upload directory
+	os.WriteFile(filepath.Join(base, "secret.txt"), []byte("secret"), 0644)
+
+	archive := &exportArchive{
+		Profile:  dto.UserResponse{ID: 1, Name: "Taro"},
+		Items:    []dto.ItemResponse{{ID: 10, Picture: "items/a.jpg"}},
+		Pictures: []string{"items/a.jpg", "items/a.jpg", "items/missing.jpg", "../secret.txt"},
+	}
+
+	var buf bytes.Buffer
+	if err := writeExportArchive(&buf, archive, uploads); err != nil {
+		t.Fatalf("writeExportArchive() error = %v", err)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+
+	contents := make(map[string]string)
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("Open(%s) error = %v", f.Name, err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		contents[f.Name] = string(data)
+	}
+
+	if len(contents) != 12 {
+		t.Errorf("archive has %d files, want 11 JSON files and 1 picture", len(contents))
+	}
+	if contents["pictures/items/a.jpg"] != "image-a" {
+		t.Errorf("picture = %q, want image-a", contents["pictures/items/a.jpg"])
+	}
+	if !strings.Contains(contents["profile.json"], `"name": "Taro"`) {
+		t.Errorf("profile.json = %s", contents["profile.json"])
+	}
+	if !strings.Contains(contents["items.json"], `"id": 10`) {
+		t.Errorf("items.json = %s", contents["items.json"])
+	}
+}
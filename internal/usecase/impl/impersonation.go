@@ -0,0 +1,68 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/dto"
+	"github.com/House-lovers7/speadwear-go/pkg/utils"
+)
+
+// ImpersonateUser issues a short-lived, read-only access token for acting as a
+// user. The token rides on the actor's session, so ending that session or
+// losing the permission ends the impersonation too.
+func (u *adminUsecase) ImpersonateUser(ctx context.Context, actorID, sessionID, userID uint, reason string) (*dto.ImpersonationResponse, error) {
+	if actorID == userID {
+		return nil, errors.New("cannot impersonate own account")
+	}
+	if sessionID == 0 {
+		return nil, errors.New("session required")
+	}
+
+	user, err := u.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	// Acting as another staff member could widen the actor's permissions
+	if user.HasPermission(domain.PermissionAdminAccess) {
+		return nil, errors.New("cannot impersonate staff accounts")
+	}
+	if user.IsSuspended(time.Now()) {
+		return nil, errors.New("user is suspended")
+	}
+
+	expiration, err := time.ParseDuration(u.config.Auth.ImpersonationExpiration)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(expiration)
+
+	// No token is handed out unless the audit trail has it
+	err = u.audit.Record(ctx, actorID, domain.AuditActionUserImpersonate, domain.AuditTargetUser, userID, map[string]interface{}{
+		"reason":     reason,
+		"session_id": sessionID,
+		"expires_at": expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := u.keys.Sign(&utils.Claims{
+		UserID:         user.ID,
+		Email:          user.Email,
+		SessionID:      sessionID,
+		ImpersonatorID: actorID,
+	}, u.config.Auth.ImpersonationExpiration)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ImpersonationResponse{
+		Token:     token,
+		ExpiresAt: expiresAt,
+		UserID:    user.ID,
+		ReadOnly:  true,
+	}, nil
+}
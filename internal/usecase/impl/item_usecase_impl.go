@@ -3,46 +3,69 @@ package impl
 import (
 	"context"
 	"errors"
-	"fmt"
-	"io"
 	"mime/multipart"
-	"os"
-	"path/filepath"
+	"strings"
 	"time"
 	
 	"github.com/House-lovers7/speadwear-go/internal/domain"
 	"github.com/House-lovers7/speadwear-go/internal/repository"
 	"github.com/House-lovers7/speadwear-go/internal/usecase"
 	"github.com/House-lovers7/speadwear-go/pkg/config"
+	"github.com/House-lovers7/speadwear-go/pkg/storage"
 )
 
 type itemUsecase struct {
-	itemRepo repository.ItemRepository
-	config   *config.Config
+	itemRepo     repository.ItemRepository
+	userRepo     repository.UserRepository
+	wearLogRepo  repository.WearLogRepository
+	categoryRepo repository.CategoryRepository
+	storage      storage.Storage
+	audit        usecase.AuditLogger
+	config       *config.Config
 }
 
 // NewItemUsecase creates a new item usecase
-func NewItemUsecase(itemRepo repository.ItemRepository, config *config.Config) usecase.ItemUsecase {
+func NewItemUsecase(itemRepo repository.ItemRepository, userRepo repository.UserRepository, wearLogRepo repository.WearLogRepository, categoryRepo repository.CategoryRepository, storage storage.Storage, audit usecase.AuditLogger, config *config.Config) usecase.ItemUsecase {
 	return &itemUsecase{
-		itemRepo: itemRepo,
-		config:   config,
+		itemRepo:     itemRepo,
+		userRepo:     userRepo,
+		wearLogRepo:  wearLogRepo,
+		categoryRepo: categoryRepo,
+		storage:      storage,
+		audit:        audit,
+		config:       config,
 	}
 }
 
 // CreateItem creates a new item
 func (u *itemUsecase) CreateItem(ctx context.Context, userID uint, item *domain.Item, image *multipart.FileHeader) error {
 	item.UserID = userID
+	item.Currency = u.itemCurrency(item)
+	if item.Status == "" {
+		item.Status = domain.ItemStatusActive
+	}
+	
+	tree, err := loadCategoryTree(ctx, u.categoryRepo, userID)
+	if err != nil {
+		return err
+	}
+	if err := tree.file(item); err != nil {
+		return err
+	}
 	
 	// Upload image if provided
 	if image != nil {
-		filename, err := u.uploadImage(image, "items")
+		filename, err := u.storage.Save(image, "items")
 		if err != nil {
 			return err
 		}
 		item.Picture = filename
 	}
 	
-	return u.itemRepo.Create(ctx, item)
+	if err := u.itemRepo.Create(ctx, item); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, userID, domain.AuditActionItemCreate, domain.AuditTargetItem, item.ID, nil)
 }
 
 // GetItem gets an item by ID
@@ -54,6 +77,12 @@ func (u *itemUsecase) GetItem(ctx context.Context, itemID uint) (*domain.Item, e
 	if item == nil {
 		return nil, errors.New("item not found")
 	}
+	if err := checkOwnerVisible(ctx, u.userRepo, item.UserID, "item not found"); err != nil {
+		return nil, err
+	}
+	if err := attachItemWearStats(ctx, u.wearLogRepo, []*domain.Item{item}); err != nil {
+		return nil, err
+	}
 	return item, nil
 }
 
@@ -73,43 +102,129 @@ func (u *itemUsecase) UpdateItem(ctx context.Context, userID uint, itemID uint,
 	}
 	
 	// Apply updates
-	if superItem, ok := updates["super_item"].(string); ok {
-		item.SuperItem = superItem
+	changes := usecase.Changes{}
+	categoryID, hasCategoryID := updates["category_id"].(uint)
+	superItem, hasSuperItem := updates["super_item"].(string)
+	if hasCategoryID || hasSuperItem {
+		previousCategoryID, previousSuperItem := itemCategoryValue(item), item.SuperItem
+		if hasCategoryID {
+			item.CategoryID = &categoryID
+		} else {
+			item.CategoryID = nil
+			item.SuperItem = superItem
+		}
+		tree, err := loadCategoryTree(ctx, u.categoryRepo, userID)
+		if err != nil {
+			return err
+		}
+		if err := tree.file(item); err != nil {
+			return err
+		}
+		changes.Track("category_id", previousCategoryID, itemCategoryValue(item))
+		changes.Track("super_item", previousSuperItem, item.SuperItem)
 	}
 	if season, ok := updates["season"].(int); ok {
+		changes.Track("season", item.Season, season)
 		item.Season = season
 	}
 	if tpo, ok := updates["tpo"].(int); ok {
+		changes.Track("tpo", item.TPO, tpo)
 		item.TPO = tpo
 	}
 	if color, ok := updates["color"].(int); ok {
+		changes.Track("color", item.Color, color)
 		item.Color = color
 	}
 	if content, ok := updates["content"].(string); ok {
+		changes.Track("content", item.Content, content)
 		item.Content = content
 	}
 	if memo, ok := updates["memo"].(string); ok {
+		changes.Track("memo", item.Memo, memo)
 		item.Memo = memo
 	}
 	if rating, ok := updates["rating"].(float32); ok {
+		changes.Track("rating", item.Rating, rating)
 		item.Rating = rating
 	}
+	if price, ok := updates["purchase_price"].(float64); ok {
+		changes.Track("purchase_price", purchasePriceValue(item), price)
+		item.PurchasePrice = &price
+	}
+	currency := item.Currency
+	if newCurrency, ok := updates["currency"].(string); ok {
+		item.Currency = newCurrency
+	}
+	item.Currency = u.itemCurrency(item)
+	changes.Track("currency", currency, item.Currency)
+	if purchasedOn, ok := updates["purchased_on"].(time.Time); ok {
+		changes.Track("purchased_on", purchasedOnValue(item), purchasedOn.Format(domain.DateLayout))
+		item.PurchasedOn = &purchasedOn
+	}
 	
 	// Upload new image if provided
 	if image != nil {
 		// Delete old image if exists
 		if item.Picture != "" {
-			u.deleteImage(item.Picture)
+			u.storage.Delete(item.Picture)
 		}
 		
-		filename, err := u.uploadImage(image, "items")
+		filename, err := u.storage.Save(image, "items")
 		if err != nil {
 			return err
 		}
+		changes.Track("picture", item.Picture, filename)
 		item.Picture = filename
 	}
 	
-	return u.itemRepo.Update(ctx, item)
+	if err := u.itemRepo.Update(ctx, item); err != nil {
+		return err
+	}
+	return u.audit.RecordChanges(ctx, userID, domain.AuditActionItemUpdate, domain.AuditTargetItem, itemID, changes)
+}
+
+// ChangeItemStatus moves an item to another lifecycle status
+func (u *itemUsecase) ChangeItemStatus(ctx context.Context, userID uint, itemID uint, status string) error {
+	item, err := u.itemRepo.FindByID(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		return errors.New("item not found")
+	}
+
+	// Check ownership
+	if item.UserID != userID {
+		return errors.New("unauthorized")
+	}
+
+	if !isItemStatus(status) {
+		return errors.New("invalid item status")
+	}
+	from := item.Status
+	if from == "" {
+		from = domain.ItemStatusActive
+	}
+	if from == status {
+		return errors.New("item already has this status")
+	}
+
+	now := time.Now()
+	item.Status = status
+	item.StatusChangedAt = &now
+	change := &domain.ItemStatusChange{
+		ItemID:     item.ID,
+		FromStatus: from,
+		ToStatus:   status,
+		ChangedAt:  now,
+	}
+	if err := u.itemRepo.ChangeStatus(ctx, item, change); err != nil {
+		return err
+	}
+
+	changes := usecase.Changes{}
+	changes.Track("status", from, status)
+	return u.audit.RecordChanges(ctx, userID, domain.AuditActionItemStatus, domain.AuditTargetItem, itemID, changes)
 }
 
 // DeleteItem deletes an item
@@ -129,30 +244,58 @@ func (u *itemUsecase) DeleteItem(ctx context.Context, userID uint, itemID uint)
 	
 	// Delete image if exists
 	if item.Picture != "" {
-		u.deleteImage(item.Picture)
+		u.storage.Delete(item.Picture)
 	}
 	
-	return u.itemRepo.Delete(ctx, itemID)
+	if err := u.itemRepo.Delete(ctx, itemID); err != nil {
+		return err
+	}
+	return u.recordItemDeleted(ctx, userID, item)
 }
 
-// GetUserItems gets items for a user
+// GetUserItems gets the items a user still has in their wardrobe
 func (u *itemUsecase) GetUserItems(ctx context.Context, userID uint, limit, offset int) ([]*domain.Item, int64, error) {
-	items, err := u.itemRepo.FindByUserID(ctx, userID, limit, offset)
+	if _, err := findVisibleUser(ctx, u.userRepo, userID); err != nil {
+		return nil, 0, err
+	}
+	
+	items, err := u.itemRepo.FindInWardrobeByUserID(ctx, userID, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
 	
-	count, err := u.itemRepo.CountByUserID(ctx, userID)
+	count, err := u.itemRepo.CountInWardrobeByUserID(ctx, userID)
 	if err != nil {
 		return nil, 0, err
 	}
 	
+	if err := attachItemWearStats(ctx, u.wearLogRepo, items); err != nil {
+		return nil, 0, err
+	}
 	return items, count, nil
 }
 
 // SearchItems searches items with filters
 func (u *itemUsecase) SearchItems(ctx context.Context, filters repository.ItemFilter) ([]*domain.Item, error) {
-	return u.itemRepo.FindByFilters(ctx, filters)
+	if len(filters.CategoryIDs) > 0 {
+		categoryIDs, err := u.categorySubtreeIDs(ctx, filters.CategoryIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(categoryIDs) == 0 {
+			return []*domain.Item{}, nil
+		}
+		filters.CategoryIDs = categoryIDs
+	}
+	
+	items, err := u.itemRepo.FindByFilters(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachItemWearStats(ctx, u.wearLogRepo, items); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 // DeleteUserItems deletes multiple items for a user
@@ -173,18 +316,22 @@ func (u *itemUsecase) DeleteUserItems(ctx context.Context, userID uint, itemIDs
 		
 		// Delete image if exists
 		if item.Picture != "" {
-			u.deleteImage(item.Picture)
+			u.storage.Delete(item.Picture)
 		}
 		
 		if err := u.itemRepo.Delete(ctx, itemID); err != nil {
 			return err
 		}
+		if err := u.recordItemDeleted(ctx, userID, item); err != nil {
+			return err
+		}
 	}
 	
 	return nil
 }
 
-// GetUserItemStatistics gets item statistics for a user
+// GetUserItemStatistics gets item statistics for a user. The breakdowns
+// cover items still in the wardrobe; retired items are counted per year left
 func (u *itemUsecase) GetUserItemStatistics(ctx context.Context, userID uint) (map[string]interface{}, error) {
 	items, err := u.itemRepo.FindByUserID(ctx, userID, 0, 0)
 	if err != nil {
@@ -200,11 +347,31 @@ func (u *itemUsecase) GetUserItemStatistics(ctx context.Context, userID uint) (m
 	seasonCount := make(map[int]int)
 	tpoCount := make(map[int]int)
 	colorCount := make(map[int]int)
+	statusCount := make(map[string]int)
+	leftByYear := make(map[int]map[string]int)
+	inWardrobe := 0
 	
 	var totalRating float32
 	ratedCount := 0
 	
 	for _, item := range items {
+		status := item.Status
+		if status == "" {
+			status = domain.ItemStatusActive
+		}
+		statusCount[status]++
+		if item.IsRetired() {
+			if item.StatusChangedAt != nil {
+				year := item.StatusChangedAt.Year()
+				if leftByYear[year] == nil {
+					leftByYear[year] = make(map[string]int)
+				}
+				leftByYear[year][status]++
+			}
+			continue
+		}
+		inWardrobe++
+
 		categoryCount[item.SuperItem]++
 		seasonCount[item.Season]++
 		tpoCount[item.TPO]++
@@ -220,6 +387,9 @@ func (u *itemUsecase) GetUserItemStatistics(ctx context.Context, userID uint) (m
 	stats["season_count"] = seasonCount
 	stats["tpo_count"] = tpoCount
 	stats["color_count"] = colorCount
+	stats["in_wardrobe_count"] = inWardrobe
+	stats["status_count"] = statusCount
+	stats["left_wardrobe_by_year"] = leftByYear
 	
 	if ratedCount > 0 {
 		stats["average_rating"] = totalRating / float32(ratedCount)
@@ -230,52 +400,81 @@ func (u *itemUsecase) GetUserItemStatistics(ctx context.Context, userID uint) (m
 	return stats, nil
 }
 
-// uploadImage uploads an image file
-func (u *itemUsecase) uploadImage(file *multipart.FileHeader, folder string) (string, error) {
-	// Check file size
-	if file.Size > u.config.Upload.MaxFileSize {
-		return "", errors.New("file size exceeds limit")
+// isItemStatus reports whether status is a known item lifecycle status
+func isItemStatus(status string) bool {
+	for _, s := range domain.ItemStatuses {
+		if s == status {
+			return true
+		}
 	}
-	
-	// Open file
-	src, err := file.Open()
-	if err != nil {
-		return "", err
+	return false
+}
+
+// itemCurrency normalizes the item's currency code, falling back to the
+// default currency for priced items entered without one
+func (u *itemUsecase) itemCurrency(item *domain.Item) string {
+	currency := strings.ToUpper(item.Currency)
+	if currency == "" && item.PurchasePrice != nil {
+		currency = u.config.App.DefaultCurrency
 	}
-	defer src.Close()
-	
-	// Generate unique filename
-	ext := filepath.Ext(file.Filename)
-	filename := fmt.Sprintf("%s/%d_%d%s", folder, time.Now().Unix(), time.Now().Nanosecond(), ext)
-	
-	// Create upload directory if not exists
-	uploadPath := filepath.Join(u.config.Upload.Path, folder)
-	if err := os.MkdirAll(uploadPath, 0755); err != nil {
-		return "", err
+	return currency
+}
+
+// categorySubtreeIDs expands categories to themselves and all their
+// sub-categories, skipping unknown ones
+func (u *itemUsecase) categorySubtreeIDs(ctx context.Context, categoryIDs []uint) ([]uint, error) {
+	var ids []uint
+	trees := make(map[uint]*categoryTree)
+	for _, categoryID := range categoryIDs {
+		category, err := u.categoryRepo.FindByID(ctx, categoryID)
+		if err != nil {
+			return nil, err
+		}
+		if category == nil {
+			continue
+		}
+		tree, ok := trees[category.UserID]
+		if !ok {
+			categories, err := u.categoryRepo.FindByUserID(ctx, category.UserID)
+			if err != nil {
+				return nil, err
+			}
+			tree = newCategoryTree(categories)
+			trees[category.UserID] = tree
+		}
+		ids = append(ids, tree.subtreeIDs(tree.byID[category.ID])...)
 	}
-	
-	// Create destination file
-	dstPath := filepath.Join(u.config.Upload.Path, filename)
-	dst, err := os.Create(dstPath)
-	if err != nil {
-		return "", err
+	return ids, nil
+}
+
+// itemCategoryValue returns the item's category ID for the audit trail, nil if unset
+func itemCategoryValue(item *domain.Item) interface{} {
+	if item.CategoryID == nil {
+		return nil
 	}
-	defer dst.Close()
-	
-	// Copy file
-	if _, err = io.Copy(dst, src); err != nil {
-		return "", err
+	return *item.CategoryID
+}
+
+// purchasePriceValue returns the item's price for the audit trail, nil if unset
+func purchasePriceValue(item *domain.Item) interface{} {
+	if item.PurchasePrice == nil {
+		return nil
 	}
-	
-	return filename, nil
+	return *item.PurchasePrice
 }
 
-// deleteImage deletes an image file
-func (u *itemUsecase) deleteImage(filename string) error {
-	if filename == "" {
+// purchasedOnValue returns the item's purchase date for the audit trail, nil if unset
+func purchasedOnValue(item *domain.Item) interface{} {
+	if item.PurchasedOn == nil {
 		return nil
 	}
-	
-	path := filepath.Join(u.config.Upload.Path, filename)
-	return os.Remove(path)
-}
\ No newline at end of file
+	return item.PurchasedOn.Format(domain.DateLayout)
+}
+
+// recordItemDeleted audits an item deletion, keeping what the item was
+func (u *itemUsecase) recordItemDeleted(ctx context.Context, userID uint, item *domain.Item) error {
+	return u.audit.Record(ctx, userID, domain.AuditActionItemDelete, domain.AuditTargetItem, item.ID, map[string]interface{}{
+		"super_item": item.SuperItem,
+		"content":    item.Content,
+	})
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"mime/multipart"
 	"testing"
+	"time"
 
 	"github.com/House-lovers7/speadwear-go/internal/domain"
 	"github.com/House-lovers7/speadwear-go/internal/repository"
 	"github.com/House-lovers7/speadwear-go/internal/testutil"
 	"github.com/House-lovers7/speadwear-go/pkg/config"
+	"github.com/House-lovers7/speadwear-go/pkg/storage"
 )
 
 func setupItemUsecase(t *testing.T) (*itemUsecase, *testutil.Fixtures) {
@@ -24,6 +26,11 @@ func setupItemUsecase(t *testing.T) (*itemUsecase, *testutil.Fixtures) {
 	
 	usecase := NewItemUsecase(
 		repos.Item,
+		repos.User,
+		repos.WearLog,
+		repos.Category,
+		storage.NewLocalStorage(cfg.Upload.Path, cfg.Upload.MaxFileSize),
+		NewAuditLogger(repos.AuditEvent),
 		cfg,
 	).(*itemUsecase)
 	
@@ -51,7 +58,7 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 			name:   "valid item creation",
 			userID: user.ID,
 			item: &domain.Item{
-				SuperItem: "シャツ",
+				SuperItem: "トップス",
 				Color:     domain.ColorBlue,
 				Season:    domain.SeasonSpring,
 				TPO:       domain.TPOWork,
@@ -70,7 +77,19 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 				Color:     domain.ColorBlue,
 			},
 			image:   nil,
-			wantErr: false, // No validation in usecase
+			wantErr: true,
+			errMsg:  "category required",
+		},
+		{
+			name:   "item with unknown super item",
+			userID: user.ID,
+			item: &domain.Item{
+				SuperItem: "シャツ",
+				Color:     domain.ColorBlue,
+			},
+			image:   nil,
+			wantErr: true,
+			errMsg:  "category not found",
 		},
 	}
 	
@@ -96,6 +115,9 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 				if tt.item.UserID != tt.userID {
 					t.Errorf("CreateItem() UserID = %v, want %v", tt.item.UserID, tt.userID)
 				}
+				if tt.item.CategoryID == nil {
+					t.Error("CreateItem() did not file the item under a category")
+				}
 			}
 		})
 	}
@@ -109,6 +131,13 @@ func TestItemUsecase_GetItem(t *testing.T) {
 	user := fixtures.CreateUser()
 	item := fixtures.CreateItem(user.ID)
 	
+	// Items of suspended users and accounts awaiting deletion are hidden
+	now := time.Now()
+	suspended := fixtures.CreateUser(func(u *domain.User) { u.SuspendedAt = &now })
+	leaving := fixtures.CreateUser(func(u *domain.User) { u.DeletionScheduledAt = &now })
+	suspendedItem := fixtures.CreateItem(suspended.ID)
+	leavingItem := fixtures.CreateItem(leaving.ID)
+	
 	tests := []struct {
 		name    string
 		itemID  uint
@@ -119,6 +148,16 @@ func TestItemUsecase_GetItem(t *testing.T) {
 			itemID:  item.ID,
 			wantErr: false,
 		},
+		{
+			name:    "item of a suspended user",
+			itemID:  suspendedItem.ID,
+			wantErr: true,
+		},
+		{
+			name:    "item of an account awaiting deletion",
+			itemID:  leavingItem.ID,
+			wantErr: true,
+		},
 		{
 			name:    "non-existent item",
 			itemID:  99999,
@@ -175,7 +214,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 			userID: user.ID,
 			itemID: item.ID,
 			updates: map[string]interface{}{
-				"super_item": "Updated Item",
+				"super_item": "ボトムス",
 				"content":    "Updated content",
 				"rating":     float32(5),
 			},
@@ -312,6 +351,64 @@ func TestItemUsecase_DeleteItem(t *testing.T) {
 	}
 }
 
+func TestItemUsecase_AuditTrail(t *testing.T) {
+	usecase, fixtures := setupItemUsecase(t)
+	ctx := context.Background()
+	auditEvents := usecase.audit.(*auditLogger).auditEventRepo
+
+	user := fixtures.CreateUser()
+	item := fixtures.CreateItem(user.ID, func(i *domain.Item) {
+		i.Memo = "old memo"
+		i.Rating = 3
+	})
+
+	// Only the fields that actually changed are recorded
+	err := usecase.UpdateItem(ctx, user.ID, item.ID, map[string]interface{}{
+		"memo":   "new memo",
+		"rating": float32(3),
+	}, nil)
+	if err != nil {
+		t.Fatalf("UpdateItem() error = %v", err)
+	}
+
+	events, total, err := auditEvents.Search(ctx, repository.AuditEventFilter{
+		ActorID: &user.ID,
+		Action:  domain.AuditActionItemUpdate,
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if total != 1 || len(events) != 1 {
+		t.Fatalf("expected 1 update event, got %d", total)
+	}
+	if events[0].TargetID != item.ID || events[0].TargetType != domain.AuditTargetItem {
+		t.Errorf("unexpected target %s/%d", events[0].TargetType, events[0].TargetID)
+	}
+	if want := `{"memo":{"from":"old memo","to":"new memo"}}`; events[0].Changes != want {
+		t.Errorf("Changes = %s, want %s", events[0].Changes, want)
+	}
+
+	// An update that changes nothing is not recorded
+	if err := usecase.UpdateItem(ctx, user.ID, item.ID, map[string]interface{}{"memo": "new memo"}, nil); err != nil {
+		t.Fatalf("UpdateItem() error = %v", err)
+	}
+
+	if err := usecase.DeleteItem(ctx, user.ID, item.ID); err != nil {
+		t.Fatalf("DeleteItem() error = %v", err)
+	}
+
+	_, total, err = auditEvents.Search(ctx, repository.AuditEventFilter{
+		TargetType: domain.AuditTargetItem,
+		TargetID:   &item.ID,
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if total != 2 {
+		t.Errorf("expected update and delete events, got %d", total)
+	}
+}
+
 func TestItemUsecase_GetUserItems(t *testing.T) {
 	usecase, fixtures := setupItemUsecase(t)
 	ctx := context.Background()
@@ -509,4 +606,236 @@ func strPtr(s string) *string {
 
 func intPtr(i int) *int {
 	return &i
-}
\ No newline at end of file
+}
+
+func TestItemUsecase_GetWardrobeAnalytics(t *testing.T) {
+	usecase, fixtures := setupItemUsecase(t)
+	ctx := context.Background()
+	usecase.config.App.DefaultCurrency = "JPY"
+
+	user := fixtures.CreateUser()
+	priced := func(superItem string, price float64, purchasedOn string) func(*domain.Item) {
+		return func(i *domain.Item) {
+			i.SuperItem = superItem
+			i.PurchasePrice = &price
+			i.Currency = "JPY"
+			date, _ := time.ParseInLocation(domain.DateLayout, purchasedOn, time.Local)
+			i.PurchasedOn = &date
+		}
+	}
+	coat := fixtures.CreateItem(user.ID, priced("アウター", 30000, "2024-01-10"))
+	shirt := fixtures.CreateItem(user.ID, priced("トップス", 4000, "2024-03-02"))
+	unworn := fixtures.CreateItem(user.ID, priced("トップス", 8000, "2024-03-20"))
+	fixtures.CreateItem(user.ID, func(i *domain.Item) {
+		price := 50.0
+		i.PurchasePrice = &price
+		i.Currency = "USD"
+	})
+	fixtures.CreateItem(user.ID) // no price
+
+	// The coat is worn 3 times, the shirt 4 times
+	for day := 1; day <= 4; day++ {
+		wearLog := &domain.WearLog{
+			UserID: user.ID,
+			WornOn: time.Date(2024, 4, day, 0, 0, 0, 0, time.Local),
+			Items:  []domain.WearLogItem{{ItemID: shirt.ID}},
+		}
+		if day < 4 {
+			wearLog.Items = append(wearLog.Items, domain.WearLogItem{ItemID: coat.ID})
+		}
+		if err := usecase.wearLogRepo.Create(ctx, wearLog); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	analytics, err := usecase.GetWardrobeAnalytics(ctx, user.ID, "")
+	if err != nil {
+		t.Fatalf("GetWardrobeAnalytics() error = %v", err)
+	}
+
+	if analytics.Currency != "JPY" || len(analytics.Currencies) != 2 {
+		t.Errorf("Currency = %s, Currencies = %v", analytics.Currency, analytics.Currencies)
+	}
+	if analytics.ItemCount != 5 || analytics.PricedItemCount != 3 {
+		t.Errorf("ItemCount = %d, PricedItemCount = %d", analytics.ItemCount, analytics.PricedItemCount)
+	}
+	if analytics.TotalValue != 42000 || analytics.TotalWears != 7 || analytics.CostPerWear != 6000 {
+		t.Errorf("TotalValue = %v, TotalWears = %d, CostPerWear = %v", analytics.TotalValue, analytics.TotalWears, analytics.CostPerWear)
+	}
+
+	if len(analytics.MonthlySpending) != 2 ||
+		analytics.MonthlySpending[0].Key != "2024-01" ||
+		analytics.MonthlySpending[1].Key != "2024-03" || analytics.MonthlySpending[1].Amount != 12000 {
+		t.Errorf("unexpected monthly spending %+v", analytics.MonthlySpending)
+	}
+	if len(analytics.CategorySpending) != 2 || analytics.CategorySpending[0].Key != "アウター" {
+		t.Errorf("unexpected category spending %+v", analytics.CategorySpending)
+	}
+
+	// Best value only ranks worn items; an unworn item costs its full price
+	if len(analytics.BestValue) != 2 || analytics.BestValue[0].Item.ID != shirt.ID || analytics.BestValue[0].CostPerWear != 1000 {
+		t.Errorf("unexpected best value %+v", analytics.BestValue)
+	}
+	if len(analytics.WorstValue) != 3 || analytics.WorstValue[0].Item.ID != coat.ID || analytics.WorstValue[1].Item.ID != unworn.ID {
+		t.Errorf("unexpected worst value %+v", analytics.WorstValue)
+	}
+
+	usd, err := usecase.GetWardrobeAnalytics(ctx, user.ID, "usd")
+	if err != nil {
+		t.Fatalf("GetWardrobeAnalytics() error = %v", err)
+	}
+	if usd.Currency != "USD" || usd.TotalValue != 50 || usd.PricedItemCount != 1 {
+		t.Errorf("unexpected USD analytics %+v", usd)
+	}
+}
+
+func TestItemUsecase_ChangeItemStatus(t *testing.T) {
+	usecase, fixtures := setupItemUsecase(t)
+	ctx := context.Background()
+
+	user := fixtures.CreateUser()
+	other := fixtures.CreateUser()
+	shirt := fixtures.CreateItem(user.ID)
+	coat := fixtures.CreateItem(user.ID, func(i *domain.Item) {
+		i.SuperItem = "アウター"
+	})
+
+	if err := usecase.ChangeItemStatus(ctx, other.ID, coat.ID, domain.ItemStatusDonated); err == nil || err.Error() != "unauthorized" {
+		t.Errorf("ChangeItemStatus() by another user error = %v", err)
+	}
+	if err := usecase.ChangeItemStatus(ctx, user.ID, coat.ID, domain.ItemStatusActive); err == nil || err.Error() != "item already has this status" {
+		t.Errorf("ChangeItemStatus() to the same status error = %v", err)
+	}
+	if err := usecase.ChangeItemStatus(ctx, user.ID, coat.ID, "lost"); err == nil || err.Error() != "invalid item status" {
+		t.Errorf("ChangeItemStatus() to an unknown status error = %v", err)
+	}
+
+	if err := usecase.ChangeItemStatus(ctx, user.ID, coat.ID, domain.ItemStatusLent); err != nil {
+		t.Fatalf("ChangeItemStatus() error = %v", err)
+	}
+	if err := usecase.ChangeItemStatus(ctx, user.ID, coat.ID, domain.ItemStatusDonated); err != nil {
+		t.Fatalf("ChangeItemStatus() error = %v", err)
+	}
+
+	item, err := usecase.GetItem(ctx, coat.ID)
+	if err != nil {
+		t.Fatalf("GetItem() error = %v", err)
+	}
+	if item.Status != domain.ItemStatusDonated || item.StatusChangedAt == nil {
+		t.Errorf("Status = %s, StatusChangedAt = %v", item.Status, item.StatusChangedAt)
+	}
+	if len(item.StatusChanges) != 2 ||
+		item.StatusChanges[0].FromStatus != domain.ItemStatusActive ||
+		item.StatusChanges[1].FromStatus != domain.ItemStatusLent ||
+		item.StatusChanges[1].ToStatus != domain.ItemStatusDonated {
+		t.Errorf("unexpected status history %+v", item.StatusChanges)
+	}
+
+	// Retired items leave the wardrobe listing but stay in the statistics
+	items, total, err := usecase.GetUserItems(ctx, user.ID, 10, 0)
+	if err != nil {
+		t.Fatalf("GetUserItems() error = %v", err)
+	}
+	if total != 1 || len(items) != 1 || items[0].ID != shirt.ID {
+		t.Errorf("GetUserItems() = %d items, total %d", len(items), total)
+	}
+
+	stats, err := usecase.GetUserItemStatistics(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetUserItemStatistics() error = %v", err)
+	}
+	if stats["total_count"] != 2 || stats["in_wardrobe_count"] != 1 {
+		t.Errorf("total_count = %v, in_wardrobe_count = %v", stats["total_count"], stats["in_wardrobe_count"])
+	}
+	if _, ok := stats["category_count"].(map[string]int)["アウター"]; ok {
+		t.Error("retired item counted in category_count")
+	}
+	leftByYear := stats["left_wardrobe_by_year"].(map[int]map[string]int)
+	if leftByYear[time.Now().Year()][domain.ItemStatusDonated] != 1 {
+		t.Errorf("unexpected left_wardrobe_by_year %v", leftByYear)
+	}
+}
+
+func TestItemUsecase_Categories(t *testing.T) {
+	usecase, fixtures := setupItemUsecase(t)
+	ctx := context.Background()
+	categories := NewCategoryUsecase(usecase.categoryRepo, usecase.audit)
+
+	user := fixtures.CreateUser()
+	tree, err := loadCategoryTree(ctx, usecase.categoryRepo, user.ID)
+	if err != nil {
+		t.Fatalf("loadCategoryTree() error = %v", err)
+	}
+	tops, bottoms := tree.childNamed(0, "トップス"), tree.childNamed(0, "ボトムス")
+	shirts := &domain.Category{Name: "シャツ", ParentID: &tops.ID}
+	if err := categories.CreateCategory(ctx, user.ID, shirts); err != nil {
+		t.Fatalf("CreateCategory() error = %v", err)
+	}
+	oxford := &domain.Category{Name: "オックスフォード", ParentID: &shirts.ID}
+	if err := categories.CreateCategory(ctx, user.ID, oxford); err != nil {
+		t.Fatalf("CreateCategory() error = %v", err)
+	}
+
+	// An item filed by category ID gets its top-level category as super item
+	shirt := &domain.Item{CategoryID: &oxford.ID, SuperItem: "ボトムス"}
+	if err := usecase.CreateItem(ctx, user.ID, shirt, nil); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+	if shirt.SuperItem != "トップス" {
+		t.Errorf("SuperItem = %q, want トップス", shirt.SuperItem)
+	}
+	tee := &domain.Item{SuperItem: "トップス"}
+	if err := usecase.CreateItem(ctx, user.ID, tee, nil); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+	pants := &domain.Item{CategoryID: &bottoms.ID}
+	if err := usecase.CreateItem(ctx, user.ID, pants, nil); err != nil {
+		t.Fatalf("CreateItem() error = %v", err)
+	}
+
+	other := fixtures.CreateUser()
+	if err := usecase.CreateItem(ctx, other.ID, &domain.Item{CategoryID: &oxford.ID}, nil); err == nil || err.Error() != "category not found" {
+		t.Errorf("CreateItem() in another user's category error = %v", err)
+	}
+
+	// Searching a category matches everything below it
+	tests := []struct {
+		name     string
+		category *domain.Category
+		want     []uint
+	}{
+		{"top-level category", tops, []uint{shirt.ID, tee.ID}},
+		{"sub-category", shirts, []uint{shirt.ID}},
+		{"leaf category", bottoms, []uint{pants.ID}},
+		{"unknown category", &domain.Category{BaseModel: domain.BaseModel{ID: 99999}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := usecase.SearchItems(ctx, repository.ItemFilter{CategoryIDs: []uint{tt.category.ID}})
+			if err != nil {
+				t.Fatalf("SearchItems() error = %v", err)
+			}
+			if len(items) != len(tt.want) {
+				t.Fatalf("SearchItems() returned %d items, want %d", len(items), len(tt.want))
+			}
+			found := make(map[uint]bool)
+			for _, item := range items {
+				found[item.ID] = true
+			}
+			for _, id := range tt.want {
+				if !found[id] {
+					t.Errorf("SearchItems() did not return item %d", id)
+				}
+			}
+		})
+	}
+
+	// Moving an item to another category updates its super item
+	if err := usecase.UpdateItem(ctx, user.ID, tee.ID, map[string]interface{}{"category_id": bottoms.ID}, nil); err != nil {
+		t.Fatalf("UpdateItem() error = %v", err)
+	}
+	moved, _ := usecase.GetItem(ctx, tee.ID)
+	if moved.SuperItem != "ボトムス" || moved.CategoryID == nil || *moved.CategoryID != bottoms.ID {
+		t.Errorf("UpdateItem() left the item in %v (%s)", moved.CategoryID, moved.SuperItem)
+	}
+}
@@ -0,0 +1,122 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/pkg/config"
+)
+
+const (
+	// Failed attempts allowed before progressive delays kick in
+	loginFreeAttempts = 2
+	loginBaseDelay    = time.Second
+	loginMaxDelay     = 30 * time.Second
+)
+
+// loginGuard throttles password logins per account and per client IP
+type loginGuard struct {
+	store         repository.LoginAttemptStore
+	maxAttempts   int
+	ipMaxAttempts int
+	lockout       time.Duration
+	now           func() time.Time
+}
+
+func newLoginGuard(store repository.LoginAttemptStore, cfg *config.Config) *loginGuard {
+	lockout, _ := time.ParseDuration(cfg.Auth.LoginLockoutDuration)
+
+	return &loginGuard{
+		store:         store,
+		maxAttempts:   cfg.Auth.LoginMaxAttempts,
+		ipMaxAttempts: cfg.Auth.LoginIPMaxAttempts,
+		lockout:       lockout,
+		now:           time.Now,
+	}
+}
+
+func loginEmailKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func loginIPKey(ip string) string {
+	return "ip:" + ip
+}
+
+// check returns "too many login attempts" while the account or IP is locked
+// out or still inside its progressive delay
+func (g *loginGuard) check(ctx context.Context, email, ip string) error {
+	now := g.now()
+
+	attempt, err := g.store.Get(ctx, loginEmailKey(email))
+	if err != nil {
+		return err
+	}
+	if g.blocked(attempt, g.maxAttempts, now, true) {
+		return errors.New("too many login attempts")
+	}
+
+	if ip == "" {
+		return nil
+	}
+	attempt, err = g.store.Get(ctx, loginIPKey(ip))
+	if err != nil {
+		return err
+	}
+	if g.blocked(attempt, g.ipMaxAttempts, now, false) {
+		return errors.New("too many login attempts")
+	}
+	return nil
+}
+
+// recordFailure counts a failed login and reports whether this failure locked
+// the account
+func (g *loginGuard) recordFailure(ctx context.Context, email, ip string) (bool, error) {
+	now := g.now()
+
+	attempt, err := g.store.RecordFailure(ctx, loginEmailKey(email), now, g.lockout)
+	if err != nil {
+		return false, err
+	}
+	if ip != "" {
+		if _, err := g.store.RecordFailure(ctx, loginIPKey(ip), now, g.lockout); err != nil {
+			return false, err
+		}
+	}
+	return attempt.Failures == g.maxAttempts, nil
+}
+
+// reset clears the account counter after a successful login or unlock. The IP
+// counter is left to expire so one valid account can't launder an IP.
+func (g *loginGuard) reset(ctx context.Context, email string) error {
+	return g.store.Reset(ctx, loginEmailKey(email))
+}
+
+func (g *loginGuard) blocked(attempt *domain.LoginAttempt, max int, now time.Time, progressive bool) bool {
+	if attempt == nil || now.Sub(attempt.LastFailedAt) > g.lockout {
+		return false
+	}
+	if max > 0 && attempt.Failures >= max {
+		return true
+	}
+	if !progressive || attempt.Failures <= loginFreeAttempts {
+		return false
+	}
+	return now.Before(attempt.LastFailedAt.Add(loginDelay(attempt.Failures)))
+}
+
+// loginDelay doubles the wait for every failure past the free ones
+func loginDelay(failures int) time.Duration {
+	delay := loginBaseDelay
+	for i := loginFreeAttempts + 1; i < failures; i++ {
+		delay *= 2
+		if delay >= loginMaxDelay {
+			return loginMaxDelay
+		}
+	}
+	return delay
+}
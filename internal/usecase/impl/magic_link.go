@@ -0,0 +1,96 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/dto"
+	"github.com/House-lovers7/speadwear-go/pkg/mailer"
+	"github.com/House-lovers7/speadwear-go/pkg/utils"
+)
+
+// RequestMagicLink emails a single-use login link
+func (u *userUsecase) RequestMagicLink(ctx context.Context, email string) error {
+	// Rate limit per address regardless of whether the account exists
+	if !u.magicLinkLimiter.Allow(strings.ToLower(strings.TrimSpace(email))) {
+		return errors.New("too many requests")
+	}
+
+	user, err := u.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("user not found")
+	}
+	// The link could not be used, so don't send one
+	if !user.Activated {
+		return errors.New("account not activated")
+	}
+
+	// Generate login token (replaces any outstanding one)
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	user.MagicLinkDigest = utils.HashToken(token)
+	user.MagicLinkSentAt = &now
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	msg, err := mailer.Render("magic_link", user.Email, map[string]interface{}{
+		"Name":      user.Name,
+		"URL":       u.config.App.FrontendURL + "/login/magic?token=" + url.QueryEscape(token),
+		"ExpiresIn": u.config.Auth.MagicLinkExpiration,
+	})
+	if err != nil {
+		return err
+	}
+	return u.mailer.Send(ctx, msg)
+}
+
+// MagicLinkLogin exchanges a login link token for tokens, or a 2FA challenge
+func (u *userUsecase) MagicLinkLogin(ctx context.Context, token string, client dto.ClientInfo) (*dto.AuthResponse, *dto.TwoFactorChallengeResponse, error) {
+	if token == "" {
+		return nil, nil, errors.New("invalid or expired token")
+	}
+
+	digest := utils.HashToken(token)
+	user, err := u.userRepo.FindByMagicLinkDigest(ctx, digest)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil || !utils.CompareTokenHash(token, user.MagicLinkDigest) {
+		return nil, nil, errors.New("invalid or expired token")
+	}
+
+	// Check expiration
+	expiration, err := time.ParseDuration(u.config.Auth.MagicLinkExpiration)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user.MagicLinkSentAt == nil || time.Since(*user.MagicLinkSentAt) > expiration {
+		return nil, nil, errors.New("invalid or expired token")
+	}
+
+	// Invalidate the link before logging in so concurrent requests can't both use it
+	consumed, err := u.userRepo.ConsumeMagicLink(ctx, user.ID, digest)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !consumed {
+		return nil, nil, errors.New("invalid or expired token")
+	}
+
+	if !user.Activated {
+		return nil, nil, errors.New("account not activated")
+	}
+
+	return u.completeLogin(ctx, user, client)
+}
@@ -0,0 +1,348 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/dto"
+	"github.com/House-lovers7/speadwear-go/pkg/mailer"
+	"github.com/House-lovers7/speadwear-go/pkg/oidc"
+	"github.com/House-lovers7/speadwear-go/pkg/utils"
+)
+
+// BeginOIDCLogin starts an authorization code + PKCE flow with a provider.
+// userID is set when a signed-in user links a new provider.
+func (u *userUsecase) BeginOIDCLogin(ctx context.Context, provider string, userID *uint) (*dto.OIDCAuthorizationResponse, error) {
+	client, ok := u.oidcClients[provider]
+	if !ok {
+		return nil, errors.New("unknown provider")
+	}
+
+	state, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := oidc.GenerateNonce()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := oidc.GenerateCodeVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	expiration, err := time.ParseDuration(u.config.OIDC.StateExpiration)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(expiration)
+
+	authURL, err := client.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.oauthStateRepo.Create(ctx, &domain.OAuthState{
+		StateDigest:  utils.HashToken(state),
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		UserID:       userID,
+		ExpiresAt:    expiresAt,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.OIDCAuthorizationResponse{
+		AuthorizationURL: authURL,
+		ExpiresAt:        expiresAt,
+	}, nil
+}
+
+// OIDCLogin signs in (or signs up) with the code returned by a provider. An
+// existing account with the same email is only linked after the owner
+// confirms by email.
+func (u *userUsecase) OIDCLogin(ctx context.Context, provider, code, state string, client dto.ClientInfo) (*dto.AuthResponse, *dto.TwoFactorChallengeResponse, error) {
+	idToken, oauthState, err := u.redeemOIDCCode(ctx, provider, code, state)
+	if err != nil {
+		return nil, nil, err
+	}
+	if oauthState.UserID != nil {
+		return nil, nil, errors.New("invalid state")
+	}
+
+	identity, err := u.identityRepo.FindByProviderSubject(ctx, provider, idToken.Subject)
+	if err != nil {
+		return nil, nil, err
+	}
+	if identity != nil && identity.ConfirmedAt != nil {
+		user, err := u.userRepo.FindByID(ctx, identity.UserID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if user == nil {
+			return nil, nil, errors.New("oidc authentication failed")
+		}
+		return u.completeLogin(ctx, user, client)
+	}
+
+	if idToken.Email == "" || !idToken.EmailVerified {
+		return nil, nil, errors.New("email not verified by provider")
+	}
+
+	existing, err := u.userRepo.FindByEmail(ctx, idToken.Email)
+	if err != nil {
+		return nil, nil, err
+	}
+	if existing != nil {
+		if err := u.requestIdentityLink(ctx, existing, identity, provider, idToken); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, errors.New("link confirmation required")
+	}
+
+	// First sign-in: the provider has verified the email address
+	now := time.Now()
+	user := &domain.User{
+		Name:        oidcDisplayName(idToken),
+		Email:       idToken.Email,
+		Activated:   true,
+		ActivatedAt: &now,
+	}
+	if err := u.userRepo.Create(ctx, user); err != nil {
+		return nil, nil, err
+	}
+	if err := u.identityRepo.Create(ctx, &domain.UserIdentity{
+		UserID:      user.ID,
+		Provider:    provider,
+		Subject:     idToken.Subject,
+		Email:       idToken.Email,
+		ConfirmedAt: &now,
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return u.completeLogin(ctx, user, client)
+}
+
+// LinkOIDCIdentity links a provider account to the signed-in user
+func (u *userUsecase) LinkOIDCIdentity(ctx context.Context, userID uint, provider, code, state string) (*domain.UserIdentity, error) {
+	idToken, oauthState, err := u.redeemOIDCCode(ctx, provider, code, state)
+	if err != nil {
+		return nil, err
+	}
+	if oauthState.UserID == nil || *oauthState.UserID != userID {
+		return nil, errors.New("invalid state")
+	}
+
+	identity, err := u.identityRepo.FindByProviderSubject(ctx, provider, idToken.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil && identity.ConfirmedAt != nil {
+		if identity.UserID != userID {
+			return nil, errors.New("identity already linked")
+		}
+		return identity, nil
+	}
+
+	// The signed-in session proves ownership, so no email confirmation is needed
+	now := time.Now()
+	if identity == nil {
+		identity = &domain.UserIdentity{Provider: provider, Subject: idToken.Subject}
+	}
+	identity.UserID = userID
+	identity.Email = idToken.Email
+	identity.ConfirmedAt = &now
+	identity.LinkDigest = ""
+	identity.LinkSentAt = nil
+
+	if identity.ID == 0 {
+		err = u.identityRepo.Create(ctx, identity)
+	} else {
+		err = u.identityRepo.Update(ctx, identity)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+// ConfirmIdentityLink completes a link requested from OIDCLogin
+func (u *userUsecase) ConfirmIdentityLink(ctx context.Context, token string) error {
+	if token == "" {
+		return errors.New("invalid or expired token")
+	}
+
+	identity, err := u.identityRepo.FindByLinkDigest(ctx, utils.HashToken(token))
+	if err != nil {
+		return err
+	}
+	if identity == nil || !utils.CompareTokenHash(token, identity.LinkDigest) {
+		return errors.New("invalid or expired token")
+	}
+
+	expiration, err := time.ParseDuration(u.config.OIDC.LinkExpiration)
+	if err != nil {
+		return err
+	}
+	if identity.LinkSentAt == nil || time.Since(*identity.LinkSentAt) > expiration {
+		return errors.New("invalid or expired token")
+	}
+
+	now := time.Now()
+	identity.ConfirmedAt = &now
+	identity.LinkDigest = ""
+	identity.LinkSentAt = nil
+	if err := u.identityRepo.Update(ctx, identity); err != nil {
+		return err
+	}
+
+	// The link mail proved ownership of the address, which also activates it
+	user, err := u.userRepo.FindByID(ctx, identity.UserID)
+	if err != nil || user == nil || user.Activated {
+		return err
+	}
+
+	// Whoever signed up with the address before it was verified may not be
+	// its owner, so their password and any tokens they hold are dropped.
+	// The owner can set a password through the reset flow.
+	user.Activated = true
+	user.ActivatedAt = &now
+	user.ActivationDigest = ""
+	user.ActivationSentAt = nil
+	user.PasswordDigest = ""
+	user.PasswordChangedAt = &now
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	if err := u.sessionRepo.RevokeAllByUserID(ctx, user.ID, 0); err != nil {
+		return err
+	}
+	return u.accessTokenRepo.RevokeAllByUserID(ctx, user.ID)
+}
+
+// ListIdentities lists the provider accounts linked to a user
+func (u *userUsecase) ListIdentities(ctx context.Context, userID uint) ([]*domain.UserIdentity, error) {
+	return u.identityRepo.FindByUserID(ctx, userID)
+}
+
+// UnlinkIdentity removes a linked provider unless it is the last way to sign in
+func (u *userUsecase) UnlinkIdentity(ctx context.Context, userID, identityID uint) error {
+	identity, err := u.identityRepo.FindByID(ctx, identityID)
+	if err != nil {
+		return err
+	}
+	if identity == nil || identity.UserID != userID {
+		return errors.New("identity not found")
+	}
+
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("user not found")
+	}
+
+	if user.PasswordDigest == "" {
+		identities, err := u.identityRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		remaining := 0
+		for _, other := range identities {
+			if other.ID != identity.ID && other.ConfirmedAt != nil {
+				remaining++
+			}
+		}
+		if remaining == 0 {
+			return errors.New("cannot remove last login method")
+		}
+	}
+
+	return u.identityRepo.Delete(ctx, identity.ID)
+}
+
+// redeemOIDCCode consumes the state, exchanges the code and verifies the ID token
+func (u *userUsecase) redeemOIDCCode(ctx context.Context, provider, code, state string) (*oidc.IDToken, *domain.OAuthState, error) {
+	client, ok := u.oidcClients[provider]
+	if !ok {
+		return nil, nil, errors.New("unknown provider")
+	}
+
+	oauthState, err := u.oauthStateRepo.Consume(ctx, utils.HashToken(state))
+	if err != nil {
+		return nil, nil, err
+	}
+	if oauthState == nil || oauthState.Provider != provider || time.Now().After(oauthState.ExpiresAt) {
+		return nil, nil, errors.New("invalid state")
+	}
+
+	rawIDToken, err := client.Exchange(ctx, code, oauthState.CodeVerifier)
+	if err != nil {
+		fmt.Printf("OIDC code exchange with %s failed: %v\n", provider, err)
+		return nil, nil, errors.New("oidc authentication failed")
+	}
+	idToken, err := client.VerifyIDToken(ctx, rawIDToken, oauthState.Nonce)
+	if err != nil {
+		fmt.Printf("OIDC ID token from %s rejected: %v\n", provider, err)
+		return nil, nil, errors.New("oidc authentication failed")
+	}
+
+	return idToken, oauthState, nil
+}
+
+// requestIdentityLink mails the owner of an existing account a link to attach the provider account
+func (u *userUsecase) requestIdentityLink(ctx context.Context, user *domain.User, identity *domain.UserIdentity, provider string, idToken *oidc.IDToken) error {
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if identity == nil {
+		identity = &domain.UserIdentity{Provider: provider, Subject: idToken.Subject}
+	}
+	identity.UserID = user.ID
+	identity.Email = idToken.Email
+	identity.LinkDigest = utils.HashToken(token)
+	identity.LinkSentAt = &now
+
+	if identity.ID == 0 {
+		err = u.identityRepo.Create(ctx, identity)
+	} else {
+		err = u.identityRepo.Update(ctx, identity)
+	}
+	if err != nil {
+		return err
+	}
+
+	msg, err := mailer.Render("identity_link", user.Email, map[string]interface{}{
+		"Name":      user.Name,
+		"Provider":  provider,
+		"URL":       u.config.App.FrontendURL + "/auth/oidc/link?token=" + url.QueryEscape(token),
+		"ExpiresIn": u.config.OIDC.LinkExpiration,
+	})
+	if err != nil {
+		return err
+	}
+	return u.mailer.Send(ctx, msg)
+}
+
+// oidcDisplayName picks a user name from the ID token claims
+func oidcDisplayName(idToken *oidc.IDToken) string {
+	name := strings.TrimSpace(idToken.Name)
+	if len(name) < 2 {
+		name = strings.Split(idToken.Email, "@")[0]
+	}
+	if len(name) > 255 {
+		name = name[:255]
+	}
+	return name
+}
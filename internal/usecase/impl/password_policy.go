@@ -0,0 +1,54 @@
+package impl
+
+import (
+	"context"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/pkg/password"
+	"github.com/House-lovers7/speadwear-go/pkg/utils"
+)
+
+// validateNewPassword checks a new password against the policy and, for an
+// existing user, the current and recent passwords
+func (u *userUsecase) validateNewPassword(ctx context.Context, user *domain.User, newPassword string) error {
+	if err := u.passwords.Validate(newPassword); err != nil {
+		return err
+	}
+
+	// The history size counts the current password
+	size := u.config.Password.HistorySize
+	if user == nil || size <= 0 {
+		return nil
+	}
+	if utils.CheckPassword(newPassword, user.PasswordDigest) {
+		return password.ErrReused
+	}
+	if size == 1 {
+		return nil
+	}
+
+	histories, err := u.passwordHistoryRepo.FindRecentByUserID(ctx, user.ID, size-1)
+	if err != nil {
+		return err
+	}
+	for _, history := range histories {
+		if utils.CheckPassword(newPassword, history.PasswordDigest) {
+			return password.ErrReused
+		}
+	}
+	return nil
+}
+
+// rememberPassword archives the digest being replaced so it can't be reused
+func (u *userUsecase) rememberPassword(ctx context.Context, user *domain.User) error {
+	size := u.config.Password.HistorySize
+	if user.PasswordDigest == "" || size <= 1 {
+		return nil
+	}
+
+	history := &domain.PasswordHistory{UserID: user.ID, PasswordDigest: user.PasswordDigest}
+	if err := u.passwordHistoryRepo.Create(ctx, history); err != nil {
+		return err
+	}
+	return u.passwordHistoryRepo.Prune(ctx, user.ID, size-1)
+}
@@ -18,6 +18,7 @@ type socialUsecase struct {
 	notificationRepo repository.NotificationRepository
 	coordinateRepo   repository.CoordinateRepository
 	userRepo         repository.UserRepository
+	audit            usecase.AuditLogger
 	config           *config.Config
 }
 
@@ -29,6 +30,7 @@ func NewSocialUsecase(
 	notificationRepo repository.NotificationRepository,
 	coordinateRepo repository.CoordinateRepository,
 	userRepo repository.UserRepository,
+	audit usecase.AuditLogger,
 	config *config.Config,
 ) usecase.SocialUsecase {
 	return &socialUsecase{
@@ -38,6 +40,7 @@ func NewSocialUsecase(
 		notificationRepo: notificationRepo,
 		coordinateRepo:   coordinateRepo,
 		userRepo:         userRepo,
+		audit:            audit,
 		config:           config,
 	}
 }
@@ -64,7 +67,7 @@ func (u *socialUsecase) CreateComment(ctx context.Context, userID uint, coordina
 	
 	// Create comment
 	newComment := &domain.Comment{
-		UserID:       userID,
+		UserID:       &userID,
 		CoordinateID: coordinateID,
 		Comment:      comment,
 	}
@@ -117,12 +120,17 @@ func (u *socialUsecase) UpdateComment(ctx context.Context, userID uint, commentI
 	}
 	
 	// Check ownership
-	if existingComment.UserID != userID {
+	if !existingComment.AuthoredBy(userID) {
 		return errors.New("unauthorized")
 	}
 	
+	changes := usecase.Changes{}
+	changes.Track("comment", existingComment.Comment, comment)
 	existingComment.Comment = comment
-	return u.commentRepo.Update(ctx, existingComment)
+	if err := u.commentRepo.Update(ctx, existingComment); err != nil {
+		return err
+	}
+	return u.audit.RecordChanges(ctx, userID, domain.AuditActionCommentUpdate, domain.AuditTargetComment, commentID, changes)
 }
 
 // DeleteComment deletes a comment
@@ -136,11 +144,17 @@ func (u *socialUsecase) DeleteComment(ctx context.Context, userID uint, commentI
 	}
 	
 	// Check ownership
-	if comment.UserID != userID {
+	if !comment.AuthoredBy(userID) {
 		return errors.New("unauthorized")
 	}
 	
-	return u.commentRepo.Delete(ctx, commentID)
+	if err := u.commentRepo.Delete(ctx, commentID); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, userID, domain.AuditActionCommentDelete, domain.AuditTargetComment, commentID, map[string]interface{}{
+		"coordinate_id": comment.CoordinateID,
+		"comment":       comment.Comment,
+	})
 }
 
 // FollowUser follows a user
@@ -186,6 +200,9 @@ func (u *socialUsecase) FollowUser(ctx context.Context, followerID uint, followe
 	if err := u.relationshipRepo.Create(ctx, relationship); err != nil {
 		return err
 	}
+	if err := u.audit.Record(ctx, followerID, domain.AuditActionUserFollow, domain.AuditTargetUser, followedID, nil); err != nil {
+		return err
+	}
 	
 	// Create notification
 	notification := &domain.Notification{
@@ -213,7 +230,10 @@ func (u *socialUsecase) UnfollowUser(ctx context.Context, followerID uint, follo
 	}
 	
 	// Delete relationship
-	return u.relationshipRepo.Delete(ctx, relationship.ID)
+	if err := u.relationshipRepo.Delete(ctx, relationship.ID); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, followerID, domain.AuditActionUserUnfollow, domain.AuditTargetUser, followedID, nil)
 }
 
 // GetFollowers gets followers of a user
@@ -285,6 +305,9 @@ func (u *socialUsecase) BlockUser(ctx context.Context, blockerID uint, blockedID
 	if err := u.blockRepo.Create(ctx, block); err != nil {
 		return err
 	}
+	if err := u.audit.Record(ctx, blockerID, domain.AuditActionUserBlock, domain.AuditTargetUser, blockedID, nil); err != nil {
+		return err
+	}
 	
 	// Remove follow relationship if exists
 	// TODO: Implement this when unfollow is properly implemented
@@ -303,7 +326,10 @@ func (u *socialUsecase) UnblockUser(ctx context.Context, blockerID uint, blocked
 	}
 	
 	// Delete block
-	return u.blockRepo.Delete(ctx, block.ID)
+	if err := u.blockRepo.Delete(ctx, block.ID); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, blockerID, domain.AuditActionUserUnblock, domain.AuditTargetUser, blockedID, nil)
 }
 
 // GetBlockedUsers gets blocked users
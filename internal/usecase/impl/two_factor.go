@@ -0,0 +1,228 @@
+package impl
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/dto"
+	"github.com/House-lovers7/speadwear-go/pkg/totp"
+	"github.com/House-lovers7/speadwear-go/pkg/utils"
+)
+
+const (
+	twoFactorChallengePurpose = "2fa_challenge"
+	recoveryCodeCount         = 10
+)
+
+// EnrollTwoFactor starts a TOTP enrollment. 2FA is not enforced until the
+// first code is confirmed with VerifyTwoFactor.
+func (u *userUsecase) EnrollTwoFactor(ctx context.Context, userID uint) (*dto.TwoFactorEnrollResponse, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	if user.TwoFactorEnabled() {
+		return nil, errors.New("two-factor already enabled")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	user.TOTPSecret = secret
+	user.TOTPEnabledAt = nil
+	user.TOTPLastStep = 0
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	codes, digests, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := u.recoveryCodeRepo.ReplaceForUser(ctx, user.ID, digests); err != nil {
+		return nil, err
+	}
+
+	return &dto.TwoFactorEnrollResponse{
+		Secret:        secret,
+		OTPAuthURI:    totp.URI(u.config.Auth.TwoFactorIssuer, user.Email, secret),
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// VerifyTwoFactor confirms a pending enrollment with a code from the authenticator
+func (u *userUsecase) VerifyTwoFactor(ctx context.Context, userID uint, code string) error {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("user not found")
+	}
+	if user.TwoFactorEnabled() {
+		return errors.New("two-factor already enabled")
+	}
+	if user.TOTPSecret == "" {
+		return errors.New("two-factor not enrolled")
+	}
+
+	step, ok := totp.Validate(user.TOTPSecret, code, time.Now())
+	if !ok {
+		return errors.New("invalid two-factor code")
+	}
+
+	now := time.Now()
+	user.TOTPEnabledAt = &now
+	user.TOTPLastStep = step
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, userID, domain.AuditActionUserTwoFactorEnable, domain.AuditTargetUser, userID, nil)
+}
+
+// DisableTwoFactor turns 2FA off after checking the password and a second factor
+func (u *userUsecase) DisableTwoFactor(ctx context.Context, userID uint, password, code string) error {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return errors.New("user not found")
+	}
+	if !user.TwoFactorEnabled() {
+		return errors.New("two-factor not enabled")
+	}
+
+	if !utils.CheckPassword(password, user.PasswordDigest) {
+		return errors.New("invalid password")
+	}
+	ok, err := u.verifySecondFactor(ctx, user, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("invalid two-factor code")
+	}
+
+	user.TOTPSecret = ""
+	user.TOTPEnabledAt = nil
+	user.TOTPLastStep = 0
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	if err := u.recoveryCodeRepo.DeleteByUserID(ctx, user.ID); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, userID, domain.AuditActionUserTwoFactorDisable, domain.AuditTargetUser, userID, nil)
+}
+
+// LoginTwoFactor completes a login started by Login for a 2FA-enabled account
+func (u *userUsecase) LoginTwoFactor(ctx context.Context, challengeToken, code string, client dto.ClientInfo) (*dto.AuthResponse, error) {
+	claims, err := u.keys.Validate(challengeToken)
+	if err != nil || claims.Purpose != twoFactorChallengePurpose {
+		return nil, errors.New("invalid challenge token")
+	}
+
+	// Codes are throttled together with passwords so the challenge can't be
+	// used to brute-force the 6 digits
+	if err := u.loginGuard.check(ctx, claims.Email, client.IPAddress); err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !user.TwoFactorEnabled() {
+		return nil, errors.New("invalid challenge token")
+	}
+
+	ok, err := u.verifySecondFactor(ctx, user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if _, err := u.loginGuard.recordFailure(ctx, claims.Email, client.IPAddress); err != nil {
+			return nil, err
+		}
+		if err := u.audit.Record(ctx, user.ID, domain.AuditActionUserLoginFailed, domain.AuditTargetUser, user.ID, map[string]interface{}{
+			"second_factor": true,
+		}); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("invalid two-factor code")
+	}
+
+	if user.IsSuspended(time.Now()) {
+		return nil, errors.New("account suspended")
+	}
+
+	if err := u.loginGuard.reset(ctx, claims.Email); err != nil {
+		return nil, err
+	}
+	return u.createSession(ctx, user, client)
+}
+
+// issueTwoFactorChallenge signs a short-lived token proving the password step passed
+func (u *userUsecase) issueTwoFactorChallenge(user *domain.User) (*dto.TwoFactorChallengeResponse, error) {
+	token, err := u.keys.Sign(&utils.Claims{
+		UserID:  user.ID,
+		Email:   user.Email,
+		Purpose: twoFactorChallengePurpose,
+	}, u.config.Auth.ChallengeExpiration)
+	if err != nil {
+		return nil, err
+	}
+
+	duration, _ := time.ParseDuration(u.config.Auth.ChallengeExpiration)
+	return &dto.TwoFactorChallengeResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    token,
+		ExpiresAt:         time.Now().Add(duration),
+	}, nil
+}
+
+// verifySecondFactor accepts a TOTP code (once per time step) or an unused recovery code
+func (u *userUsecase) verifySecondFactor(ctx context.Context, user *domain.User, code string) (bool, error) {
+	if step, ok := totp.Validate(user.TOTPSecret, code, time.Now()); ok {
+		advanced, err := u.userRepo.AdvanceTOTPStep(ctx, user.ID, step)
+		if err != nil || !advanced {
+			return false, err
+		}
+		user.TOTPLastStep = step
+		return true, nil
+	}
+
+	return u.recoveryCodeRepo.Use(ctx, user.ID, utils.HashToken(normalizeRecoveryCode(code)))
+}
+
+// generateRecoveryCodes returns display codes (xxxxx-xxxxx) and their digests
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	digests := make([]string, recoveryCodeCount)
+	for i := range codes {
+		bytes := make([]byte, 10)
+		if _, err := rand.Read(bytes); err != nil {
+			return nil, nil, err
+		}
+		raw := strings.ToLower(base32.StdEncoding.EncodeToString(bytes))[:10]
+		codes[i] = raw[:5] + "-" + raw[5:]
+		digests[i] = utils.HashToken(raw)
+	}
+	return codes, digests, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
@@ -3,6 +3,9 @@ package impl
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/url"
+	"strings"
 	"time"
 	
 	"github.com/House-lovers7/speadwear-go/internal/domain"
@@ -10,69 +13,131 @@ import (
 	"github.com/House-lovers7/speadwear-go/internal/repository"
 	"github.com/House-lovers7/speadwear-go/internal/usecase"
 	"github.com/House-lovers7/speadwear-go/pkg/config"
+	"github.com/House-lovers7/speadwear-go/pkg/mailer"
+	"github.com/House-lovers7/speadwear-go/pkg/oidc"
+	"github.com/House-lovers7/speadwear-go/pkg/password"
+	"github.com/House-lovers7/speadwear-go/pkg/ratelimit"
 	"github.com/House-lovers7/speadwear-go/pkg/utils"
 )
 
 type userUsecase struct {
-	userRepo repository.UserRepository
-	config   *config.Config
+	userRepo            repository.UserRepository
+	sessionRepo         repository.SessionRepository
+	refreshTokenRepo    repository.RefreshTokenRepository
+	recoveryCodeRepo    repository.RecoveryCodeRepository
+	identityRepo        repository.UserIdentityRepository
+	oauthStateRepo      repository.OAuthStateRepository
+	passwordHistoryRepo repository.PasswordHistoryRepository
+	accessTokenRepo     repository.PersonalAccessTokenRepository
+	oidcClients         map[string]*oidc.Client
+	keys                *utils.KeySet
+	passwords           *password.Policy
+	mailer              mailer.Mailer
+	audit               usecase.AuditLogger
+	resetLimiter        ratelimit.Limiter
+	magicLinkLimiter    ratelimit.Limiter
+	loginGuard          *loginGuard
+	config              *config.Config
 }
 
 // NewUserUsecase creates a new user usecase
-func NewUserUsecase(userRepo repository.UserRepository, config *config.Config) usecase.UserUsecase {
+func NewUserUsecase(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	recoveryCodeRepo repository.RecoveryCodeRepository,
+	identityRepo repository.UserIdentityRepository,
+	oauthStateRepo repository.OAuthStateRepository,
+	passwordHistoryRepo repository.PasswordHistoryRepository,
+	accessTokenRepo repository.PersonalAccessTokenRepository,
+	loginAttempts repository.LoginAttemptStore,
+	oidcClients map[string]*oidc.Client,
+	keys *utils.KeySet,
+	passwords *password.Policy,
+	mailer mailer.Mailer,
+	audit usecase.AuditLogger,
+	config *config.Config,
+) usecase.UserUsecase {
+	resetWindow, _ := time.ParseDuration(config.Auth.PasswordResetWindow)
+	magicLinkWindow, _ := time.ParseDuration(config.Auth.MagicLinkWindow)
+	
 	return &userUsecase{
-		userRepo: userRepo,
-		config:   config,
+		userRepo:            userRepo,
+		sessionRepo:         sessionRepo,
+		refreshTokenRepo:    refreshTokenRepo,
+		recoveryCodeRepo:    recoveryCodeRepo,
+		identityRepo:        identityRepo,
+		oauthStateRepo:      oauthStateRepo,
+		passwordHistoryRepo: passwordHistoryRepo,
+		accessTokenRepo:     accessTokenRepo,
+		oidcClients:         oidcClients,
+		keys:                keys,
+		passwords:           passwords,
+		mailer:              mailer,
+		audit:               audit,
+		resetLimiter:        ratelimit.NewMemoryLimiter(config.Auth.PasswordResetLimit, resetWindow),
+		magicLinkLimiter:    ratelimit.NewMemoryLimiter(config.Auth.MagicLinkLimit, magicLinkWindow),
+		loginGuard:          newLoginGuard(loginAttempts, config),
+		config:              config,
 	}
 }
 
-// Login handles user login
-func (u *userUsecase) Login(ctx context.Context, email, password string) (*dto.AuthResponse, error) {
+// Login handles user login. Accounts with two-factor authentication get a
+// challenge to complete with LoginTwoFactor instead of tokens.
+func (u *userUsecase) Login(ctx context.Context, email, password string, client dto.ClientInfo) (*dto.AuthResponse, *dto.TwoFactorChallengeResponse, error) {
+	// Refuse before touching bcrypt while the account or IP is throttled
+	if err := u.loginGuard.check(ctx, email, client.IPAddress); err != nil {
+		return nil, nil, err
+	}
+	
 	// Find user by email
 	user, err := u.userRepo.FindByEmail(ctx, email)
 	if err != nil {
-		return nil, err
-	}
-	if user == nil {
-		return nil, errors.New("invalid email or password")
+		return nil, nil, err
 	}
 	
-	// Check password
-	if !utils.CheckPassword(password, user.PasswordDigest) {
-		return nil, errors.New("invalid email or password")
+	// Check password. Unknown emails are counted the same way so responses
+	// don't reveal whether an account exists.
+	if user == nil || !utils.CheckPassword(password, user.PasswordDigest) {
+		locked, err := u.loginGuard.recordFailure(ctx, email, client.IPAddress)
+		if err != nil {
+			return nil, nil, err
+		}
+		if user != nil {
+			if err := u.audit.Record(ctx, user.ID, domain.AuditActionUserLoginFailed, domain.AuditTargetUser, user.ID, map[string]interface{}{
+				"locked": locked,
+			}); err != nil {
+				return nil, nil, err
+			}
+		}
+		if locked && user != nil {
+			if err := u.sendUnlockEmail(ctx, user); err != nil {
+				fmt.Printf("Failed to send unlock email: %v\n", err)
+			}
+		}
+		return nil, nil, errors.New("invalid email or password")
 	}
 	
 	// Check if account is activated
 	if !user.Activated {
-		return nil, errors.New("account not activated")
+		return nil, nil, errors.New("account not activated")
 	}
 	
-	// Generate JWT token
-	token, err := utils.GenerateToken(user.ID, user.Email, u.config.JWT.Secret, u.config.JWT.Expiration)
-	if err != nil {
-		return nil, err
+	resp, challenge, err := u.completeLogin(ctx, user, client)
+	if err != nil || challenge != nil {
+		return nil, challenge, err
 	}
 	
-	duration, _ := time.ParseDuration(u.config.JWT.Expiration)
-	expiresAt := time.Now().Add(duration)
-	
-	return &dto.AuthResponse{
-		Token:     token,
-		ExpiresAt: expiresAt,
-		User: dto.UserResponse{
-			ID:        user.ID,
-			Name:      user.Name,
-			Email:     user.Email,
-			Picture:   user.Picture,
-			Admin:     user.Admin,
-			Activated: user.Activated,
-			CreatedAt: user.CreatedAt,
-		},
-	}, nil
+	// Only a fully completed login clears the failure count; a pending 2FA
+	// challenge keeps it so codes can't be guessed indefinitely
+	if err := u.loginGuard.reset(ctx, email); err != nil {
+		return nil, nil, err
+	}
+	return resp, nil, nil
 }
 
 // Signup handles user registration
-func (u *userUsecase) Signup(ctx context.Context, req *dto.SignupRequest) (*dto.AuthResponse, error) {
+func (u *userUsecase) Signup(ctx context.Context, req *dto.SignupRequest) (*dto.UserResponse, error) {
 	// Check if email already exists
 	exists, err := u.userRepo.ExistsByEmail(ctx, req.Email)
 	if err != nil {
@@ -82,69 +147,127 @@ func (u *userUsecase) Signup(ctx context.Context, req *dto.SignupRequest) (*dto.
 		return nil, errors.New("email already exists")
 	}
 	
+	if err := u.validateNewPassword(ctx, nil, req.Password); err != nil {
+		return nil, err
+	}
+	
 	// Hash password
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
 		return nil, err
 	}
 	
-	// Create user
+	// Generate activation token
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Create inactive user
 	now := time.Now()
 	user := &domain.User{
-		Name:           req.Name,
-		Email:          req.Email,
-		PasswordDigest: hashedPassword,
-		Activated:      true, // TODO: Implement email activation
-		ActivatedAt:    &now,
+		Name:             req.Name,
+		Email:            req.Email,
+		PasswordDigest:   hashedPassword,
+		Activated:        false,
+		ActivationDigest: utils.HashToken(token),
+		ActivationSentAt: &now,
 	}
 	
 	if err := u.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
 	
-	// Generate JWT token
-	token, err := utils.GenerateToken(user.ID, user.Email, u.config.JWT.Secret, u.config.JWT.Expiration)
+	if err := u.sendActivationEmail(ctx, user, token); err != nil {
+		// Log error but don't fail the signup; the user can request a new email
+		fmt.Printf("Failed to send activation email: %v\n", err)
+	}
+	
+	resp := newUserResponse(user)
+	return &resp, nil
+}
+
+// RefreshToken rotates a refresh token and issues a new access token
+func (u *userUsecase) RefreshToken(ctx context.Context, refreshToken string, client dto.ClientInfo) (*dto.AuthResponse, error) {
+	token, err := u.refreshTokenRepo.FindByDigest(ctx, utils.HashToken(refreshToken))
 	if err != nil {
 		return nil, err
 	}
+	if token == nil || !utils.CompareTokenHash(refreshToken, token.TokenDigest) {
+		return nil, errors.New("invalid refresh token")
+	}
 	
-	duration, _ := time.ParseDuration(u.config.JWT.Expiration)
-	expiresAt := time.Now().Add(duration)
+	session := token.Session
+	if !session.IsActive(time.Now()) {
+		return nil, errors.New("invalid refresh token")
+	}
 	
-	return &dto.AuthResponse{
-		Token:     token,
-		ExpiresAt: expiresAt,
-		User: dto.UserResponse{
-			ID:        user.ID,
-			Name:      user.Name,
-			Email:     user.Email,
-			Picture:   user.Picture,
-			Admin:     user.Admin,
-			Activated: user.Activated,
-			CreatedAt: user.CreatedAt,
-		},
-	}, nil
+	// A refresh token may only be used once. Reuse means it has leaked,
+	// so the whole session (token family) is revoked.
+	if token.UsedAt != nil {
+		if err := u.sessionRepo.Revoke(ctx, session.ID); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("refresh token reuse detected")
+	}
+	marked, err := u.refreshTokenRepo.MarkUsed(ctx, token.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		if err := u.sessionRepo.Revoke(ctx, session.ID); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("refresh token reuse detected")
+	}
+	
+	user, err := u.userRepo.FindByID(ctx, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("invalid refresh token")
+	}
+	if user.IsSuspended(time.Now()) {
+		return nil, errors.New("account suspended")
+	}
+	
+	// Update session usage
+	refreshDuration, err := time.ParseDuration(u.config.JWT.RefreshExpiration)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	session.LastUsedAt = now
+	session.ExpiresAt = now.Add(refreshDuration)
+	session.IPAddress = client.IPAddress
+	session.UserAgent = client.UserAgent
+	if err := u.sessionRepo.Update(ctx, &session); err != nil {
+		return nil, err
+	}
+	
+	return u.issueTokens(ctx, user, &session)
 }
 
-// RefreshToken refreshes JWT token
-func (u *userUsecase) RefreshToken(ctx context.Context, tokenString string) (string, error) {
-	newToken, err := utils.RefreshToken(tokenString, u.config.JWT.Secret, u.config.JWT.Expiration)
+// Logout revokes the current session
+func (u *userUsecase) Logout(ctx context.Context, sessionID uint) error {
+	session, err := u.sessionRepo.FindByID(ctx, sessionID)
 	if err != nil {
-		return "", err
+		return err
 	}
-	return newToken, nil
+	if session == nil {
+		return errors.New("session not found")
+	}
+	
+	if err := u.sessionRepo.Revoke(ctx, sessionID); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, session.UserID, domain.AuditActionUserLogout, domain.AuditTargetSession, sessionID, nil)
 }
 
 // GetUser gets user by ID
 func (u *userUsecase) GetUser(ctx context.Context, userID uint) (*domain.User, error) {
-	user, err := u.userRepo.FindByID(ctx, userID)
-	if err != nil {
-		return nil, err
-	}
-	if user == nil {
-		return nil, errors.New("user not found")
-	}
-	return user, nil
+	return findVisibleUser(ctx, u.userRepo, userID)
 }
 
 // GetUserByEmail gets user by email
@@ -170,40 +293,79 @@ func (u *userUsecase) UpdateUser(ctx context.Context, userID uint, updates map[s
 	}
 	
 	// Apply updates
+	changes := usecase.Changes{}
 	if name, ok := updates["name"].(string); ok {
+		changes.Track("name", user.Name, name)
 		user.Name = name
 	}
-	if email, ok := updates["email"].(string); ok {
-		// Check if new email already exists
-		if email != user.Email {
-			exists, err := u.userRepo.ExistsByEmail(ctx, email)
-			if err != nil {
-				return err
-			}
-			if exists {
-				return errors.New("email already exists")
-			}
-		}
-		user.Email = email
-	}
 	if picture, ok := updates["picture"].(string); ok {
+		changes.Track("picture", user.Picture, picture)
 		user.Picture = picture
 	}
 	
-	return u.userRepo.Update(ctx, user)
+	// A new email only takes effect once confirmed from that address
+	email, ok := updates["email"].(string)
+	if !ok || strings.EqualFold(email, user.Email) {
+		err = u.userRepo.Update(ctx, user)
+	} else {
+		changes.Track("unconfirmed_email", "", email)
+		err = u.requestEmailChange(ctx, user, email)
+	}
+	if err != nil {
+		return err
+	}
+	return u.audit.RecordChanges(ctx, userID, domain.AuditActionUserProfileUpdate, domain.AuditTargetUser, userID, changes)
 }
 
-// DeleteUser deletes a user
-func (u *userUsecase) DeleteUser(ctx context.Context, userID uint) error {
+// DeleteUser schedules a user's account for deletion after the grace period.
+// The account is hidden and signed out at once; the purge job removes it later.
+func (u *userUsecase) DeleteUser(ctx context.Context, userID uint) (time.Time, error) {
 	user, err := u.userRepo.FindByID(ctx, userID)
 	if err != nil {
-		return err
+		return time.Time{}, err
 	}
 	if user == nil {
-		return errors.New("user not found")
+		return time.Time{}, errors.New("user not found")
 	}
 	
-	return u.userRepo.Delete(ctx, userID)
+	// Repeated requests keep the original schedule
+	if user.DeletionPending() {
+		return *user.DeletionScheduledAt, nil
+	}
+	
+	gracePeriod, err := time.ParseDuration(u.config.Auth.DeletionGracePeriod)
+	if err != nil {
+		return time.Time{}, err
+	}
+	scheduledAt := time.Now().Add(gracePeriod)
+	user.DeletionScheduledAt = &scheduledAt
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return time.Time{}, err
+	}
+	
+	if err := u.sessionRepo.RevokeAllByUserID(ctx, user.ID, 0); err != nil {
+		return time.Time{}, err
+	}
+	
+	if err := u.audit.Record(ctx, userID, domain.AuditActionUserDeletionRequest, domain.AuditTargetUser, userID, map[string]interface{}{
+		"scheduled_at": scheduledAt,
+	}); err != nil {
+		return time.Time{}, err
+	}
+
+	msg, err := mailer.Render("account_deletion", user.Email, map[string]interface{}{
+		"Name":        user.Name,
+		"ScheduledAt": scheduledAt.Format("2006-01-02 15:04"),
+		"URL":         u.config.App.FrontendURL + "/login",
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := u.mailer.Send(ctx, msg); err != nil {
+		return time.Time{}, err
+	}
+	
+	return scheduledAt, nil
 }
 
 // ListUsers lists all users with pagination
@@ -222,7 +384,7 @@ func (u *userUsecase) ListUsers(ctx context.Context, limit, offset int) ([]*doma
 }
 
 // ChangePassword changes user password
-func (u *userUsecase) ChangePassword(ctx context.Context, userID uint, oldPassword, newPassword string) error {
+func (u *userUsecase) ChangePassword(ctx context.Context, userID, currentSessionID uint, oldPassword, newPassword string, revokeOtherSessions bool) error {
 	user, err := u.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return err
@@ -236,18 +398,78 @@ func (u *userUsecase) ChangePassword(ctx context.Context, userID uint, oldPasswo
 		return errors.New("invalid old password")
 	}
 	
+	if err := u.validateNewPassword(ctx, user, newPassword); err != nil {
+		return err
+	}
+	
 	// Hash new password
 	hashedPassword, err := utils.HashPassword(newPassword)
 	if err != nil {
 		return err
 	}
 	
+	if err := u.rememberPassword(ctx, user); err != nil {
+		return err
+	}
+	
+	// Access tokens issued before now stop working; the current session
+	// continues with the next refresh
+	now := time.Now()
 	user.PasswordDigest = hashedPassword
-	return u.userRepo.Update(ctx, user)
+	user.PasswordChangedAt = &now
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	
+	if revokeOtherSessions {
+		if err := u.sessionRepo.RevokeAllByUserID(ctx, userID, currentSessionID); err != nil {
+			return err
+		}
+	}
+
+	return u.audit.Record(ctx, userID, domain.AuditActionUserPasswordChange, domain.AuditTargetUser, userID, map[string]interface{}{
+		"revoked_other_sessions": revokeOtherSessions,
+	})
+}
+
+// ListSessions lists the active sessions of a user
+func (u *userUsecase) ListSessions(ctx context.Context, userID uint) ([]*domain.Session, error) {
+	return u.sessionRepo.FindActiveByUserID(ctx, userID)
+}
+
+// RevokeSession revokes one of the user's sessions
+func (u *userUsecase) RevokeSession(ctx context.Context, userID, sessionID uint) error {
+	session, err := u.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID != userID {
+		return errors.New("session not found")
+	}
+	
+	if err := u.sessionRepo.Revoke(ctx, sessionID); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, userID, domain.AuditActionSessionRevoke, domain.AuditTargetSession, sessionID, nil)
+}
+
+// RevokeOtherSessions revokes all sessions of the user except the current one
+func (u *userUsecase) RevokeOtherSessions(ctx context.Context, userID, currentSessionID uint) error {
+	if err := u.sessionRepo.RevokeAllByUserID(ctx, userID, currentSessionID); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, userID, domain.AuditActionSessionRevoke, domain.AuditTargetUser, userID, map[string]interface{}{
+		"except_session_id": currentSessionID,
+	})
 }
 
 // ResetPasswordRequest initiates password reset
 func (u *userUsecase) ResetPasswordRequest(ctx context.Context, email string) error {
+	// Rate limit per address regardless of whether the account exists
+	if !u.resetLimiter.Allow(strings.ToLower(strings.TrimSpace(email))) {
+		return errors.New("too many requests")
+	}
+	
 	user, err := u.userRepo.FindByEmail(ctx, email)
 	if err != nil {
 		return err
@@ -256,23 +478,152 @@ func (u *userUsecase) ResetPasswordRequest(ctx context.Context, email string) er
 		return errors.New("user not found")
 	}
 	
-	// TODO: Generate reset token and send email
-	// For now, just return nil
-	return nil
+	// Generate reset token (replaces any outstanding one)
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return err
+	}
+	
+	now := time.Now()
+	user.ResetDigest = utils.HashToken(token)
+	user.ResetSentAt = &now
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	
+	msg, err := mailer.Render("password_reset", user.Email, map[string]interface{}{
+		"Name":      user.Name,
+		"URL":       u.config.App.FrontendURL + "/password/reset?token=" + url.QueryEscape(token),
+		"ExpiresIn": u.config.Auth.PasswordResetExpiration,
+	})
+	if err != nil {
+		return err
+	}
+	return u.mailer.Send(ctx, msg)
 }
 
 // ResetPassword resets password with token
 func (u *userUsecase) ResetPassword(ctx context.Context, token, newPassword string) error {
-	// TODO: Validate reset token and update password
-	// For now, just return nil
-	return nil
+	if token == "" {
+		return errors.New("invalid or expired token")
+	}
+	
+	user, err := u.userRepo.FindByResetDigest(ctx, utils.HashToken(token))
+	if err != nil {
+		return err
+	}
+	if user == nil || !utils.CompareTokenHash(token, user.ResetDigest) {
+		return errors.New("invalid or expired token")
+	}
+	
+	// Check expiration
+	expiration, err := time.ParseDuration(u.config.Auth.PasswordResetExpiration)
+	if err != nil {
+		return err
+	}
+	if user.ResetSentAt == nil || time.Since(*user.ResetSentAt) > expiration {
+		return errors.New("invalid or expired token")
+	}
+	
+	if err := u.validateNewPassword(ctx, user, newPassword); err != nil {
+		return err
+	}
+	
+	// Hash new password
+	hashedPassword, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	
+	if err := u.rememberPassword(ctx, user); err != nil {
+		return err
+	}
+	
+	// Update password, invalidate the token and all tokens issued before now
+	now := time.Now()
+	user.PasswordDigest = hashedPassword
+	user.ResetDigest = ""
+	user.ResetSentAt = nil
+	user.PasswordChangedAt = &now
+	
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	
+	// Log out everywhere
+	if err := u.sessionRepo.RevokeAllByUserID(ctx, user.ID, 0); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, user.ID, domain.AuditActionUserPasswordReset, domain.AuditTargetUser, user.ID, nil)
 }
 
 // ActivateAccount activates user account
 func (u *userUsecase) ActivateAccount(ctx context.Context, token string) error {
-	// TODO: Validate activation token and activate account
-	// For now, just return nil
-	return nil
+	if token == "" {
+		return errors.New("invalid or expired token")
+	}
+	
+	user, err := u.userRepo.FindByActivationDigest(ctx, utils.HashToken(token))
+	if err != nil {
+		return err
+	}
+	if user == nil || !utils.CompareTokenHash(token, user.ActivationDigest) {
+		return errors.New("invalid or expired token")
+	}
+	
+	if user.Activated {
+		return errors.New("account already activated")
+	}
+	
+	// Check expiration
+	expiration, err := time.ParseDuration(u.config.Auth.ActivationExpiration)
+	if err != nil {
+		return err
+	}
+	if user.ActivationSentAt == nil || time.Since(*user.ActivationSentAt) > expiration {
+		return errors.New("invalid or expired token")
+	}
+	
+	// Activate and invalidate the token so it cannot be reused
+	now := time.Now()
+	user.Activated = true
+	user.ActivatedAt = &now
+	user.ActivationDigest = ""
+	user.ActivationSentAt = nil
+	
+	return u.userRepo.Update(ctx, user)
+}
+
+// UnlockAccount lifts a login lockout with the token from the unlock email
+func (u *userUsecase) UnlockAccount(ctx context.Context, token string) error {
+	if token == "" {
+		return errors.New("invalid or expired token")
+	}
+	
+	user, err := u.userRepo.FindByUnlockDigest(ctx, utils.HashToken(token))
+	if err != nil {
+		return err
+	}
+	if user == nil || !utils.CompareTokenHash(token, user.UnlockDigest) {
+		return errors.New("invalid or expired token")
+	}
+	
+	// Check expiration
+	expiration, err := time.ParseDuration(u.config.Auth.UnlockExpiration)
+	if err != nil {
+		return err
+	}
+	if user.UnlockSentAt == nil || time.Since(*user.UnlockSentAt) > expiration {
+		return errors.New("invalid or expired token")
+	}
+	
+	if err := u.loginGuard.reset(ctx, user.Email); err != nil {
+		return err
+	}
+	
+	user.UnlockDigest = ""
+	user.UnlockSentAt = nil
+	return u.userRepo.Update(ctx, user)
 }
 
 // ResendActivationEmail resends activation email
@@ -289,9 +640,20 @@ func (u *userUsecase) ResendActivationEmail(ctx context.Context, email string) e
 		return errors.New("account already activated")
 	}
 	
-	// TODO: Generate activation token and send email
-	// For now, just return nil
-	return nil
+	// Generate a new activation token (invalidates the previous one)
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return err
+	}
+	
+	now := time.Now()
+	user.ActivationDigest = utils.HashToken(token)
+	user.ActivationSentAt = &now
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	
+	return u.sendActivationEmail(ctx, user, token)
 }
 
 // UpdateProfile updates user profile
@@ -304,12 +666,157 @@ func (u *userUsecase) UpdateProfile(ctx context.Context, userID uint, name, pict
 		return errors.New("user not found")
 	}
 	
+	changes := usecase.Changes{}
 	if name != "" {
+		changes.Track("name", user.Name, name)
 		user.Name = name
 	}
 	if picture != "" {
+		changes.Track("picture", user.Picture, picture)
 		user.Picture = picture
 	}
 	
-	return u.userRepo.Update(ctx, user)
-}
\ No newline at end of file
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	return u.audit.RecordChanges(ctx, userID, domain.AuditActionUserProfileUpdate, domain.AuditTargetUser, userID, changes)
+}
+
+// completeLogin finishes any first-factor login: it enforces suspension and
+// two-factor authentication, then starts a session
+func (u *userUsecase) completeLogin(ctx context.Context, user *domain.User, client dto.ClientInfo) (*dto.AuthResponse, *dto.TwoFactorChallengeResponse, error) {
+	if user.IsSuspended(time.Now()) {
+		return nil, nil, errors.New("account suspended")
+	}
+	
+	if user.TwoFactorEnabled() {
+		challenge, err := u.issueTwoFactorChallenge(user)
+		return nil, challenge, err
+	}
+	
+	resp, err := u.createSession(ctx, user, client)
+	return resp, nil, err
+}
+
+// createSession starts a new login session for the user
+func (u *userUsecase) createSession(ctx context.Context, user *domain.User, client dto.ClientInfo) (*dto.AuthResponse, error) {
+	refreshDuration, err := time.ParseDuration(u.config.JWT.RefreshExpiration)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Signing in cancels a pending account deletion
+	if user.DeletionPending() {
+		user.DeletionScheduledAt = nil
+		if err := u.userRepo.Update(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+	
+	now := time.Now()
+	session := &domain.Session{
+		UserID:     user.ID,
+		DeviceName: client.DeviceName,
+		IPAddress:  client.IPAddress,
+		UserAgent:  client.UserAgent,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(refreshDuration),
+	}
+	if err := u.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+	
+	if err := u.audit.Record(ctx, user.ID, domain.AuditActionUserLogin, domain.AuditTargetSession, session.ID, map[string]interface{}{
+		"device_name": client.DeviceName,
+	}); err != nil {
+		return nil, err
+	}
+
+	return u.issueTokens(ctx, user, session)
+}
+
+// issueTokens issues an access token and a new refresh token for a session
+func (u *userUsecase) issueTokens(ctx context.Context, user *domain.User, session *domain.Session) (*dto.AuthResponse, error) {
+	refreshToken, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.refreshTokenRepo.Create(ctx, &domain.RefreshToken{
+		SessionID:   session.ID,
+		TokenDigest: utils.HashToken(refreshToken),
+	}); err != nil {
+		return nil, err
+	}
+	
+	// Generate JWT token
+	token, err := u.keys.Sign(&utils.Claims{
+		UserID:    user.ID,
+		Email:     user.Email,
+		SessionID: session.ID,
+	}, u.config.JWT.Expiration)
+	if err != nil {
+		return nil, err
+	}
+	
+	duration, _ := time.ParseDuration(u.config.JWT.Expiration)
+	expiresAt := time.Now().Add(duration)
+	
+	return &dto.AuthResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+		User:         newUserResponse(user),
+	}, nil
+}
+
+// sendActivationEmail sends the account activation link
+func (u *userUsecase) sendActivationEmail(ctx context.Context, user *domain.User, token string) error {
+	msg, err := mailer.Render("activation", user.Email, map[string]interface{}{
+		"Name":      user.Name,
+		"URL":       u.config.App.FrontendURL + "/activate?token=" + url.QueryEscape(token),
+		"ExpiresIn": u.config.Auth.ActivationExpiration,
+	})
+	if err != nil {
+		return err
+	}
+	return u.mailer.Send(ctx, msg)
+}
+
+// sendUnlockEmail issues a new unlock token and mails it to a locked-out user
+func (u *userUsecase) sendUnlockEmail(ctx context.Context, user *domain.User) error {
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return err
+	}
+	
+	now := time.Now()
+	user.UnlockDigest = utils.HashToken(token)
+	user.UnlockSentAt = &now
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	
+	msg, err := mailer.Render("unlock", user.Email, map[string]interface{}{
+		"Name":            user.Name,
+		"URL":             u.config.App.FrontendURL + "/unlock?token=" + url.QueryEscape(token),
+		"ExpiresIn":       u.config.Auth.UnlockExpiration,
+		"LockoutDuration": u.config.Auth.LoginLockoutDuration,
+	})
+	if err != nil {
+		return err
+	}
+	return u.mailer.Send(ctx, msg)
+}
+
+// newUserResponse converts a domain user to response DTO
+func newUserResponse(user *domain.User) dto.UserResponse {
+	return dto.UserResponse{
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		Picture:   user.Picture,
+		Admin:     user.Admin,
+		Activated: user.Activated,
+		CreatedAt: user.CreatedAt,
+	}
+}
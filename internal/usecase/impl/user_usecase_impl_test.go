@@ -3,13 +3,21 @@ package impl
 import (
 	"context"
 	"errors"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/House-lovers7/speadwear-go/internal/domain"
 	"github.com/House-lovers7/speadwear-go/internal/dto"
 	"github.com/House-lovers7/speadwear-go/internal/repository"
 	"github.com/House-lovers7/speadwear-go/internal/testutil"
 	"github.com/House-lovers7/speadwear-go/pkg/config"
+	"github.com/House-lovers7/speadwear-go/pkg/mailer"
+	"github.com/House-lovers7/speadwear-go/pkg/oidc"
+	"github.com/House-lovers7/speadwear-go/pkg/oidc/oidctest"
+	"github.com/House-lovers7/speadwear-go/pkg/password"
+	"github.com/House-lovers7/speadwear-go/pkg/totp"
 	"github.com/House-lovers7/speadwear-go/pkg/utils"
 )
 
@@ -19,17 +27,57 @@ func setupUserUsecase(t *testing.T) (*userUsecase, *testutil.Fixtures) {
 	repos := repository.NewContainer(db)
 	cfg := &config.Config{
 		JWT: config.JWTConfig{
-			Secret:     "test-secret",
-			Expiration: "24h",
+			Secret:            "test-secret",
+			Expiration:        "15m",
+			RefreshExpiration: "720h",
 		},
 		Upload: config.UploadConfig{
 			Path:        "./test-uploads",
 			MaxFileSize: 5 * 1024 * 1024,
 		},
+		Auth: config.AuthConfig{
+			ActivationExpiration:  "24h",
+			PasswordResetLimit:    3,
+			PasswordResetWindow:   "1h",
+			LoginMaxAttempts:      5,
+			LoginIPMaxAttempts:    50,
+			LoginLockoutDuration:  "15m",
+			UnlockExpiration:      "24h",
+			TwoFactorIssuer:       "Speadwear",
+			ChallengeExpiration:   "5m",
+			MagicLinkExpiration:   "15m",
+			MagicLinkLimit:        3,
+			MagicLinkWindow:       "1h",
+			EmailChangeExpiration: "24h",
+			EmailRevertExpiration: "168h",
+			DeletionGracePeriod:   "720h",
+		},
+		Password: config.PasswordConfig{
+			MinLength:   8,
+			MinClasses:  2,
+			HistorySize: 3,
+		},
+		OIDC: config.OIDCConfig{
+			StateExpiration: "10m",
+			LinkExpiration:  "1h",
+		},
 	}
 	
 	usecase := NewUserUsecase(
 		repos.User,
+		repos.Session,
+		repos.RefreshToken,
+		repos.RecoveryCode,
+		repos.UserIdentity,
+		repos.OAuthState,
+		repos.PasswordHistory,
+		repos.AccessToken,
+		repository.NewMemoryLoginAttemptStore(),
+		map[string]*oidc.Client{},
+		utils.NewHMACKeySet(cfg.JWT.Secret),
+		&password.Policy{MinLength: cfg.Password.MinLength, MinClasses: cfg.Password.MinClasses},
+		mailer.NewMemoryMailer(),
+		NewAuditLogger(repos.AuditEvent),
 		cfg,
 	).(*userUsecase)
 	
@@ -118,11 +166,11 @@ func TestUserUsecase_Signup(t *testing.T) {
 				if resp == nil {
 					t.Error("Signup() returned nil response")
 				}
-				if resp != nil && resp.Token == "" {
-					t.Error("Signup() returned empty token")
+				if resp != nil && resp.Activated {
+					t.Error("Signup() should create an inactive user")
 				}
-				if resp != nil && resp.User.Email != tt.req.Email {
-					t.Errorf("Signup() user email = %v, want %v", resp.User.Email, tt.req.Email)
+				if resp != nil && resp.Email != tt.req.Email {
+					t.Errorf("Signup() user email = %v, want %v", resp.Email, tt.req.Email)
 				}
 			}
 		})
@@ -187,7 +235,7 @@ func TestUserUsecase_Login(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			resp, err := usecase.Login(ctx, tt.email, tt.password)
+			resp, _, err := usecase.Login(ctx, tt.email, tt.password, dto.ClientInfo{})
 			
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Login() error = %v, wantErr %v", err, tt.wantErr)
@@ -215,6 +263,456 @@ func TestUserUsecase_Login(t *testing.T) {
 	}
 }
 
+func TestUserUsecase_LoginLockout(t *testing.T) {
+	usecase, fixtures := setupUserUsecase(t)
+	ctx := context.Background()
+	outbox := usecase.mailer.(*mailer.MemoryMailer)
+
+	plainPassword := "testpassword123"
+	hashedPassword, _ := utils.HashPassword(plainPassword)
+	testUser := fixtures.CreateUser(func(u *domain.User) {
+		u.Email = "lockout@example.com"
+		u.PasswordDigest = hashedPassword
+		u.Activated = true
+	})
+	client := dto.ClientInfo{IPAddress: "192.0.2.1"}
+
+	// Step the clock past the progressive delays so every attempt is counted
+	clock := time.Now()
+	usecase.loginGuard.now = func() time.Time { return clock }
+
+	for i := 0; i < 5; i++ {
+		clock = clock.Add(time.Minute)
+		_, _, err := usecase.Login(ctx, testUser.Email, "wrongpassword", client)
+		if err == nil || err.Error() != "invalid email or password" {
+			t.Fatalf("Login() error = %v, want invalid email or password", err)
+		}
+	}
+
+	// Locked even with the right password
+	_, _, err := usecase.Login(ctx, testUser.Email, plainPassword, client)
+	if err == nil || err.Error() != "too many login attempts" {
+		t.Errorf("Login() error = %v, want too many login attempts", err)
+	}
+
+	// Unknown accounts lock out the same way
+	for i := 0; i < 5; i++ {
+		clock = clock.Add(time.Minute)
+		usecase.Login(ctx, "nobody@example.com", "wrongpassword", dto.ClientInfo{})
+	}
+	_, _, err = usecase.Login(ctx, "nobody@example.com", "wrongpassword", dto.ClientInfo{})
+	if err == nil || err.Error() != "too many login attempts" {
+		t.Errorf("Login() error = %v, want too many login attempts", err)
+	}
+
+	// Only the existing account gets an unlock email
+	if len(outbox.Messages()) != 1 {
+		t.Fatalf("sent %d emails, want 1", len(outbox.Messages()))
+	}
+	body := outbox.Last().Body
+	token := body[strings.Index(body, "token=")+len("token="):]
+	token, _ = url.QueryUnescape(strings.TrimSpace(token[:strings.Index(token, "\n")]))
+
+	if err := usecase.UnlockAccount(ctx, token); err != nil {
+		t.Fatalf("UnlockAccount() error = %v", err)
+	}
+	if err := usecase.UnlockAccount(ctx, token); err == nil {
+		t.Error("UnlockAccount() should reject a used token")
+	}
+
+	if _, _, err := usecase.Login(ctx, testUser.Email, plainPassword, client); err != nil {
+		t.Errorf("Login() after unlock error = %v", err)
+	}
+}
+
+func TestUserUsecase_TwoFactor(t *testing.T) {
+	usecase, fixtures := setupUserUsecase(t)
+	ctx := context.Background()
+
+	plainPassword := "testpassword123"
+	hashedPassword, _ := utils.HashPassword(plainPassword)
+	testUser := fixtures.CreateUser(func(u *domain.User) {
+		u.Email = "2fa@example.com"
+		u.PasswordDigest = hashedPassword
+		u.Activated = true
+	})
+
+	enrollment, err := usecase.EnrollTwoFactor(ctx, testUser.ID)
+	if err != nil {
+		t.Fatalf("EnrollTwoFactor() error = %v", err)
+	}
+	if len(enrollment.RecoveryCodes) != recoveryCodeCount || !strings.HasPrefix(enrollment.OTPAuthURI, "otpauth://totp/") {
+		t.Fatalf("EnrollTwoFactor() = %+v", enrollment)
+	}
+
+	// Not enforced until verified
+	if resp, _, err := usecase.Login(ctx, testUser.Email, plainPassword, dto.ClientInfo{}); err != nil || resp == nil {
+		t.Fatalf("Login() before verification = %v, %v", resp, err)
+	}
+
+	// Verify with the previous step so the current one is still usable below
+	previous, _ := totp.Code(enrollment.Secret, totp.Step(time.Now())-1)
+	if err := usecase.VerifyTwoFactor(ctx, testUser.ID, previous); err != nil {
+		t.Fatalf("VerifyTwoFactor() error = %v", err)
+	}
+
+	resp, challenge, err := usecase.Login(ctx, testUser.Email, plainPassword, dto.ClientInfo{})
+	if err != nil || resp != nil || challenge == nil || !challenge.TwoFactorRequired {
+		t.Fatalf("Login() = %v, %v, %v, want challenge", resp, challenge, err)
+	}
+
+	if _, err := usecase.LoginTwoFactor(ctx, challenge.ChallengeToken, "000000", dto.ClientInfo{}); err == nil {
+		t.Error("LoginTwoFactor() should reject a wrong code")
+	}
+
+	code, _ := totp.Code(enrollment.Secret, totp.Step(time.Now()))
+	resp, err = usecase.LoginTwoFactor(ctx, challenge.ChallengeToken, code, dto.ClientInfo{})
+	if err != nil || resp.Token == "" {
+		t.Fatalf("LoginTwoFactor() = %v, %v", resp, err)
+	}
+
+	// The same code can't be replayed
+	if _, err := usecase.LoginTwoFactor(ctx, challenge.ChallengeToken, code, dto.ClientInfo{}); err == nil {
+		t.Error("LoginTwoFactor() should reject a replayed code")
+	}
+
+	// Recovery codes work once
+	recovery := enrollment.RecoveryCodes[0]
+	if _, err := usecase.LoginTwoFactor(ctx, challenge.ChallengeToken, recovery, dto.ClientInfo{}); err != nil {
+		t.Errorf("LoginTwoFactor() with recovery code error = %v", err)
+	}
+	if _, err := usecase.LoginTwoFactor(ctx, challenge.ChallengeToken, recovery, dto.ClientInfo{}); err == nil {
+		t.Error("LoginTwoFactor() should reject a used recovery code")
+	}
+
+	if err := usecase.DisableTwoFactor(ctx, testUser.ID, plainPassword, enrollment.RecoveryCodes[1]); err != nil {
+		t.Fatalf("DisableTwoFactor() error = %v", err)
+	}
+	if resp, _, err := usecase.Login(ctx, testUser.Email, plainPassword, dto.ClientInfo{}); err != nil || resp == nil {
+		t.Errorf("Login() after disabling = %v, %v", resp, err)
+	}
+}
+
+func TestUserUsecase_MagicLink(t *testing.T) {
+	usecase, fixtures := setupUserUsecase(t)
+	ctx := context.Background()
+	outbox := usecase.mailer.(*mailer.MemoryMailer)
+
+	testUser := fixtures.CreateUser(func(u *domain.User) {
+		u.Email = "magic@example.com"
+		u.Activated = true
+	})
+	fixtures.CreateUser(func(u *domain.User) {
+		u.Email = "inactive@example.com"
+		u.Activated = false
+	})
+
+	// No link for unknown or inactive accounts
+	if err := usecase.RequestMagicLink(ctx, "nobody@example.com"); err == nil {
+		t.Error("RequestMagicLink() should fail for an unknown email")
+	}
+	if err := usecase.RequestMagicLink(ctx, "inactive@example.com"); err == nil || err.Error() != "account not activated" {
+		t.Errorf("RequestMagicLink() error = %v, want account not activated", err)
+	}
+	if len(outbox.Messages()) != 0 {
+		t.Fatalf("sent %d emails, want 0", len(outbox.Messages()))
+	}
+
+	if err := usecase.RequestMagicLink(ctx, testUser.Email); err != nil {
+		t.Fatalf("RequestMagicLink() error = %v", err)
+	}
+	body := outbox.Last().Body
+	token := body[strings.Index(body, "token=")+len("token="):]
+	token, _ = url.QueryUnescape(strings.TrimSpace(token[:strings.Index(token, "\n")]))
+
+	resp, challenge, err := usecase.MagicLinkLogin(ctx, token, dto.ClientInfo{DeviceName: "phone"})
+	if err != nil {
+		t.Fatalf("MagicLinkLogin() error = %v", err)
+	}
+	if challenge != nil || resp == nil || resp.Token == "" || resp.User.ID != testUser.ID {
+		t.Errorf("MagicLinkLogin() = %+v, %+v, want tokens for user %d", resp, challenge, testUser.ID)
+	}
+
+	// Single use
+	if _, _, err := usecase.MagicLinkLogin(ctx, token, dto.ClientInfo{}); err == nil || err.Error() != "invalid or expired token" {
+		t.Errorf("MagicLinkLogin() error = %v, want invalid or expired token", err)
+	}
+
+	// Expired links are refused
+	if err := usecase.RequestMagicLink(ctx, testUser.Email); err != nil {
+		t.Fatalf("RequestMagicLink() error = %v", err)
+	}
+	body = outbox.Last().Body
+	token = body[strings.Index(body, "token=")+len("token="):]
+	token, _ = url.QueryUnescape(strings.TrimSpace(token[:strings.Index(token, "\n")]))
+
+	user, _ := usecase.userRepo.FindByID(ctx, testUser.ID)
+	sentAt := time.Now().Add(-time.Hour)
+	user.MagicLinkSentAt = &sentAt
+	usecase.userRepo.Update(ctx, user)
+
+	if _, _, err := usecase.MagicLinkLogin(ctx, token, dto.ClientInfo{}); err == nil || err.Error() != "invalid or expired token" {
+		t.Errorf("MagicLinkLogin() error = %v, want invalid or expired token", err)
+	}
+}
+
+func TestUserUsecase_MagicLinkRateLimit(t *testing.T) {
+	usecase, _ := setupUserUsecase(t)
+	ctx := context.Background()
+	email := "limited@example.com"
+
+	// Password reset requests don't use up the magic link allowance
+	for i := 0; i < usecase.config.Auth.PasswordResetLimit; i++ {
+		usecase.ResetPasswordRequest(ctx, email)
+	}
+	if err := usecase.ResetPasswordRequest(ctx, email); err == nil || err.Error() != "too many requests" {
+		t.Fatalf("ResetPasswordRequest() error = %v, want too many requests", err)
+	}
+
+	for i := 0; i < usecase.config.Auth.MagicLinkLimit; i++ {
+		if err := usecase.RequestMagicLink(ctx, email); err != nil && err.Error() == "too many requests" {
+			t.Fatalf("RequestMagicLink() #%d was rate limited", i+1)
+		}
+	}
+	if err := usecase.RequestMagicLink(ctx, email); err == nil || err.Error() != "too many requests" {
+		t.Errorf("RequestMagicLink() error = %v, want too many requests", err)
+	}
+}
+
+func TestUserUsecase_EmailChange(t *testing.T) {
+	usecase, fixtures := setupUserUsecase(t)
+	ctx := context.Background()
+	outbox := usecase.mailer.(*mailer.MemoryMailer)
+
+	testUser := fixtures.CreateUser(func(u *domain.User) {
+		u.Email = "old@example.com"
+		u.Activated = true
+	})
+	fixtures.CreateUser(func(u *domain.User) {
+		u.Email = "taken@example.com"
+	})
+	extractToken := func(body string) string {
+		token := body[strings.Index(body, "token=")+len("token="):]
+		token, _ = url.QueryUnescape(strings.TrimSpace(token[:strings.Index(token, "\n")]))
+		return token
+	}
+
+	if err := usecase.UpdateUser(ctx, testUser.ID, map[string]interface{}{"email": "taken@example.com"}); err == nil || err.Error() != "email already exists" {
+		t.Errorf("UpdateUser() error = %v, want email already exists", err)
+	}
+
+	if err := usecase.UpdateUser(ctx, testUser.ID, map[string]interface{}{"email": "new@example.com"}); err != nil {
+		t.Fatalf("UpdateUser() error = %v", err)
+	}
+
+	// Nothing changes until the new address confirms
+	user, _ := usecase.userRepo.FindByID(ctx, testUser.ID)
+	if user.Email != "old@example.com" || user.UnconfirmedEmail != "new@example.com" {
+		t.Errorf("Email = %s, UnconfirmedEmail = %s, want pending change", user.Email, user.UnconfirmedEmail)
+	}
+
+	messages := outbox.Messages()
+	if len(messages) != 2 || messages[0].To != "new@example.com" || messages[1].To != "old@example.com" {
+		t.Fatalf("emails = %+v, want confirmation to new and notice to old address", messages)
+	}
+	confirmToken := extractToken(messages[0].Body)
+	cancelToken := extractToken(messages[1].Body)
+
+	if err := usecase.ConfirmEmailChange(ctx, confirmToken); err != nil {
+		t.Fatalf("ConfirmEmailChange() error = %v", err)
+	}
+	if err := usecase.ConfirmEmailChange(ctx, confirmToken); err == nil {
+		t.Error("ConfirmEmailChange() should reject a used token")
+	}
+	user, _ = usecase.userRepo.FindByID(ctx, testUser.ID)
+	if user.Email != "new@example.com" || user.UnconfirmedEmail != "" {
+		t.Errorf("Email = %s, UnconfirmedEmail = %s, want new@example.com", user.Email, user.UnconfirmedEmail)
+	}
+
+	// A second change can't be used to invalidate the old address's cancel link
+	if err := usecase.UpdateUser(ctx, testUser.ID, map[string]interface{}{"email": "other@example.com"}); err == nil || err.Error() != "email recently changed" {
+		t.Errorf("UpdateUser() error = %v, want email recently changed", err)
+	}
+
+	// The old address can still revert the completed change
+	if err := usecase.CancelEmailChange(ctx, cancelToken); err != nil {
+		t.Fatalf("CancelEmailChange() error = %v", err)
+	}
+	user, _ = usecase.userRepo.FindByID(ctx, testUser.ID)
+	if user.Email != "old@example.com" {
+		t.Errorf("Email = %s, want old@example.com after revert", user.Email)
+	}
+	if err := usecase.CancelEmailChange(ctx, cancelToken); err == nil {
+		t.Error("CancelEmailChange() should reject a used token")
+	}
+}
+
+func TestUserUsecase_OIDCLogin(t *testing.T) {
+	usecase, fixtures := setupUserUsecase(t)
+	ctx := context.Background()
+	outbox := usecase.mailer.(*mailer.MemoryMailer)
+
+	provider := oidctest.NewServer("speadwear")
+	defer provider.Close()
+	usecase.oidcClients["mock"] = oidc.NewClient(oidc.Config{
+		Issuer:      provider.Issuer(),
+		ClientID:    "speadwear",
+		RedirectURL: "http://localhost:3000/auth/oidc/mock/callback",
+	}, nil)
+
+	// signIn runs the browser part of the flow against the mock provider
+	signIn := func(user oidctest.User) (*dto.AuthResponse, error) {
+		authorization, err := usecase.BeginOIDCLogin(ctx, "mock", nil)
+		if err != nil {
+			t.Fatalf("BeginOIDCLogin() error = %v", err)
+		}
+		code, state, err := provider.Approve(authorization.AuthorizationURL, user)
+		if err != nil {
+			t.Fatalf("Approve() error = %v", err)
+		}
+		resp, _, err := usecase.OIDCLogin(ctx, "mock", code, state, dto.ClientInfo{})
+		return resp, err
+	}
+
+	t.Run("first sign-in creates an activated account", func(t *testing.T) {
+		resp, err := signIn(oidctest.User{Subject: "new-user", Email: "oidc-new@example.com", EmailVerified: true, Name: "New User"})
+		if err != nil {
+			t.Fatalf("OIDCLogin() error = %v", err)
+		}
+		if resp.Token == "" || !resp.User.Activated || resp.User.Email != "oidc-new@example.com" {
+			t.Errorf("OIDCLogin() = %+v", resp)
+		}
+
+		// Signing in again reuses the identity
+		again, err := signIn(oidctest.User{Subject: "new-user", Email: "oidc-new@example.com", EmailVerified: true})
+		if err != nil || again.User.ID != resp.User.ID {
+			t.Errorf("OIDCLogin() second sign-in = %v, %v", again, err)
+		}
+	})
+
+	t.Run("unverified email is rejected", func(t *testing.T) {
+		_, err := signIn(oidctest.User{Subject: "unverified", Email: "unverified@example.com"})
+		if err == nil || err.Error() != "email not verified by provider" {
+			t.Errorf("OIDCLogin() error = %v, want email not verified by provider", err)
+		}
+	})
+
+	t.Run("state can only be used once", func(t *testing.T) {
+		authorization, _ := usecase.BeginOIDCLogin(ctx, "mock", nil)
+		code, state, _ := provider.Approve(authorization.AuthorizationURL, oidctest.User{Subject: "once", Email: "once@example.com", EmailVerified: true})
+		usecase.OIDCLogin(ctx, "mock", code, state, dto.ClientInfo{})
+		_, _, err := usecase.OIDCLogin(ctx, "mock", code, state, dto.ClientInfo{})
+		if err == nil || err.Error() != "invalid state" {
+			t.Errorf("OIDCLogin() error = %v, want invalid state", err)
+		}
+	})
+
+	t.Run("existing email requires confirmation", func(t *testing.T) {
+		existing := fixtures.CreateUser(func(u *domain.User) {
+			u.Email = "oidc-existing@example.com"
+			u.Activated = true
+		})
+
+		_, err := signIn(oidctest.User{Subject: "existing", Email: existing.Email, EmailVerified: true})
+		if err == nil || err.Error() != "link confirmation required" {
+			t.Fatalf("OIDCLogin() error = %v, want link confirmation required", err)
+		}
+
+		body := outbox.Last().Body
+		token := body[strings.Index(body, "token=")+len("token="):]
+		token, _ = url.QueryUnescape(strings.TrimSpace(token[:strings.Index(token, "\n")]))
+		if err := usecase.ConfirmIdentityLink(ctx, token); err != nil {
+			t.Fatalf("ConfirmIdentityLink() error = %v", err)
+		}
+
+		resp, err := signIn(oidctest.User{Subject: "existing", Email: existing.Email, EmailVerified: true})
+		if err != nil || resp.User.ID != existing.ID {
+			t.Errorf("OIDCLogin() after linking = %v, %v", resp, err)
+		}
+	})
+
+	t.Run("linking an unactivated account drops its password", func(t *testing.T) {
+		// Someone signed up with the address before its owner did
+		hashedPassword, _ := utils.HashPassword("squatter123")
+		squatted := fixtures.CreateUser(func(u *domain.User) {
+			u.Email = "oidc-squatted@example.com"
+			u.PasswordDigest = hashedPassword
+			u.Activated = false
+		})
+		token := &domain.PersonalAccessToken{UserID: squatted.ID, Name: "squatter", TokenDigest: utils.HashToken("squatter-token"), TokenPrefix: "spw_squat", Scopes: "read"}
+		if err := usecase.accessTokenRepo.Create(ctx, token); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		_, err := signIn(oidctest.User{Subject: "squatted", Email: squatted.Email, EmailVerified: true})
+		if err == nil || err.Error() != "link confirmation required" {
+			t.Fatalf("OIDCLogin() error = %v, want link confirmation required", err)
+		}
+		body := outbox.Last().Body
+		link := body[strings.Index(body, "token=")+len("token="):]
+		link, _ = url.QueryUnescape(strings.TrimSpace(link[:strings.Index(link, "\n")]))
+		if err := usecase.ConfirmIdentityLink(ctx, link); err != nil {
+			t.Fatalf("ConfirmIdentityLink() error = %v", err)
+		}
+
+		user, _ := usecase.userRepo.FindByID(ctx, squatted.ID)
+		if !user.Activated || user.PasswordDigest != "" || user.PasswordChangedAt == nil {
+			t.Errorf("Activated = %v, PasswordDigest = %q, PasswordChangedAt = %v", user.Activated, user.PasswordDigest, user.PasswordChangedAt)
+		}
+		if _, _, err := usecase.Login(ctx, squatted.Email, "squatter123", dto.ClientInfo{}); err == nil {
+			t.Error("Login() with the sign-up password should fail")
+		}
+		token, _ = usecase.accessTokenRepo.FindByID(ctx, token.ID)
+		if token.RevokedAt == nil {
+			t.Error("personal access token should be revoked")
+		}
+
+		resp, err := signIn(oidctest.User{Subject: "squatted", Email: squatted.Email, EmailVerified: true})
+		if err != nil || resp.User.ID != squatted.ID {
+			t.Errorf("OIDCLogin() after linking = %v, %v", resp, err)
+		}
+	})
+}
+
+func TestUserUsecase_RefreshToken(t *testing.T) {
+	usecase, fixtures := setupUserUsecase(t)
+	ctx := context.Background()
+
+	plainPassword := "testpassword123"
+	hashedPassword, _ := utils.HashPassword(plainPassword)
+	testUser := fixtures.CreateUser(func(u *domain.User) {
+		u.Email = "refresh@example.com"
+		u.PasswordDigest = hashedPassword
+		u.Activated = true
+	})
+
+	loginResp, _, err := usecase.Login(ctx, testUser.Email, plainPassword, dto.ClientInfo{DeviceName: "test"})
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	// Rotation issues a new refresh token
+	refreshed, err := usecase.RefreshToken(ctx, loginResp.RefreshToken, dto.ClientInfo{})
+	if err != nil {
+		t.Fatalf("RefreshToken() error = %v", err)
+	}
+	if refreshed.RefreshToken == "" || refreshed.RefreshToken == loginResp.RefreshToken {
+		t.Error("RefreshToken() should issue a new refresh token")
+	}
+
+	// Reusing the old token revokes the session
+	_, err = usecase.RefreshToken(ctx, loginResp.RefreshToken, dto.ClientInfo{})
+	if err == nil || err.Error() != "refresh token reuse detected" {
+		t.Errorf("RefreshToken() error = %v, want reuse detected", err)
+	}
+
+	// The rotated token belongs to the revoked session and is no longer valid
+	_, err = usecase.RefreshToken(ctx, refreshed.RefreshToken, dto.ClientInfo{})
+	if err == nil || err.Error() != "invalid refresh token" {
+		t.Errorf("RefreshToken() error = %v, want invalid refresh token", err)
+	}
+}
+
 func TestUserUsecase_GetUser(t *testing.T) {
 	usecase, fixtures := setupUserUsecase(t)
 	ctx := context.Background()
@@ -265,6 +763,51 @@ func TestUserUsecase_GetUser(t *testing.T) {
 	}
 }
 
+func TestUserUsecase_DeleteUser(t *testing.T) {
+	usecase, fixtures := setupUserUsecase(t)
+	ctx := context.Background()
+
+	testUser := fixtures.CreateUser(func(u *domain.User) {
+		u.Email = "leaving@example.com"
+		u.Activated = true
+	})
+	resp, _, err := usecase.Login(ctx, testUser.Email, "password123", dto.ClientInfo{})
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	scheduledAt, err := usecase.DeleteUser(ctx, testUser.ID)
+	if err != nil {
+		t.Fatalf("DeleteUser() error = %v", err)
+	}
+	if scheduledAt.Before(time.Now().Add(719 * time.Hour)) {
+		t.Errorf("DeleteUser() scheduled at %v, want after the grace period", scheduledAt)
+	}
+
+	// Signed out and hidden during the grace period
+	if _, err := usecase.RefreshToken(ctx, resp.RefreshToken, dto.ClientInfo{}); err == nil {
+		t.Error("RefreshToken() should fail once deletion is requested")
+	}
+	if _, err := usecase.GetUser(ctx, testUser.ID); err == nil || err.Error() != "user not found" {
+		t.Errorf("GetUser() error = %v, want user not found", err)
+	}
+
+	// Asking again keeps the original schedule
+	again, err := usecase.DeleteUser(ctx, testUser.ID)
+	if err != nil || again.Sub(scheduledAt).Abs() > time.Second {
+		t.Errorf("DeleteUser() = %v, %v, want %v", again, err, scheduledAt)
+	}
+
+	// Logging in cancels the deletion
+	if _, _, err := usecase.Login(ctx, testUser.Email, "password123", dto.ClientInfo{}); err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	user, _ := usecase.GetUser(ctx, testUser.ID)
+	if user == nil || user.DeletionPending() {
+		t.Error("Login() should cancel the pending deletion")
+	}
+}
+
 func TestUserUsecase_UpdateProfile(t *testing.T) {
 	usecase, fixtures := setupUserUsecase(t)
 	ctx := context.Background()
@@ -390,7 +933,7 @@ func TestUserUsecase_ChangePassword(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := usecase.ChangePassword(ctx, tt.userID, tt.currentPassword, tt.newPassword)
+			err := usecase.ChangePassword(ctx, tt.userID, 0, tt.currentPassword, tt.newPassword, true)
 			
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ChangePassword() error = %v, wantErr %v", err, tt.wantErr)
@@ -405,21 +948,79 @@ func TestUserUsecase_ChangePassword(t *testing.T) {
 			
 			if !tt.wantErr {
 				// Try logging in with new password
-				_, err := usecase.Login(ctx, testUser.Email, tt.newPassword)
+				_, _, err := usecase.Login(ctx, testUser.Email, tt.newPassword, dto.ClientInfo{})
 				if err != nil {
 					t.Error("ChangePassword() failed: cannot login with new password")
 				}
 				
 				// Try logging in with old password (should fail)
-				_, err = usecase.Login(ctx, testUser.Email, currentPassword)
+				_, _, err = usecase.Login(ctx, testUser.Email, currentPassword, dto.ClientInfo{})
 				if err == nil {
 					t.Error("ChangePassword() failed: can still login with old password")
 				}
+
+				// Access tokens issued before the change are rejected
+				user, _ := usecase.userRepo.FindByID(ctx, testUser.ID)
+				if user.PasswordChangedAt == nil {
+					t.Error("ChangePassword() did not record PasswordChangedAt")
+				}
 			}
 		})
 	}
 }
 
+func TestUserUsecase_PasswordPolicy(t *testing.T) {
+	usecase, fixtures := setupUserUsecase(t)
+	ctx := context.Background()
+	
+	hashedPassword, _ := utils.HashPassword("firstpassword1")
+	user := fixtures.CreateUser(func(u *domain.User) {
+		u.PasswordDigest = hashedPassword
+	})
+	
+	// Policy violations are rejected before anything changes
+	if err := usecase.ChangePassword(ctx, user.ID, 0, "firstpassword1", "allletters", true); err != password.ErrTooSimple {
+		t.Errorf("ChangePassword(too simple) error = %v, want %v", err, password.ErrTooSimple)
+	}
+	if err := usecase.ChangePassword(ctx, user.ID, 0, "firstpassword1", "firstpassword1", true); err != password.ErrReused {
+		t.Errorf("ChangePassword(current) error = %v, want %v", err, password.ErrReused)
+	}
+	
+	// With a history size of 3 the two passwords before the current one stay blocked
+	for i, next := range []string{"secondpassword2", "thirdpassword3"} {
+		previous := []string{"firstpassword1", "secondpassword2"}[i]
+		if err := usecase.ChangePassword(ctx, user.ID, 0, previous, next, true); err != nil {
+			t.Fatalf("ChangePassword(%s) error = %v", next, err)
+		}
+	}
+	if err := usecase.ChangePassword(ctx, user.ID, 0, "thirdpassword3", "firstpassword1", true); err != password.ErrReused {
+		t.Errorf("ChangePassword(history) error = %v, want %v", err, password.ErrReused)
+	}
+	if err := usecase.ChangePassword(ctx, user.ID, 0, "thirdpassword3", "fourthpassword4", true); err != nil {
+		t.Fatalf("ChangePassword() error = %v", err)
+	}
+	// firstpassword1 has now dropped out of the history
+	histories, _ := usecase.passwordHistoryRepo.FindRecentByUserID(ctx, user.ID, 10)
+	if len(histories) != 2 {
+		t.Errorf("password history has %d entries, want 2", len(histories))
+	}
+	
+	// A rejected reset keeps the token usable
+	outbox := usecase.mailer.(*mailer.MemoryMailer)
+	if err := usecase.ResetPasswordRequest(ctx, user.Email); err != nil {
+		t.Fatalf("ResetPasswordRequest() error = %v", err)
+	}
+	body := outbox.Last().Body
+	token := body[strings.Index(body, "token=")+len("token="):]
+	token, _ = url.QueryUnescape(strings.TrimSpace(token[:strings.Index(token, "\n")]))
+	if err := usecase.ResetPassword(ctx, token, "thirdpassword3"); err != password.ErrReused {
+		t.Errorf("ResetPassword(history) error = %v, want %v", err, password.ErrReused)
+	}
+	if err := usecase.ResetPassword(ctx, token, "fifthpassword5"); err != nil {
+		t.Errorf("ResetPassword() error = %v", err)
+	}
+}
+
 
 // Mock repository for testing error cases
 type mockUserRepository struct {
@@ -0,0 +1,36 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"time"
+	
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+)
+
+// findVisibleUser loads a user for public display. Suspended accounts and
+// accounts awaiting deletion are reported as not found so their profile and
+// content disappear.
+func findVisibleUser(ctx context.Context, userRepo repository.UserRepository, userID uint) (*domain.User, error) {
+	user, err := userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.IsSuspended(time.Now()) || user.DeletionPending() {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+// checkOwnerVisible reports notFound when the owner of a record looked up by
+// ID is hidden, so their content can't be reached directly either
+func checkOwnerVisible(ctx context.Context, userRepo repository.UserRepository, ownerID uint, notFound string) error {
+	if _, err := findVisibleUser(ctx, userRepo, ownerID); err != nil {
+		if err.Error() == "user not found" {
+			return errors.New(notFound)
+		}
+		return err
+	}
+	return nil
+}
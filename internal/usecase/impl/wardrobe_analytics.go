@@ -0,0 +1,144 @@
+package impl
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+)
+
+// valueRankingSize is how many items the best and worst value lists hold
+const valueRankingSize = 5
+
+// GetWardrobeAnalytics computes wardrobe value, cost per wear and spending for
+// the items a user priced in the given currency
+func (u *itemUsecase) GetWardrobeAnalytics(ctx context.Context, userID uint, currency string) (*usecase.WardrobeAnalytics, error) {
+	currency = strings.ToUpper(currency)
+	if currency == "" {
+		currency = u.config.App.DefaultCurrency
+	}
+
+	items, err := u.itemRepo.FindByUserID(ctx, userID, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachItemWearStats(ctx, u.wearLogRepo, items); err != nil {
+		return nil, err
+	}
+
+	analytics := &usecase.WardrobeAnalytics{
+		Currency:   currency,
+		Currencies: []string{},
+		ItemCount:  len(items),
+	}
+	currencies := make(map[string]bool)
+	monthly := make(map[string]*usecase.SpendingBucket)
+	categories := make(map[string]*usecase.SpendingBucket)
+	var worn, priced []usecase.ItemValue
+
+	for _, item := range items {
+		if item.PurchasePrice == nil {
+			continue
+		}
+		if !currencies[item.Currency] {
+			currencies[item.Currency] = true
+			analytics.Currencies = append(analytics.Currencies, item.Currency)
+		}
+		if item.Currency != currency {
+			continue
+		}
+
+		price := *item.PurchasePrice
+		analytics.PricedItemCount++
+		analytics.TotalValue += price
+		analytics.TotalWears += item.WearCount
+
+		addSpending(categories, item.SuperItem, price)
+		if item.PurchasedOn != nil {
+			addSpending(monthly, item.PurchasedOn.Format("2006-01"), price)
+		}
+
+		value := usecase.ItemValue{Item: item, CostPerWear: roundMoney(price)}
+		if item.WearCount > 0 {
+			value.CostPerWear = roundMoney(price / float64(item.WearCount))
+			worn = append(worn, value)
+		}
+		priced = append(priced, value)
+	}
+	sort.Strings(analytics.Currencies)
+
+	if analytics.TotalWears > 0 {
+		analytics.CostPerWear = roundMoney(analytics.TotalValue / float64(analytics.TotalWears))
+	}
+	analytics.TotalValue = roundMoney(analytics.TotalValue)
+
+	analytics.MonthlySpending = spendingBuckets(monthly)
+	sort.Slice(analytics.MonthlySpending, func(i, j int) bool {
+		return analytics.MonthlySpending[i].Key < analytics.MonthlySpending[j].Key
+	})
+	analytics.CategorySpending = spendingBuckets(categories)
+	sort.Slice(analytics.CategorySpending, func(i, j int) bool {
+		a, b := analytics.CategorySpending[i], analytics.CategorySpending[j]
+		if a.Amount != b.Amount {
+			return a.Amount > b.Amount
+		}
+		return a.Key < b.Key
+	})
+
+	sort.SliceStable(worn, func(i, j int) bool {
+		if worn[i].CostPerWear != worn[j].CostPerWear {
+			return worn[i].CostPerWear < worn[j].CostPerWear
+		}
+		return worn[i].Item.WearCount > worn[j].Item.WearCount
+	})
+	analytics.BestValue = topItemValues(worn)
+
+	sort.SliceStable(priced, func(i, j int) bool {
+		if priced[i].CostPerWear != priced[j].CostPerWear {
+			return priced[i].CostPerWear > priced[j].CostPerWear
+		}
+		return priced[i].Item.WearCount < priced[j].Item.WearCount
+	})
+	analytics.WorstValue = topItemValues(priced)
+
+	return analytics, nil
+}
+
+// addSpending adds a purchase to the bucket for key
+func addSpending(buckets map[string]*usecase.SpendingBucket, key string, amount float64) {
+	bucket, ok := buckets[key]
+	if !ok {
+		bucket = &usecase.SpendingBucket{Key: key}
+		buckets[key] = bucket
+	}
+	bucket.Amount += amount
+	bucket.ItemCount++
+}
+
+// spendingBuckets flattens buckets into a slice with rounded amounts
+func spendingBuckets(buckets map[string]*usecase.SpendingBucket) []usecase.SpendingBucket {
+	result := make([]usecase.SpendingBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		bucket.Amount = roundMoney(bucket.Amount)
+		result = append(result, *bucket)
+	}
+	return result
+}
+
+// topItemValues returns at most valueRankingSize entries of a ranked list
+func topItemValues(values []usecase.ItemValue) []usecase.ItemValue {
+	if len(values) > valueRankingSize {
+		values = values[:valueRankingSize]
+	}
+	if values == nil {
+		values = []usecase.ItemValue{}
+	}
+	return values
+}
+
+// roundMoney rounds an amount to two decimal places
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,229 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/internal/usecase"
+	"github.com/House-lovers7/speadwear-go/pkg/config"
+)
+
+type wearLogUsecase struct {
+	wearLogRepo    repository.WearLogRepository
+	itemRepo       repository.ItemRepository
+	coordinateRepo repository.CoordinateRepository
+	audit          usecase.AuditLogger
+	config         *config.Config
+}
+
+// NewWearLogUsecase creates a new wear log usecase
+func NewWearLogUsecase(
+	wearLogRepo repository.WearLogRepository,
+	itemRepo repository.ItemRepository,
+	coordinateRepo repository.CoordinateRepository,
+	audit usecase.AuditLogger,
+	config *config.Config,
+) usecase.WearLogUsecase {
+	return &wearLogUsecase{
+		wearLogRepo:    wearLogRepo,
+		itemRepo:       itemRepo,
+		coordinateRepo: coordinateRepo,
+		audit:          audit,
+		config:         config,
+	}
+}
+
+// LogWear records what the user wore on a day
+func (u *wearLogUsecase) LogWear(ctx context.Context, userID uint, wearLog *domain.WearLog, itemIDs []uint) error {
+	wearLog.UserID = userID
+	if wearLog.CoordinateID == nil && len(itemIDs) == 0 {
+		return errors.New("coordinate or items required")
+	}
+
+	items, err := u.wornItems(ctx, userID, wearLog.CoordinateID, itemIDs)
+	if err != nil {
+		return err
+	}
+	wearLog.Items = items
+
+	if err := u.wearLogRepo.Create(ctx, wearLog); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, userID, domain.AuditActionWearLogCreate, domain.AuditTargetWearLog, wearLog.ID, map[string]interface{}{
+		"worn_on": wearLog.WornOn.Format(domain.DateLayout),
+	})
+}
+
+// GetWearLog gets one of the user's wear logs
+func (u *wearLogUsecase) GetWearLog(ctx context.Context, userID uint, wearLogID uint) (*domain.WearLog, error) {
+	wearLog, err := u.findOwnWearLog(ctx, userID, wearLogID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.attachStats(ctx, wearLog); err != nil {
+		return nil, err
+	}
+	return wearLog, nil
+}
+
+// UpdateWearLog edits a wear log. A coordinate_id of 0 detaches the coordinate;
+// picking a new coordinate without item IDs records its current items.
+func (u *wearLogUsecase) UpdateWearLog(ctx context.Context, userID uint, wearLogID uint, updates map[string]interface{}, itemIDs []uint) error {
+	wearLog, err := u.findOwnWearLog(ctx, userID, wearLogID)
+	if err != nil {
+		return err
+	}
+
+	changes := usecase.Changes{}
+	if wornOn, ok := updates["worn_on"].(time.Time); ok {
+		changes.Track("worn_on", wearLog.WornOn.Format(domain.DateLayout), wornOn.Format(domain.DateLayout))
+		wearLog.WornOn = wornOn
+	}
+	if weather, ok := updates["weather"].(string); ok {
+		changes.Track("weather", wearLog.Weather, weather)
+		wearLog.Weather = weather
+	}
+	if mood, ok := updates["mood"].(int); ok {
+		changes.Track("mood", wearLog.Mood, mood)
+		wearLog.Mood = mood
+	}
+
+	coordinateChanged := false
+	if coordinateID, ok := updates["coordinate_id"].(uint); ok {
+		var previous uint
+		if wearLog.CoordinateID != nil {
+			previous = *wearLog.CoordinateID
+		}
+		coordinateChanged = previous != coordinateID
+		changes.Track("coordinate_id", previous, coordinateID)
+		if coordinateID == 0 {
+			wearLog.CoordinateID = nil
+		} else {
+			wearLog.CoordinateID = &coordinateID
+		}
+	}
+
+	// Re-resolve the items when they were given or the outfit changed
+	if len(itemIDs) > 0 || (coordinateChanged && wearLog.CoordinateID != nil) {
+		items, err := u.wornItems(ctx, userID, wearLog.CoordinateID, itemIDs)
+		if err != nil {
+			return err
+		}
+		wearLog.Items = items
+		changes["item_ids"] = usecase.FieldChange{To: wearLogItemIDs(items)}
+	}
+	if wearLog.CoordinateID == nil && len(wearLog.Items) == 0 {
+		return errors.New("coordinate or items required")
+	}
+
+	if err := u.wearLogRepo.Update(ctx, wearLog); err != nil {
+		return err
+	}
+	return u.audit.RecordChanges(ctx, userID, domain.AuditActionWearLogUpdate, domain.AuditTargetWearLog, wearLogID, changes)
+}
+
+// DeleteWearLog deletes one of the user's wear logs
+func (u *wearLogUsecase) DeleteWearLog(ctx context.Context, userID uint, wearLogID uint) error {
+	if _, err := u.findOwnWearLog(ctx, userID, wearLogID); err != nil {
+		return err
+	}
+	if err := u.wearLogRepo.Delete(ctx, wearLogID); err != nil {
+		return err
+	}
+	return u.audit.Record(ctx, userID, domain.AuditActionWearLogDelete, domain.AuditTargetWearLog, wearLogID, nil)
+}
+
+// ListWearLogs lists the user's wear logs within an inclusive date range
+func (u *wearLogUsecase) ListWearLogs(ctx context.Context, filter repository.WearLogFilter) ([]*domain.WearLog, int64, error) {
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return nil, 0, errors.New("invalid date range")
+	}
+
+	wearLogs, total, err := u.wearLogRepo.FindByFilters(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := u.attachStats(ctx, wearLogs...); err != nil {
+		return nil, 0, err
+	}
+	return wearLogs, total, nil
+}
+
+// findOwnWearLog loads a wear log, hiding other users' logs as not found
+func (u *wearLogUsecase) findOwnWearLog(ctx context.Context, userID, wearLogID uint) (*domain.WearLog, error) {
+	wearLog, err := u.wearLogRepo.FindByID(ctx, wearLogID)
+	if err != nil {
+		return nil, err
+	}
+	if wearLog == nil || wearLog.UserID != userID {
+		return nil, errors.New("wear log not found")
+	}
+	return wearLog, nil
+}
+
+// wornItems resolves the items worn: the given ones, or else the coordinate's
+// current items. Both must belong to the user; repeated items count once.
+func (u *wearLogUsecase) wornItems(ctx context.Context, userID uint, coordinateID *uint, itemIDs []uint) ([]domain.WearLogItem, error) {
+	if coordinateID != nil {
+		coordinate, err := u.coordinateRepo.FindWithItems(ctx, *coordinateID)
+		if err != nil {
+			return nil, err
+		}
+		if coordinate == nil {
+			return nil, errors.New("coordinate not found")
+		}
+		if coordinate.UserID != userID {
+			return nil, errors.New("unauthorized: coordinate does not belong to user")
+		}
+		if len(itemIDs) == 0 {
+			for _, link := range coordinate.Items {
+				itemIDs = append(itemIDs, link.ItemID)
+			}
+		}
+	}
+
+	items := make([]domain.WearLogItem, 0, len(itemIDs))
+	seen := make(map[uint]bool, len(itemIDs))
+	for _, itemID := range itemIDs {
+		if seen[itemID] {
+			continue
+		}
+		seen[itemID] = true
+
+		item, err := u.itemRepo.FindByID(ctx, itemID)
+		if err != nil {
+			return nil, err
+		}
+		if item == nil {
+			return nil, errors.New("item not found")
+		}
+		if item.UserID != userID {
+			return nil, errors.New("unauthorized: item does not belong to user")
+		}
+		items = append(items, domain.WearLogItem{ItemID: itemID})
+	}
+	return items, nil
+}
+
+// attachStats fills in the wear stats of the logged items
+func (u *wearLogUsecase) attachStats(ctx context.Context, wearLogs ...*domain.WearLog) error {
+	var items []*domain.Item
+	for _, wearLog := range wearLogs {
+		for i := range wearLog.Items {
+			items = append(items, &wearLog.Items[i].Item)
+		}
+	}
+	return attachItemWearStats(ctx, u.wearLogRepo, items)
+}
+
+// wearLogItemIDs lists the IDs of the items in a wear log
+func wearLogItemIDs(items []domain.WearLogItem) []uint {
+	itemIDs := make([]uint, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ItemID
+	}
+	return itemIDs
+}
@@ -0,0 +1,201 @@
+package impl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+	"github.com/House-lovers7/speadwear-go/internal/testutil"
+	"github.com/House-lovers7/speadwear-go/pkg/config"
+)
+
+func setupWearLogUsecase(t *testing.T) (*wearLogUsecase, *repository.Container, *testutil.Fixtures) {
+	db := testutil.TestDB(t)
+	repos := repository.NewContainer(db)
+
+	usecase := NewWearLogUsecase(
+		repos.WearLog,
+		repos.Item,
+		repos.Coordinate,
+		NewAuditLogger(repos.AuditEvent),
+		&config.Config{},
+	).(*wearLogUsecase)
+
+	return usecase, repos, testutil.NewFixtures(t, db)
+}
+
+// wornOn is a day in May 2024
+func wornOn(day int) time.Time {
+	return time.Date(2024, 5, day, 0, 0, 0, 0, time.Local)
+}
+
+// checkWearStats compares derived wear stats with a count and a last worn day, "" for never
+func checkWearStats(t *testing.T, what string, stats domain.WearStats, count int64, lastWornOn string) {
+	t.Helper()
+	var got string
+	if stats.LastWornAt != nil {
+		got = stats.LastWornAt.Format(domain.DateLayout)
+	}
+	if stats.WearCount != count || got != lastWornOn {
+		t.Errorf("%s worn %d times, last on %q; want %d times, last on %q", what, stats.WearCount, got, count, lastWornOn)
+	}
+}
+
+func TestWearLogUsecase_LogWear(t *testing.T) {
+	usecase, repos, fixtures := setupWearLogUsecase(t)
+	ctx := context.Background()
+
+	user := fixtures.CreateUser()
+	other := fixtures.CreateUser()
+	coordinate := fixtures.CreateCoordinate(user.ID)
+	scarf := fixtures.CreateItem(user.ID)
+	othersItem := fixtures.CreateItem(other.ID)
+	othersCoordinate := fixtures.CreateCoordinate(other.ID)
+
+	tests := []struct {
+		name         string
+		coordinateID *uint
+		itemIDs      []uint
+		wantItems    int
+		errMsg       string
+	}{
+		{"coordinate records its items", &coordinate.ID, nil, 3, ""},
+		{"items only", nil, []uint{scarf.ID, scarf.ID}, 1, ""},
+		{"nothing worn", nil, nil, 0, "coordinate or items required"},
+		{"unknown item", nil, []uint{99999}, 0, "item not found"},
+		{"another user's item", nil, []uint{scarf.ID, othersItem.ID}, 0, "unauthorized: item does not belong to user"},
+		{"another user's coordinate", &othersCoordinate.ID, nil, 0, "unauthorized: coordinate does not belong to user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wearLog := &domain.WearLog{WornOn: wornOn(1), CoordinateID: tt.coordinateID}
+			err := usecase.LogWear(ctx, user.ID, wearLog, tt.itemIDs)
+			if tt.errMsg != "" {
+				if err == nil || err.Error() != tt.errMsg {
+					t.Errorf("LogWear() error = %v, want %v", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LogWear() error = %v", err)
+			}
+
+			saved, err := usecase.GetWearLog(ctx, user.ID, wearLog.ID)
+			if err != nil {
+				t.Fatalf("GetWearLog() error = %v", err)
+			}
+			if len(saved.Items) != tt.wantItems {
+				t.Errorf("wear log has %d items, want %d", len(saved.Items), tt.wantItems)
+			}
+		})
+	}
+
+	// Rejected logs leave no trace
+	stats, _ := repos.WearLog.ItemWearStats(ctx, []uint{othersItem.ID, scarf.ID})
+	checkWearStats(t, "other user's item", stats[othersItem.ID], 0, "")
+	checkWearStats(t, "scarf", stats[scarf.ID], 1, "2024-05-01")
+}
+
+func TestWearLogUsecase_WearStats(t *testing.T) {
+	usecase, repos, fixtures := setupWearLogUsecase(t)
+	ctx := context.Background()
+
+	user := fixtures.CreateUser()
+	coordinate := fixtures.CreateCoordinate(user.ID)
+	outfit, _ := repos.Coordinate.FindWithItems(ctx, coordinate.ID)
+	shoes, tops := outfit.Items[0].ItemID, outfit.Items[2].ItemID
+	scarf := fixtures.CreateItem(user.ID)
+
+	// 1 May: the coordinate; 3 May: the scarf with the coordinate's top
+	first := &domain.WearLog{WornOn: wornOn(1), CoordinateID: &coordinate.ID}
+	if err := usecase.LogWear(ctx, user.ID, first, nil); err != nil {
+		t.Fatalf("LogWear() error = %v", err)
+	}
+	second := &domain.WearLog{WornOn: wornOn(3)}
+	if err := usecase.LogWear(ctx, user.ID, second, []uint{scarf.ID, tops}); err != nil {
+		t.Fatalf("LogWear() error = %v", err)
+	}
+
+	stats, _ := repos.WearLog.ItemWearStats(ctx, []uint{shoes, tops, scarf.ID})
+	checkWearStats(t, "shoes", stats[shoes], 1, "2024-05-01")
+	checkWearStats(t, "top", stats[tops], 2, "2024-05-03")
+	checkWearStats(t, "scarf", stats[scarf.ID], 1, "2024-05-03")
+
+	// Coordinates carry their own stats and those of their items
+	if err := attachCoordinateWearStats(ctx, repos.WearLog, []*domain.Coordinate{outfit}); err != nil {
+		t.Fatalf("attachCoordinateWearStats() error = %v", err)
+	}
+	checkWearStats(t, "coordinate", outfit.WearStats, 1, "2024-05-01")
+	checkWearStats(t, "coordinate top", outfit.Items[2].Item.WearStats, 2, "2024-05-03")
+
+	// Moving the second log to 5 May without the top
+	err := usecase.UpdateWearLog(ctx, user.ID, second.ID, map[string]interface{}{"worn_on": wornOn(5)}, []uint{scarf.ID})
+	if err != nil {
+		t.Fatalf("UpdateWearLog() error = %v", err)
+	}
+	stats, _ = repos.WearLog.ItemWearStats(ctx, []uint{tops, scarf.ID})
+	checkWearStats(t, "top", stats[tops], 1, "2024-05-01")
+	checkWearStats(t, "scarf", stats[scarf.ID], 1, "2024-05-05")
+
+	// Detaching the coordinate from the first log, keeping only the shoes
+	err = usecase.UpdateWearLog(ctx, user.ID, first.ID, map[string]interface{}{"coordinate_id": uint(0)}, []uint{shoes})
+	if err != nil {
+		t.Fatalf("UpdateWearLog() error = %v", err)
+	}
+	coordinateStats, _ := repos.WearLog.CoordinateWearStats(ctx, []uint{coordinate.ID})
+	checkWearStats(t, "coordinate", coordinateStats[coordinate.ID], 0, "")
+	stats, _ = repos.WearLog.ItemWearStats(ctx, []uint{shoes, tops})
+	checkWearStats(t, "shoes", stats[shoes], 1, "2024-05-01")
+	checkWearStats(t, "top", stats[tops], 0, "")
+
+	// Deleting a log drops it from the stats
+	if err := usecase.DeleteWearLog(ctx, user.ID, second.ID); err != nil {
+		t.Fatalf("DeleteWearLog() error = %v", err)
+	}
+	stats, _ = repos.WearLog.ItemWearStats(ctx, []uint{scarf.ID})
+	checkWearStats(t, "scarf", stats[scarf.ID], 0, "")
+}
+
+func TestWearLogUsecase_Ownership(t *testing.T) {
+	usecase, _, fixtures := setupWearLogUsecase(t)
+	ctx := context.Background()
+
+	user := fixtures.CreateUser()
+	other := fixtures.CreateUser()
+	scarf := fixtures.CreateItem(user.ID)
+	othersItem := fixtures.CreateItem(other.ID)
+
+	wearLog := &domain.WearLog{WornOn: wornOn(1)}
+	if err := usecase.LogWear(ctx, user.ID, wearLog, []uint{scarf.ID}); err != nil {
+		t.Fatalf("LogWear() error = %v", err)
+	}
+
+	// Other users' logs are reported as not found
+	if _, err := usecase.GetWearLog(ctx, other.ID, wearLog.ID); err == nil || err.Error() != "wear log not found" {
+		t.Errorf("GetWearLog() by another user error = %v", err)
+	}
+	if err := usecase.UpdateWearLog(ctx, other.ID, wearLog.ID, map[string]interface{}{"mood": 5}, nil); err == nil || err.Error() != "wear log not found" {
+		t.Errorf("UpdateWearLog() by another user error = %v", err)
+	}
+	if err := usecase.DeleteWearLog(ctx, other.ID, wearLog.ID); err == nil || err.Error() != "wear log not found" {
+		t.Errorf("DeleteWearLog() by another user error = %v", err)
+	}
+
+	// The owner can't log someone else's item either
+	err := usecase.UpdateWearLog(ctx, user.ID, wearLog.ID, nil, []uint{othersItem.ID})
+	if err == nil || err.Error() != "unauthorized: item does not belong to user" {
+		t.Errorf("UpdateWearLog() with another user's item error = %v", err)
+	}
+
+	saved, err := usecase.GetWearLog(ctx, user.ID, wearLog.ID)
+	if err != nil {
+		t.Fatalf("GetWearLog() error = %v", err)
+	}
+	if len(saved.Items) != 1 || saved.Items[0].ItemID != scarf.ID {
+		t.Errorf("wear log items changed to %+v", saved.Items)
+	}
+	checkWearStats(t, "scarf", saved.Items[0].Item.WearStats, 1, "2024-05-01")
+}
@@ -0,0 +1,50 @@
+package impl
+
+import (
+	"context"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+)
+
+// attachItemWearStats fills in how often and when each item was last worn
+func attachItemWearStats(ctx context.Context, wearLogRepo repository.WearLogRepository, items []*domain.Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+	itemIDs := make([]uint, len(items))
+	for i, item := range items {
+		itemIDs[i] = item.ID
+	}
+	stats, err := wearLogRepo.ItemWearStats(ctx, itemIDs)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		item.WearStats = stats[item.ID]
+	}
+	return nil
+}
+
+// attachCoordinateWearStats fills in the wear stats of coordinates and of the items they contain
+func attachCoordinateWearStats(ctx context.Context, wearLogRepo repository.WearLogRepository, coordinates []*domain.Coordinate) error {
+	if len(coordinates) == 0 {
+		return nil
+	}
+	coordinateIDs := make([]uint, len(coordinates))
+	var items []*domain.Item
+	for i, coordinate := range coordinates {
+		coordinateIDs[i] = coordinate.ID
+		for j := range coordinate.Items {
+			items = append(items, &coordinate.Items[j].Item)
+		}
+	}
+	stats, err := wearLogRepo.CoordinateWearStats(ctx, coordinateIDs)
+	if err != nil {
+		return err
+	}
+	for _, coordinate := range coordinates {
+		coordinate.WearStats = stats[coordinate.ID]
+	}
+	return attachItemWearStats(ctx, wearLogRepo, items)
+}
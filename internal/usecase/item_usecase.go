@@ -8,6 +8,35 @@ import (
 	"github.com/House-lovers7/speadwear-go/internal/repository"
 )
 
+// WardrobeAnalytics summarizes what a user's wardrobe cost and how much it is
+// worn, counting only items priced in Currency
+type WardrobeAnalytics struct {
+	Currency         string
+	Currencies       []string // every currency the user has priced items in
+	ItemCount        int
+	PricedItemCount  int
+	TotalValue       float64
+	TotalWears       int64
+	CostPerWear      float64          // TotalValue over TotalWears, 0 until something is worn
+	MonthlySpending  []SpendingBucket // by purchase month (YYYY-MM), oldest first
+	CategorySpending []SpendingBucket // by super item, largest first
+	BestValue        []ItemValue      // lowest cost per wear among worn items
+	WorstValue       []ItemValue      // highest cost per wear; unworn items cost their full price
+}
+
+// SpendingBucket is the amount spent on the items sharing a key
+type SpendingBucket struct {
+	Key       string
+	Amount    float64
+	ItemCount int
+}
+
+// ItemValue pairs an item with its cost per wear
+type ItemValue struct {
+	Item        *domain.Item
+	CostPerWear float64
+}
+
 // ItemUsecase defines item-related business logic
 type ItemUsecase interface {
 	// CRUD operations
@@ -15,8 +44,10 @@ type ItemUsecase interface {
 	GetItem(ctx context.Context, itemID uint) (*domain.Item, error)
 	UpdateItem(ctx context.Context, userID uint, itemID uint, updates map[string]interface{}, image *multipart.FileHeader) error
 	DeleteItem(ctx context.Context, userID uint, itemID uint) error
+	// ChangeItemStatus moves an item between lifecycle statuses, recording when
+	ChangeItemStatus(ctx context.Context, userID uint, itemID uint, status string) error
 	
-	// Listing and searching
+	// Listing and searching; listings leave out archived, donated and sold items
 	GetUserItems(ctx context.Context, userID uint, limit, offset int) ([]*domain.Item, int64, error)
 	SearchItems(ctx context.Context, filters repository.ItemFilter) ([]*domain.Item, error)
 	
@@ -25,4 +56,6 @@ type ItemUsecase interface {
 	
 	// Statistics
 	GetUserItemStatistics(ctx context.Context, userID uint) (map[string]interface{}, error)
+	// GetWardrobeAnalytics computes value and cost per wear; an empty currency uses the default
+	GetWardrobeAnalytics(ctx context.Context, userID uint, currency string) (*WardrobeAnalytics, error)
 }
\ No newline at end of file
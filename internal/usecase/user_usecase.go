@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 	
 	"github.com/House-lovers7/speadwear-go/internal/domain"
 	"github.com/House-lovers7/speadwear-go/internal/dto"
@@ -10,28 +11,62 @@ import (
 // UserUsecase defines user-related business logic
 type UserUsecase interface {
 	// Authentication
-	Login(ctx context.Context, email, password string) (*dto.AuthResponse, error)
-	Signup(ctx context.Context, req *dto.SignupRequest) (*dto.AuthResponse, error)
-	RefreshToken(ctx context.Context, tokenString string) (string, error)
+	Login(ctx context.Context, email, password string, client dto.ClientInfo) (*dto.AuthResponse, *dto.TwoFactorChallengeResponse, error)
+	LoginTwoFactor(ctx context.Context, challengeToken, code string, client dto.ClientInfo) (*dto.AuthResponse, error)
+	Signup(ctx context.Context, req *dto.SignupRequest) (*dto.UserResponse, error)
+	RefreshToken(ctx context.Context, refreshToken string, client dto.ClientInfo) (*dto.AuthResponse, error)
+	Logout(ctx context.Context, sessionID uint) error
+	
+	// Passwordless login
+	RequestMagicLink(ctx context.Context, email string) error
+	MagicLinkLogin(ctx context.Context, token string, client dto.ClientInfo) (*dto.AuthResponse, *dto.TwoFactorChallengeResponse, error)
 	
 	// User management
 	GetUser(ctx context.Context, userID uint) (*domain.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
+	// UpdateUser applies profile updates; an email change stays pending until confirmed
 	UpdateUser(ctx context.Context, userID uint, updates map[string]interface{}) error
-	DeleteUser(ctx context.Context, userID uint) error
+	// DeleteUser schedules the account to be purged once the grace period
+	// ends and returns the purge time; logging in before then cancels it
+	DeleteUser(ctx context.Context, userID uint) (time.Time, error)
 	
 	// User listing
 	ListUsers(ctx context.Context, limit, offset int) ([]*domain.User, int64, error)
 	
 	// Password management
-	ChangePassword(ctx context.Context, userID uint, oldPassword, newPassword string) error
+	ChangePassword(ctx context.Context, userID, currentSessionID uint, oldPassword, newPassword string, revokeOtherSessions bool) error
 	ResetPasswordRequest(ctx context.Context, email string) error
 	ResetPassword(ctx context.Context, token, newPassword string) error
 	
+	// Sessions
+	ListSessions(ctx context.Context, userID uint) ([]*domain.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID uint) error
+	RevokeOtherSessions(ctx context.Context, userID, currentSessionID uint) error
+	
 	// Account activation
 	ActivateAccount(ctx context.Context, token string) error
 	ResendActivationEmail(ctx context.Context, email string) error
 	
+	// Email change
+	ConfirmEmailChange(ctx context.Context, token string) error
+	CancelEmailChange(ctx context.Context, token string) error
+	
+	// Login lockout
+	UnlockAccount(ctx context.Context, token string) error
+	
+	// OpenID Connect login and linked identities
+	BeginOIDCLogin(ctx context.Context, provider string, userID *uint) (*dto.OIDCAuthorizationResponse, error)
+	OIDCLogin(ctx context.Context, provider, code, state string, client dto.ClientInfo) (*dto.AuthResponse, *dto.TwoFactorChallengeResponse, error)
+	LinkOIDCIdentity(ctx context.Context, userID uint, provider, code, state string) (*domain.UserIdentity, error)
+	ConfirmIdentityLink(ctx context.Context, token string) error
+	ListIdentities(ctx context.Context, userID uint) ([]*domain.UserIdentity, error)
+	UnlinkIdentity(ctx context.Context, userID, identityID uint) error
+	
+	// Two-factor authentication
+	EnrollTwoFactor(ctx context.Context, userID uint) (*dto.TwoFactorEnrollResponse, error)
+	VerifyTwoFactor(ctx context.Context, userID uint, code string) error
+	DisableTwoFactor(ctx context.Context, userID uint, password, code string) error
+	
 	// Profile
 	UpdateProfile(ctx context.Context, userID uint, name, picture string) error
 }
\ No newline at end of file
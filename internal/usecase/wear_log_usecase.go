@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/House-lovers7/speadwear-go/internal/domain"
+	"github.com/House-lovers7/speadwear-go/internal/repository"
+)
+
+// WearLogUsecase defines wear diary business logic. Wear logs are private to their owner.
+type WearLogUsecase interface {
+	// LogWear records a wear log; without item IDs the coordinate's current items are recorded
+	LogWear(ctx context.Context, userID uint, wearLog *domain.WearLog, itemIDs []uint) error
+	GetWearLog(ctx context.Context, userID uint, wearLogID uint) (*domain.WearLog, error)
+	UpdateWearLog(ctx context.Context, userID uint, wearLogID uint, updates map[string]interface{}, itemIDs []uint) error
+	DeleteWearLog(ctx context.Context, userID uint, wearLogID uint) error
+	ListWearLogs(ctx context.Context, filter repository.WearLogFilter) ([]*domain.WearLog, int64, error)
+}
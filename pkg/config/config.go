@@ -1,23 +1,41 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
+// defaultJWTSecret is only suitable for development
+const defaultJWTSecret = "your-secret-key-here"
+
+// insecureJWTSecrets are placeholder secrets shipped with the project
+var insecureJWTSecrets = []string{
+	defaultJWTSecret,
+	"your-super-secret-jwt-key-change-this-in-production",
+}
+
 type Config struct {
 	App      AppConfig
 	Database DatabaseConfig
 	JWT      JWTConfig
 	Upload   UploadConfig
+	Export   ExportConfig
+	Mail     MailConfig
+	Auth     AuthConfig
+	Password PasswordConfig
+	OIDC     OIDCConfig
 }
 
 type AppConfig struct {
-	Env  string
-	Port string
+	Env             string
+	Port            string
+	FrontendURL     string
+	DefaultCurrency string // ISO 4217 code for purchase prices entered without one
 }
 
 type DatabaseConfig struct {
@@ -29,8 +47,11 @@ type DatabaseConfig struct {
 }
 
 type JWTConfig struct {
-	Secret     string
-	Expiration string
+	Secret            string // HS256 secret, used only when KeysDir is empty
+	KeysDir           string // directory of <kid>.pem RS256/EdDSA keys
+	KeyID             string // kid of the signing key in KeysDir
+	Expiration        string
+	RefreshExpiration string
 }
 
 type UploadConfig struct {
@@ -38,6 +59,65 @@ type UploadConfig struct {
 	MaxFileSize int64
 }
 
+type ExportConfig struct {
+	Path           string // directory for generated export archives
+	LinkExpiration string
+	JobTimeout     string // unfinished jobs not updated for this long count as failed
+}
+
+type MailConfig struct {
+	Driver       string // smtp, file or memory
+	From         string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUser     string
+	SMTPPassword string
+	OutboxPath   string
+}
+
+type AuthConfig struct {
+	ActivationExpiration    string
+	PasswordResetExpiration string
+	PasswordResetLimit      int
+	PasswordResetWindow     string
+	LoginAttemptStore       string // db or memory
+	LoginMaxAttempts        int
+	LoginIPMaxAttempts      int
+	LoginLockoutDuration    string
+	UnlockExpiration        string
+	TwoFactorIssuer         string
+	ChallengeExpiration     string // 2FA login challenge lifetime
+	MagicLinkExpiration     string
+	MagicLinkLimit          int
+	MagicLinkWindow         string
+	EmailChangeExpiration   string // confirmation link sent to the new address
+	EmailRevertExpiration   string // cancel link sent to the old address
+	DeletionGracePeriod     string // time before a deleted account is purged; logging in cancels
+	ImpersonationExpiration string // lifetime of read-only staff impersonation tokens
+}
+
+type PasswordConfig struct {
+	MinLength          int
+	MinClasses         int    // of lowercase, uppercase, digits and symbols
+	HistorySize        int    // previous passwords that can't be reused
+	BreachedCorpusPath string // breached password hash file or range directory; empty disables the check
+}
+
+type OIDCConfig struct {
+	Providers       []OIDCProviderConfig
+	StateExpiration string
+	LinkExpiration  string
+}
+
+type OIDCProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
 func Load() (*Config, error) {
 	// .envファイルの読み込み
 	if err := godotenv.Load(); err != nil {
@@ -46,8 +126,10 @@ func Load() (*Config, error) {
 
 	config := &Config{
 		App: AppConfig{
-			Env:  getEnv("APP_ENV", "development"),
-			Port: getEnv("APP_PORT", "8080"),
+			Env:             getEnv("APP_ENV", "development"),
+			Port:            getEnv("APP_PORT", "8080"),
+			FrontendURL:     getEnv("FRONTEND_URL", "http://localhost:3000"),
+			DefaultCurrency: strings.ToUpper(getEnv("DEFAULT_CURRENCY", "JPY")),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -57,23 +139,118 @@ func Load() (*Config, error) {
 			Name:     getEnv("DB_NAME", "speadwear_dev"),
 		},
 		JWT: JWTConfig{
-			Secret:     getEnv("JWT_SECRET", "your-secret-key-here"),
-			Expiration: getEnv("JWT_EXPIRATION", "24h"),
+			Secret:            getEnv("JWT_SECRET", defaultJWTSecret),
+			KeysDir:           getEnv("JWT_KEYS_DIR", ""),
+			KeyID:             getEnv("JWT_KEY_ID", ""),
+			Expiration:        getEnv("JWT_EXPIRATION", "15m"),
+			RefreshExpiration: getEnv("JWT_REFRESH_EXPIRATION", "720h"),
 		},
 		Upload: UploadConfig{
 			Path:        getEnv("UPLOAD_PATH", "./uploads"),
 			MaxFileSize: getEnvAsInt64("MAX_UPLOAD_SIZE", 10485760), // 10MB
 		},
+		Export: ExportConfig{
+			Path:           getEnv("EXPORT_PATH", "./tmp/exports"),
+			LinkExpiration: getEnv("EXPORT_LINK_EXPIRATION", "72h"),
+			JobTimeout:     getEnv("EXPORT_JOB_TIMEOUT", "1h"),
+		},
+		Mail: MailConfig{
+			Driver:       getEnv("MAIL_DRIVER", "file"),
+			From:         getEnv("MAIL_FROM", "Speadwear <no-reply@speadwear.local>"),
+			SMTPHost:     getEnv("SMTP_HOST", "localhost"),
+			SMTPPort:     getEnv("SMTP_PORT", "587"),
+			SMTPUser:     getEnv("SMTP_USER", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			OutboxPath:   getEnv("MAIL_OUTBOX_PATH", "./tmp/mail"),
+		},
+		Auth: AuthConfig{
+			ActivationExpiration:    getEnv("ACTIVATION_EXPIRATION", "24h"),
+			PasswordResetExpiration: getEnv("PASSWORD_RESET_EXPIRATION", "2h"),
+			PasswordResetLimit:      getEnvAsInt("PASSWORD_RESET_LIMIT", 3),
+			PasswordResetWindow:     getEnv("PASSWORD_RESET_WINDOW", "1h"),
+			LoginAttemptStore:       getEnv("LOGIN_ATTEMPT_STORE", "db"),
+			LoginMaxAttempts:        getEnvAsInt("LOGIN_MAX_ATTEMPTS", 5),
+			LoginIPMaxAttempts:      getEnvAsInt("LOGIN_IP_MAX_ATTEMPTS", 50),
+			LoginLockoutDuration:    getEnv("LOGIN_LOCKOUT_DURATION", "15m"),
+			UnlockExpiration:        getEnv("UNLOCK_EXPIRATION", "24h"),
+			TwoFactorIssuer:         getEnv("TWO_FACTOR_ISSUER", "Speadwear"),
+			ChallengeExpiration:     getEnv("TWO_FACTOR_CHALLENGE_EXPIRATION", "5m"),
+			MagicLinkExpiration:     getEnv("MAGIC_LINK_EXPIRATION", "15m"),
+			MagicLinkLimit:          getEnvAsInt("MAGIC_LINK_LIMIT", 5),
+			MagicLinkWindow:         getEnv("MAGIC_LINK_WINDOW", "1h"),
+			EmailChangeExpiration:   getEnv("EMAIL_CHANGE_EXPIRATION", "24h"),
+			EmailRevertExpiration:   getEnv("EMAIL_REVERT_EXPIRATION", "168h"),
+			DeletionGracePeriod:     getEnv("ACCOUNT_DELETION_GRACE_PERIOD", "720h"),
+			ImpersonationExpiration: getEnv("IMPERSONATION_EXPIRATION", "15m"),
+		},
+		Password: PasswordConfig{
+			MinLength:          getEnvAsInt("PASSWORD_MIN_LENGTH", 8),
+			MinClasses:         getEnvAsInt("PASSWORD_MIN_CLASSES", 2),
+			HistorySize:        getEnvAsInt("PASSWORD_HISTORY_SIZE", 5),
+			BreachedCorpusPath: getEnv("PASSWORD_BREACHED_CORPUS", ""),
+		},
+		OIDC: OIDCConfig{
+			Providers:       loadOIDCProviders(),
+			StateExpiration: getEnv("OIDC_STATE_EXPIRATION", "10m"),
+			LinkExpiration:  getEnv("OIDC_LINK_EXPIRATION", "1h"),
+		},
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, err
 	}
 
 	return config, nil
 }
 
+// validate refuses settings that are unsafe to run in production
+func (c *Config) validate() error {
+	if c.App.Env != "production" || c.JWT.KeysDir != "" {
+		return nil
+	}
+	for _, secret := range insecureJWTSecrets {
+		if c.JWT.Secret == secret {
+			return errors.New("JWT_SECRET must be changed from the default in production (or set JWT_KEYS_DIR)")
+		}
+	}
+	if len(c.JWT.Secret) < 32 {
+		return errors.New("JWT_SECRET must be at least 32 characters in production")
+	}
+	return nil
+}
+
 func (d DatabaseConfig) DSN() string {
 	return fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
 		d.User, d.Password, d.Host, d.Port, d.Name)
 }
 
+// loadOIDCProviders reads OIDC_PROVIDERS (e.g. "google,line") and the
+// OIDC_<NAME>_* settings of each listed provider
+func loadOIDCProviders() []OIDCProviderConfig {
+	var providers []OIDCProviderConfig
+	for _, name := range strings.Split(getEnv("OIDC_PROVIDERS", ""), ",") {
+		name = strings.TrimSpace(strings.ToLower(name))
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		provider := OIDCProviderConfig{
+			Name:         name,
+			Issuer:       getEnv(prefix+"ISSUER", ""),
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			RedirectURL:  getEnv(prefix+"REDIRECT_URL", ""),
+			Scopes:       strings.Fields(getEnv(prefix+"SCOPES", "openid email profile")),
+		}
+		if provider.Issuer == "" || provider.ClientID == "" {
+			log.Printf("OIDC provider %s is missing issuer or client ID, skipping", name)
+			continue
+		}
+		providers = append(providers, provider)
+	}
+	return providers
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -81,6 +258,15 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvAsInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		var intValue int
+		fmt.Sscanf(value, "%d", &intValue)
+		return intValue
+	}
+	return defaultValue
+}
+
 func getEnvAsInt64(key string, defaultValue int64) int64 {
 	if value := os.Getenv(key); value != "" {
 		var intValue int64
@@ -0,0 +1,34 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/House-lovers7/speadwear-go/pkg/config"
+)
+
+// Message represents a plain text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends email messages
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// New creates a mailer for the configured driver
+func New(cfg *config.MailConfig) (Mailer, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTPMailer(cfg), nil
+	case "file":
+		return NewFileMailer(cfg.OutboxPath, cfg.From), nil
+	case "memory":
+		return NewMemoryMailer(), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver: %s", cfg.Driver)
+	}
+}
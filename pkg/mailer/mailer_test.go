@@ -0,0 +1,64 @@
+package mailer
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	msg, err := Render("activation", "user@example.com", map[string]string{
+		"Name":      "Test User",
+		"URL":       "http://localhost:3000/activate?token=abc",
+		"ExpiresIn": "24h",
+	})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	if msg.To != "user@example.com" {
+		t.Errorf("Render() to = %v, want user@example.com", msg.To)
+	}
+	if msg.Subject == "" {
+		t.Error("Render() returned empty subject")
+	}
+	if !strings.Contains(msg.Body, "http://localhost:3000/activate?token=abc") {
+		t.Error("Render() body does not contain activation URL")
+	}
+}
+
+func TestMemoryMailer(t *testing.T) {
+	m := NewMemoryMailer()
+	if m.Last() != nil {
+		t.Error("Last() should be nil before sending")
+	}
+
+	_ = m.Send(context.Background(), &Message{To: "a@example.com", Subject: "first"})
+	_ = m.Send(context.Background(), &Message{To: "b@example.com", Subject: "second"})
+
+	if len(m.Messages()) != 2 {
+		t.Errorf("Messages() length = %d, want 2", len(m.Messages()))
+	}
+	if m.Last().Subject != "second" {
+		t.Errorf("Last() subject = %v, want second", m.Last().Subject)
+	}
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := t.TempDir()
+	m := NewFileMailer(dir, "Speadwear <no-reply@example.com>")
+
+	err := m.Send(context.Background(), &Message{To: "user@example.com", Subject: "件名", Body: "本文"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Fatalf("outbox contains %d files, want 1", len(entries))
+	}
+	if !strings.HasSuffix(entries[0].Name(), "user_at_example.com.eml") {
+		t.Errorf("unexpected outbox file name: %s", entries[0].Name())
+	}
+}
@@ -0,0 +1,76 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryMailer keeps sent messages in memory (for tests)
+type MemoryMailer struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// NewMemoryMailer creates a new in-memory mailer
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+// Send stores a message
+func (m *MemoryMailer) Send(ctx context.Context, msg *Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, *msg)
+	return nil
+}
+
+// Messages returns a copy of all sent messages
+func (m *MemoryMailer) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	messages := make([]Message, len(m.messages))
+	copy(messages, m.messages)
+	return messages
+}
+
+// Last returns the most recently sent message
+func (m *MemoryMailer) Last() *Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.messages) == 0 {
+		return nil
+	}
+	msg := m.messages[len(m.messages)-1]
+	return &msg
+}
+
+// FileMailer writes messages into an outbox directory (for local development)
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer creates a new file mailer
+func NewFileMailer(dir, from string) *FileMailer {
+	return &FileMailer{dir: dir, from: from}
+}
+
+// Send writes a message to the outbox directory
+func (m *FileMailer) Send(ctx context.Context, msg *Message) error {
+	if err := os.MkdirAll(m.dir, 0755); err != nil {
+		return err
+	}
+
+	recipient := strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(msg.To)
+	filename := fmt.Sprintf("%d_%s.eml", time.Now().UnixNano(), recipient)
+
+	return os.WriteFile(filepath.Join(m.dir, filename), buildRFC822(m.from, msg), 0644)
+}
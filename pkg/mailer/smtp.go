@@ -0,0 +1,67 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net/mail"
+	"net/smtp"
+	"time"
+
+	"github.com/House-lovers7/speadwear-go/pkg/config"
+)
+
+// SMTPMailer sends messages through an SMTP server
+type SMTPMailer struct {
+	host     string
+	port     string
+	user     string
+	password string
+	from     string
+}
+
+// NewSMTPMailer creates a new SMTP mailer
+func NewSMTPMailer(cfg *config.MailConfig) *SMTPMailer {
+	return &SMTPMailer{
+		host:     cfg.SMTPHost,
+		port:     cfg.SMTPPort,
+		user:     cfg.SMTPUser,
+		password: cfg.SMTPPassword,
+		from:     cfg.From,
+	}
+}
+
+// Send sends a message
+func (m *SMTPMailer) Send(ctx context.Context, msg *Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	from, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return fmt.Errorf("invalid from address: %w", err)
+	}
+
+	var auth smtp.Auth
+	if m.user != "" {
+		auth = smtp.PlainAuth("", m.user, m.password, m.host)
+	}
+
+	return smtp.SendMail(m.host+":"+m.port, auth, from.Address, []string{msg.To}, buildRFC822(m.from, msg))
+}
+
+// buildRFC822 renders a message with headers suitable for SMTP delivery
+func buildRFC822(from string, msg *Message) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(msg.Body)
+	return buf.Bytes()
+}
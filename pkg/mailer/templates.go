@@ -0,0 +1,31 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+// Render builds a message from a named template.
+// Each template defines "<name>_subject" and "<name>_body".
+func Render(name, to string, data interface{}) (*Message, error) {
+	var subject, body bytes.Buffer
+	if err := templates.ExecuteTemplate(&subject, name+"_subject", data); err != nil {
+		return nil, err
+	}
+	if err := templates.ExecuteTemplate(&body, name+"_body", data); err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		To:      to,
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    body.String(),
+	}, nil
+}
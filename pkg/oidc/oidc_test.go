@@ -0,0 +1,67 @@
+package oidc_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/House-lovers7/speadwear-go/pkg/oidc"
+	"github.com/House-lovers7/speadwear-go/pkg/oidc/oidctest"
+)
+
+func TestClient_AuthorizationCodeFlow(t *testing.T) {
+	provider := oidctest.NewServer("speadwear")
+	defer provider.Close()
+
+	ctx := context.Background()
+	client := oidc.NewClient(oidc.Config{
+		Issuer:      provider.Issuer(),
+		ClientID:    "speadwear",
+		RedirectURL: "http://localhost:3000/auth/callback",
+	}, nil)
+
+	verifier, _ := oidc.GenerateCodeVerifier()
+	authURL, err := client.AuthCodeURL(ctx, "state-1", "nonce-1", verifier)
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+	parsed, _ := url.Parse(authURL)
+	if parsed.Query().Get("code_challenge") != oidc.CodeChallenge(verifier) {
+		t.Error("AuthCodeURL() should carry the S256 code challenge")
+	}
+
+	user := oidctest.User{Subject: "sub-1", Email: "user@example.com", EmailVerified: true, Name: "User"}
+	code, state, err := provider.Approve(authURL, user)
+	if err != nil || state != "state-1" {
+		t.Fatalf("Approve() = %s, %v", state, err)
+	}
+
+	t.Run("wrong verifier is rejected", func(t *testing.T) {
+		code, _, _ := provider.Approve(authURL, user)
+		if _, err := client.Exchange(ctx, code, "wrong-verifier"); err == nil {
+			t.Error("Exchange() should fail without the matching code verifier")
+		}
+	})
+
+	rawIDToken, err := client.Exchange(ctx, code, verifier)
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+
+	idToken, err := client.VerifyIDToken(ctx, rawIDToken, "nonce-1")
+	if err != nil {
+		t.Fatalf("VerifyIDToken() error = %v", err)
+	}
+	if idToken.Subject != "sub-1" || idToken.Email != "user@example.com" || !idToken.EmailVerified {
+		t.Errorf("VerifyIDToken() = %+v", idToken)
+	}
+
+	if _, err := client.VerifyIDToken(ctx, rawIDToken, "other-nonce"); err == nil {
+		t.Error("VerifyIDToken() should reject a nonce mismatch")
+	}
+
+	foreign, _ := provider.SignIDToken(user, "nonce-1", "another-client")
+	if _, err := client.VerifyIDToken(ctx, foreign, "nonce-1"); err == nil {
+		t.Error("VerifyIDToken() should reject tokens for another audience")
+	}
+}
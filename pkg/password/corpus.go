@@ -0,0 +1,97 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// prefixLength is the number of hash characters that select a range, as in
+// the Pwned Passwords k-anonymity model
+const prefixLength = 5
+
+// Corpus is a local list of breached passwords stored as SHA-1 hashes. It is
+// either a directory of range files (<PREFIX>.txt holding "SUFFIX:COUNT"
+// lines) read on demand, or a single file of "HASH:COUNT" lines loaded into
+// memory. Passwords are never sent anywhere.
+type Corpus struct {
+	dir    string
+	hashes map[string]map[string]bool // prefix -> suffixes, for a single file
+}
+
+// LoadCorpus opens a breached password corpus at path
+func LoadCorpus(path string) (*Corpus, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return &Corpus{dir: path}, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	corpus := &Corpus{hashes: make(map[string]map[string]bool)}
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		hash := parseHash(scanner.Text())
+		if hash == "" {
+			continue
+		}
+		if len(hash) != sha1.Size*2 {
+			return nil, fmt.Errorf("%s:%d: invalid SHA-1 hash", path, line)
+		}
+		prefix, suffix := hash[:prefixLength], hash[prefixLength:]
+		if corpus.hashes[prefix] == nil {
+			corpus.hashes[prefix] = make(map[string]bool)
+		}
+		corpus.hashes[prefix][suffix] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return corpus, nil
+}
+
+// Contains reports whether the password appears in the corpus
+func (c *Corpus) Contains(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:prefixLength], hash[prefixLength:]
+
+	if c.hashes != nil {
+		return c.hashes[prefix][suffix], nil
+	}
+
+	// Only the range for this prefix is read
+	file, err := os.Open(filepath.Join(c.dir, prefix+".txt"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if parseHash(scanner.Text()) == suffix {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+// parseHash returns the upper-case hash of a "HASH:COUNT" line
+func parseHash(line string) string {
+	hash, _, _ := strings.Cut(strings.TrimSpace(line), ":")
+	return strings.ToUpper(hash)
+}
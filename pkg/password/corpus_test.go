@@ -0,0 +1,79 @@
+package password
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func sha1Hex(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// writeCorpusFile writes a single-file corpus of "HASH:COUNT" lines
+func writeCorpusFile(t *testing.T, passwords ...string) string {
+	t.Helper()
+	var lines []string
+	for _, password := range passwords {
+		lines = append(lines, sha1Hex(password)+":42")
+	}
+	path := filepath.Join(t.TempDir(), "pwned.txt")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCorpusFile(t *testing.T) {
+	corpus, err := LoadCorpus(writeCorpusFile(t, "password", "123456"))
+	if err != nil {
+		t.Fatalf("LoadCorpus() error = %v", err)
+	}
+
+	for password, want := range map[string]bool{"password": true, "123456": true, "Password": false} {
+		got, err := corpus.Contains(password)
+		if err != nil {
+			t.Fatalf("Contains() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("Contains(%q) = %v, want %v", password, got, want)
+		}
+	}
+}
+
+func TestCorpusRangeDirectory(t *testing.T) {
+	dir := t.TempDir()
+	hash := sha1Hex("letmein")
+	// Range files hold only suffixes; other entries share the prefix
+	content := "0000000000000000000000000000000000A:1\r\n" + strings.ToLower(hash[prefixLength:]) + ":3\r\n"
+	if err := os.WriteFile(filepath.Join(dir, hash[:prefixLength]+".txt"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	corpus, err := LoadCorpus(dir)
+	if err != nil {
+		t.Fatalf("LoadCorpus() error = %v", err)
+	}
+	if found, err := corpus.Contains("letmein"); err != nil || !found {
+		t.Errorf("Contains(letmein) = %v, %v, want true", found, err)
+	}
+	// A prefix without a range file is not breached
+	if found, err := corpus.Contains("a much longer unusual passphrase"); err != nil || found {
+		t.Errorf("Contains() = %v, %v, want false", found, err)
+	}
+}
+
+func TestLoadCorpusInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.txt")
+	os.WriteFile(path, []byte("not-a-hash:1\n"), 0644)
+	if _, err := LoadCorpus(path); err == nil {
+		t.Error("LoadCorpus() should reject malformed hashes")
+	}
+	if _, err := LoadCorpus(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("LoadCorpus() should fail for a missing path")
+	}
+}
@@ -0,0 +1,74 @@
+package password
+
+import (
+	"errors"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Errors returned for passwords that break the policy
+var (
+	ErrTooShort  = errors.New("password is too short")
+	ErrTooSimple = errors.New("password needs more character types")
+	ErrReused    = errors.New("password was used recently")
+	ErrBreached  = errors.New("password has appeared in a data breach")
+)
+
+// Policy describes the rules a new password must satisfy
+type Policy struct {
+	MinLength  int
+	MinClasses int     // of lowercase, uppercase, digits and symbols
+	Corpus     *Corpus // breached passwords; nil disables the check
+}
+
+// Validate checks a new password against the policy
+func (p *Policy) Validate(password string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return ErrTooShort
+	}
+	if characterClasses(password) < p.MinClasses {
+		return ErrTooSimple
+	}
+
+	if p.Corpus != nil {
+		breached, err := p.Corpus.Contains(password)
+		if err != nil {
+			return err
+		}
+		if breached {
+			return ErrBreached
+		}
+	}
+	return nil
+}
+
+// IsPolicyError reports whether err means the password was rejected
+func IsPolicyError(err error) bool {
+	return errors.Is(err, ErrTooShort) || errors.Is(err, ErrTooSimple) ||
+		errors.Is(err, ErrReused) || errors.Is(err, ErrBreached)
+}
+
+// characterClasses counts the kinds of characters used in a password
+func characterClasses(password string) int {
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	count := 0
+	for _, used := range []bool{lower, upper, digit, symbol} {
+		if used {
+			count++
+		}
+	}
+	return count
+}
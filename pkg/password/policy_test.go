@@ -0,0 +1,45 @@
+package password
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPolicyValidate(t *testing.T) {
+	policy := &Policy{MinLength: 10, MinClasses: 3}
+
+	tests := []struct {
+		password string
+		want     error
+	}{
+		{"Sh0rt!", ErrTooShort},
+		{"alllowercaseletters", ErrTooSimple},
+		{"lowercase123456", ErrTooSimple},
+		{"Lowercase123456", nil},
+		{"パスワード-Pass-2024", nil},
+	}
+
+	for _, tt := range tests {
+		if err := policy.Validate(tt.password); !errors.Is(err, tt.want) {
+			t.Errorf("Validate(%q) = %v, want %v", tt.password, err, tt.want)
+		}
+	}
+}
+
+func TestPolicyValidateBreached(t *testing.T) {
+	corpus, err := LoadCorpus(writeCorpusFile(t, "Password123"))
+	if err != nil {
+		t.Fatalf("LoadCorpus() error = %v", err)
+	}
+	policy := &Policy{MinLength: 8, MinClasses: 2, Corpus: corpus}
+
+	if err := policy.Validate("Password123"); err != ErrBreached {
+		t.Errorf("Validate() = %v, want %v", err, ErrBreached)
+	}
+	if err := policy.Validate("Correct-Horse-9"); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+	if !IsPolicyError(ErrBreached) || IsPolicyError(errors.New("database is down")) {
+		t.Error("IsPolicyError() misclassified an error")
+	}
+}
@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter decides whether an action identified by key is allowed
+type Limiter interface {
+	Allow(key string) bool
+}
+
+type window struct {
+	start time.Time
+	count int
+}
+
+// MemoryLimiter is a fixed-window rate limiter kept in process memory
+type MemoryLimiter struct {
+	mu      sync.Mutex
+	limit   int
+	period  time.Duration
+	windows map[string]*window
+	now     func() time.Time
+}
+
+// NewMemoryLimiter creates a limiter allowing limit actions per period for each key
+func NewMemoryLimiter(limit int, period time.Duration) *MemoryLimiter {
+	return &MemoryLimiter{
+		limit:   limit,
+		period:  period,
+		windows: make(map[string]*window),
+		now:     time.Now,
+	}
+}
+
+// Allow records an action for key and reports whether it is within the limit
+func (l *MemoryLimiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.cleanup(now)
+
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= l.period {
+		l.windows[key] = &window{start: now, count: 1}
+		return true
+	}
+
+	if w.count >= l.limit {
+		return false
+	}
+	w.count++
+	return true
+}
+
+// cleanup removes expired windows so the map does not grow without bound
+func (l *MemoryLimiter) cleanup(now time.Time) {
+	for key, w := range l.windows {
+		if now.Sub(w.start) >= l.period {
+			delete(l.windows, key)
+		}
+	}
+}
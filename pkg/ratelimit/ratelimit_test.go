@@ -0,0 +1,31 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryLimiter_Allow(t *testing.T) {
+	now := time.Now()
+	limiter := NewMemoryLimiter(2, time.Hour)
+	limiter.now = func() time.Time { return now }
+
+	if !limiter.Allow("user@example.com") {
+		t.Error("first request should be allowed")
+	}
+	if !limiter.Allow("user@example.com") {
+		t.Error("second request should be allowed")
+	}
+	if limiter.Allow("user@example.com") {
+		t.Error("third request should be rejected")
+	}
+	if !limiter.Allow("other@example.com") {
+		t.Error("requests for other keys should be allowed")
+	}
+
+	// Move past the window
+	now = now.Add(time.Hour)
+	if !limiter.Allow("user@example.com") {
+		t.Error("request after window should be allowed")
+	}
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Storage stores uploaded files
+type Storage interface {
+	// Save stores the file under folder and returns its relative filename
+	Save(file *multipart.FileHeader, folder string) (string, error)
+	// Delete removes a previously saved file
+	Delete(filename string) error
+}
+
+// LocalStorage stores files on the local filesystem
+type LocalStorage struct {
+	basePath    string
+	maxFileSize int64
+}
+
+// NewLocalStorage creates a storage rooted at basePath
+func NewLocalStorage(basePath string, maxFileSize int64) *LocalStorage {
+	return &LocalStorage{
+		basePath:    basePath,
+		maxFileSize: maxFileSize,
+	}
+}
+
+// Save stores an uploaded file
+func (s *LocalStorage) Save(file *multipart.FileHeader, folder string) (string, error) {
+	// Check file size
+	if s.maxFileSize > 0 && file.Size > s.maxFileSize {
+		return "", errors.New("file size exceeds limit")
+	}
+
+	// Open file
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	// Generate unique filename
+	ext := filepath.Ext(file.Filename)
+	filename := fmt.Sprintf("%s/%d_%d%s", folder, time.Now().Unix(), time.Now().Nanosecond(), ext)
+
+	// Create upload directory if not exists
+	if err := os.MkdirAll(filepath.Join(s.basePath, folder), 0755); err != nil {
+		return "", err
+	}
+
+	// Create destination file
+	dst, err := os.Create(filepath.Join(s.basePath, filename))
+	if err != nil {
+		return "", err
+	}
+	defer dst.Close()
+
+	// Copy file
+	if _, err = io.Copy(dst, src); err != nil {
+		return "", err
+	}
+
+	return filename, nil
+}
+
+// Delete removes a stored file. Missing files are not an error.
+func (s *LocalStorage) Delete(filename string) error {
+	if filename == "" {
+		return nil
+	}
+
+	err := os.Remove(filepath.Join(s.basePath, filepath.Clean("/"+filename)))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newFileHeader(t *testing.T, filename string, content []byte) *multipart.FileHeader {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("image", filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(content)
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if err := req.ParseMultipartForm(1 << 20); err != nil {
+		t.Fatal(err)
+	}
+	return req.MultipartForm.File["image"][0]
+}
+
+func TestLocalStorage_SaveAndDelete(t *testing.T) {
+	dir := t.TempDir()
+	s := NewLocalStorage(dir, 1024)
+
+	filename, err := s.Save(newFileHeader(t, "photo.jpg", []byte("image-data")), "items")
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if filepath.Dir(filename) != "items" || filepath.Ext(filename) != ".jpg" {
+		t.Errorf("Save() filename = %s", filename)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, filename))
+	if err != nil || string(data) != "image-data" {
+		t.Fatalf("stored file = %q, %v", data, err)
+	}
+
+	if err := s.Delete(filename); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, filename)); !os.IsNotExist(err) {
+		t.Error("Delete() did not remove the file")
+	}
+
+	// Deleting again is a no-op
+	if err := s.Delete(filename); err != nil {
+		t.Errorf("Delete() of missing file error = %v", err)
+	}
+}
+
+func TestLocalStorage_SaveTooLarge(t *testing.T) {
+	s := NewLocalStorage(t.TempDir(), 4)
+
+	if _, err := s.Save(newFileHeader(t, "photo.jpg", []byte("too large")), "items"); err == nil {
+		t.Error("Save() should reject files over the size limit")
+	}
+}
@@ -0,0 +1,62 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 appendix B test secret ("12345678901234567890")
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		got, err := Code(rfcSecret, Step(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("Code() error = %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("Code(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	code, _ := Code(rfcSecret, Step(now))
+
+	if step, ok := Validate(rfcSecret, code, now); !ok || step != Step(now) {
+		t.Errorf("Validate() = %d, %v, want current step", step, ok)
+	}
+	if _, ok := Validate(rfcSecret, code, now.Add(Period)); !ok {
+		t.Error("Validate() should accept the previous step")
+	}
+	if _, ok := Validate(rfcSecret, code, now.Add(3*Period)); ok {
+		t.Error("Validate() should reject codes outside the skew")
+	}
+	if _, ok := Validate(rfcSecret, "12345", now); ok {
+		t.Error("Validate() should reject short codes")
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("Speadwear", "user@example.com", "ABC")
+	if !strings.HasPrefix(uri, "otpauth://totp/Speadwear:user@example.com?") {
+		t.Errorf("URI() = %s", uri)
+	}
+	if !strings.Contains(uri, "secret=ABC") || !strings.Contains(uri, "issuer=Speadwear") {
+		t.Errorf("URI() = %s, missing parameters", uri)
+	}
+}
@@ -1,63 +1,32 @@
 package utils
 
 import (
-	"errors"
-	"time"
-
 	"github.com/golang-jwt/jwt/v5"
 )
 
 type Claims struct {
-	UserID uint   `json:"user_id"`
-	Email  string `json:"email"`
+	UserID    uint   `json:"user_id"`
+	Email     string `json:"email"`
+	SessionID uint   `json:"sid,omitempty"`
+	// Purpose marks special-use tokens (e.g. a 2FA login challenge) that must
+	// not be accepted as access tokens
+	Purpose   string `json:"purpose,omitempty"`
+	// ImpersonatorID marks a read-only token issued to staff acting as the
+	// user; SessionID then refers to the impersonator's session
+	ImpersonatorID uint `json:"impersonator_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
 func GenerateToken(userID uint, email string, secret string, expiration string) (string, error) {
-	duration, err := time.ParseDuration(expiration)
-	if err != nil {
-		return "", err
-	}
-
-	claims := &Claims{
-		UserID: userID,
-		Email:  email,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(duration)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
-		},
-	}
+	return GenerateTokenWithClaims(&Claims{UserID: userID, Email: email}, secret, expiration)
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
+// GenerateTokenWithClaims signs the given claims with an HS256 secret, setting the registered time claims
+func GenerateTokenWithClaims(claims *Claims, secret string, expiration string) (string, error) {
+	return NewHMACKeySet(secret).Sign(claims, expiration)
 }
 
+// ValidateToken verifies an HS256 token signed with secret
 func ValidateToken(tokenString string, secret string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return []byte(secret), nil
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		return claims, nil
-	}
-
-	return nil, errors.New("invalid token")
+	return NewHMACKeySet(secret).Validate(tokenString)
 }
-
-func RefreshToken(tokenString string, secret string, expiration string) (string, error) {
-	claims, err := ValidateToken(tokenString, secret)
-	if err != nil {
-		return "", err
-	}
-
-	// Generate new token with same claims but new expiration
-	return GenerateToken(claims.UserID, claims.Email, secret, expiration)
-}
\ No newline at end of file
@@ -0,0 +1,254 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SigningKey is a JWT key identified by its kid. Keys without a private part
+// can only verify tokens, which is how retired keys are kept during rotation.
+type SigningKey struct {
+	ID         string
+	Method     jwt.SigningMethod
+	PrivateKey crypto.PrivateKey // nil for verification-only keys
+	PublicKey  crypto.PublicKey
+}
+
+// KeySet signs tokens with its active key and verifies tokens against every key
+type KeySet struct {
+	active *SigningKey
+	keys   map[string]*SigningKey
+}
+
+// JSONWebKey is the public part of a key as published in a JWKS document
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+// JSONWebKeySet is a JWKS document
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// NewHMACKeySet returns a key set that signs and verifies with a shared HS256 secret
+func NewHMACKeySet(secret string) *KeySet {
+	key := &SigningKey{
+		Method:     jwt.SigningMethodHS256,
+		PrivateKey: []byte(secret),
+		PublicKey:  []byte(secret),
+	}
+	return &KeySet{active: key, keys: map[string]*SigningKey{"": key}}
+}
+
+// NewKeySet builds a key set that signs with the key activeID and verifies with all keys
+func NewKeySet(activeID string, keys ...*SigningKey) (*KeySet, error) {
+	set := &KeySet{keys: make(map[string]*SigningKey, len(keys))}
+	for _, key := range keys {
+		if key.ID == "" {
+			return nil, errors.New("signing key without kid")
+		}
+		if _, exists := set.keys[key.ID]; exists {
+			return nil, fmt.Errorf("duplicate kid %q", key.ID)
+		}
+		set.keys[key.ID] = key
+	}
+
+	active, ok := set.keys[activeID]
+	if !ok {
+		return nil, fmt.Errorf("active key %q not found", activeID)
+	}
+	if active.PrivateKey == nil {
+		return nil, fmt.Errorf("active key %q has no private key", activeID)
+	}
+	set.active = active
+	return set, nil
+}
+
+// LoadKeySet reads every <kid>.pem file in dir. Files may hold a PKCS#8
+// private key or a PKIX public key (RSA or Ed25519). When activeID is empty
+// the directory must contain exactly one private key.
+func LoadKeySet(dir, activeID string) (*KeySet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no keys found in %s", dir)
+	}
+	sort.Strings(paths)
+
+	keys := make([]*SigningKey, 0, len(paths))
+	var privateIDs []string
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := ParseSigningKey(strings.TrimSuffix(filepath.Base(path), ".pem"), data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if key.PrivateKey != nil {
+			privateIDs = append(privateIDs, key.ID)
+		}
+		keys = append(keys, key)
+	}
+
+	if activeID == "" {
+		if len(privateIDs) != 1 {
+			return nil, errors.New("active key id required when the key directory holds more than one private key")
+		}
+		activeID = privateIDs[0]
+	}
+	return NewKeySet(activeID, keys...)
+}
+
+// ParseSigningKey parses a PEM encoded private or public key
+func ParseSigningKey(id string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	key := &SigningKey{ID: id}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key.PrivateKey = parsed
+		switch private := parsed.(type) {
+		case *rsa.PrivateKey:
+			key.PublicKey = &private.PublicKey
+		case ed25519.PrivateKey:
+			key.PublicKey = private.Public()
+		}
+	case "RSA PRIVATE KEY":
+		parsed, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key.PrivateKey = parsed
+		key.PublicKey = &parsed.PublicKey
+	case "PUBLIC KEY":
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key.PublicKey = parsed
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+
+	switch key.PublicKey.(type) {
+	case *rsa.PublicKey:
+		key.Method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+	default:
+		return nil, errors.New("unsupported key type, expected RSA or Ed25519")
+	}
+	return key, nil
+}
+
+// Sign signs the claims with the active key, setting the registered time claims
+func (s *KeySet) Sign(claims *Claims, expiration string) (string, error) {
+	duration, err := time.ParseDuration(expiration)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(duration))
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.NotBefore = jwt.NewNumericDate(now)
+
+	token := jwt.NewWithClaims(s.active.Method, claims)
+	if s.active.ID != "" {
+		token.Header["kid"] = s.active.ID
+	}
+	return token.SignedString(s.active.PrivateKey)
+}
+
+// Validate verifies a token against the key named by its kid header
+func (s *KeySet) Validate(tokenString string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := s.keys[kid]
+		if !ok {
+			return nil, errors.New("unknown signing key")
+		}
+		// The algorithm is bound to the key, never taken from the token alone
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, errors.New("unexpected signing method")
+		}
+		return key.PublicKey, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
+		return claims, nil
+	}
+
+	return nil, errors.New("invalid token")
+}
+
+// JWKS returns the public keys for publishing; shared secrets are never included
+func (s *KeySet) JWKS() JSONWebKeySet {
+	ids := make([]string, 0, len(s.keys))
+	for id := range s.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	set := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, id := range ids {
+		key := s.keys[id]
+		switch public := key.PublicKey.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JSONWebKey{
+				KeyType:   "RSA",
+				KeyID:     key.ID,
+				Use:       "sig",
+				Algorithm: key.Method.Alg(),
+				N:         base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+				E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JSONWebKey{
+				KeyType:   "OKP",
+				KeyID:     key.ID,
+				Use:       "sig",
+				Algorithm: key.Method.Alg(),
+				Curve:     "Ed25519",
+				X:         base64.RawURLEncoding.EncodeToString(public),
+			})
+		}
+	}
+	return set
+}
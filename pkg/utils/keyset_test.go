@@ -0,0 +1,203 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newRSAKey(t *testing.T, id string) *SigningKey {
+	t.Helper()
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+	return &SigningKey{ID: id, Method: jwt.SigningMethodRS256, PrivateKey: private, PublicKey: &private.PublicKey}
+}
+
+func newEd25519Key(t *testing.T, id string) *SigningKey {
+	t.Helper()
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() error = %v", err)
+	}
+	return &SigningKey{ID: id, Method: jwt.SigningMethodEdDSA, PrivateKey: private, PublicKey: public}
+}
+
+func TestKeySetSignAndValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		key  *SigningKey
+	}{
+		{"RS256", newRSAKey(t, "rsa-1")},
+		{"EdDSA", newEd25519Key(t, "ed-1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := NewKeySet(tt.key.ID, tt.key)
+			if err != nil {
+				t.Fatalf("NewKeySet() error = %v", err)
+			}
+
+			token, err := keys.Sign(&Claims{UserID: 7, Email: "test@example.com", SessionID: 3}, "15m")
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &Claims{})
+			if err != nil {
+				t.Fatalf("ParseUnverified() error = %v", err)
+			}
+			if parsed.Header["kid"] != tt.key.ID || parsed.Header["alg"] != tt.name {
+				t.Errorf("header = %v, want kid %s and alg %s", parsed.Header, tt.key.ID, tt.name)
+			}
+
+			claims, err := keys.Validate(token)
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if claims.UserID != 7 || claims.SessionID != 3 {
+				t.Errorf("Validate() claims = %+v", claims)
+			}
+		})
+	}
+}
+
+func TestKeySetRotation(t *testing.T) {
+	oldKey := newRSAKey(t, "2024-01")
+	newKey := newEd25519Key(t, "2024-02")
+
+	oldKeys, err := NewKeySet(oldKey.ID, oldKey)
+	if err != nil {
+		t.Fatalf("NewKeySet() error = %v", err)
+	}
+	oldToken, err := oldKeys.Sign(&Claims{UserID: 1}, "15m")
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	// After rotation the old key only verifies
+	retired := &SigningKey{ID: oldKey.ID, Method: oldKey.Method, PublicKey: oldKey.PublicKey}
+	rotated, err := NewKeySet(newKey.ID, newKey, retired)
+	if err != nil {
+		t.Fatalf("NewKeySet() error = %v", err)
+	}
+	if _, err := rotated.Validate(oldToken); err != nil {
+		t.Errorf("Validate(old token) error = %v", err)
+	}
+
+	newToken, err := rotated.Sign(&Claims{UserID: 1}, "15m")
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if _, err := oldKeys.Validate(newToken); err == nil {
+		t.Error("Validate() should reject a token signed with an unknown kid")
+	}
+
+	if _, err := NewKeySet(oldKey.ID, newKey, retired); err == nil {
+		t.Error("NewKeySet() should refuse a verification-only active key")
+	}
+}
+
+func TestKeySetRejectsAlgorithmConfusion(t *testing.T) {
+	key := newRSAKey(t, "rsa-1")
+	keys, err := NewKeySet(key.ID, key)
+	if err != nil {
+		t.Fatalf("NewKeySet() error = %v", err)
+	}
+
+	// An HS256 token keyed with the published public key must not verify
+	publicDER, _ := x509.MarshalPKIXPublicKey(key.PublicKey)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{UserID: 1})
+	forged.Header["kid"] = key.ID
+	forgedString, err := forged.SignedString(publicDER)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+
+	if _, err := keys.Validate(forgedString); err == nil {
+		t.Error("Validate() should reject a token whose alg does not match the key")
+	}
+
+	// HS256 secrets are never accepted by an asymmetric key set
+	hmacToken, _ := GenerateTokenWithClaims(&Claims{UserID: 1}, "secret", "15m")
+	if _, err := keys.Validate(hmacToken); err == nil {
+		t.Error("Validate() should reject HS256 tokens")
+	}
+}
+
+func TestKeySetJWKS(t *testing.T) {
+	rsaKey := newRSAKey(t, "a")
+	edKey := newEd25519Key(t, "b")
+	keys, err := NewKeySet(rsaKey.ID, rsaKey, edKey)
+	if err != nil {
+		t.Fatalf("NewKeySet() error = %v", err)
+	}
+
+	set := keys.JWKS()
+	if len(set.Keys) != 2 {
+		t.Fatalf("JWKS() returned %d keys, want 2", len(set.Keys))
+	}
+	if set.Keys[0].KeyType != "RSA" || set.Keys[0].Algorithm != "RS256" || set.Keys[0].E != "AQAB" {
+		t.Errorf("JWKS() RSA key = %+v", set.Keys[0])
+	}
+	if set.Keys[1].KeyType != "OKP" || set.Keys[1].Curve != "Ed25519" || set.Keys[1].X == "" {
+		t.Errorf("JWKS() Ed25519 key = %+v", set.Keys[1])
+	}
+
+	if len(NewHMACKeySet("secret").JWKS().Keys) != 0 {
+		t.Error("JWKS() must not publish HMAC secrets")
+	}
+}
+
+func TestLoadKeySet(t *testing.T) {
+	dir := t.TempDir()
+
+	active := newEd25519Key(t, "current")
+	privateDER, _ := x509.MarshalPKCS8PrivateKey(active.PrivateKey)
+	writePEM(t, filepath.Join(dir, "current.pem"), "PRIVATE KEY", privateDER)
+
+	previous := newRSAKey(t, "previous")
+	publicDER, _ := x509.MarshalPKIXPublicKey(previous.PublicKey)
+	writePEM(t, filepath.Join(dir, "previous.pem"), "PUBLIC KEY", publicDER)
+
+	keys, err := LoadKeySet(dir, "")
+	if err != nil {
+		t.Fatalf("LoadKeySet() error = %v", err)
+	}
+
+	token, err := keys.Sign(&Claims{UserID: 1}, "15m")
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	parsed, _, _ := jwt.NewParser().ParseUnverified(token, &Claims{})
+	if parsed.Header["kid"] != "current" {
+		t.Errorf("Sign() kid = %v, want current", parsed.Header["kid"])
+	}
+	if len(keys.JWKS().Keys) != 2 {
+		t.Errorf("JWKS() returned %d keys, want 2", len(keys.JWKS().Keys))
+	}
+
+	if _, err := LoadKeySet(dir, "previous"); err == nil {
+		t.Error("LoadKeySet() should refuse a public key as the active key")
+	}
+	if _, err := LoadKeySet(t.TempDir(), ""); err == nil {
+		t.Error("LoadKeySet() should fail for an empty directory")
+	}
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestGenerateSecureToken(t *testing.T) {
+	token1, err := GenerateSecureToken(32)
+	if err != nil {
+		t.Fatalf("GenerateSecureToken() error = %v", err)
+	}
+	token2, err := GenerateSecureToken(32)
+	if err != nil {
+		t.Fatalf("GenerateSecureToken() error = %v", err)
+	}
+
+	if token1 == token2 {
+		t.Error("GenerateSecureToken() should generate unique tokens")
+	}
+	if len(token1) != 43 {
+		t.Errorf("GenerateSecureToken() length = %d, want 43", len(token1))
+	}
+}
+
+func TestCompareTokenHash(t *testing.T) {
+	token := "activation-token"
+	digest := HashToken(token)
+
+	tests := []struct {
+		name   string
+		token  string
+		digest string
+		want   bool
+	}{
+		{
+			name:   "matching token",
+			token:  token,
+			digest: digest,
+			want:   true,
+		},
+		{
+			name:   "wrong token",
+			token:  "other-token",
+			digest: digest,
+			want:   false,
+		},
+		{
+			name:   "empty token",
+			token:  "",
+			digest: HashToken(""),
+			want:   false,
+		},
+		{
+			name:   "empty digest",
+			token:  token,
+			digest: "",
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareTokenHash(tt.token, tt.digest); got != tt.want {
+				t.Errorf("CompareTokenHash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}